@@ -38,3 +38,10 @@ bash "${CODEGEN_PKG}/generate-internal-groups.sh" "deepcopy,defaulter,conversion
   "proxies:v1alpha1" \
   --output-base "$(dirname "${BASH_SOURCE[0]}")/../../../.." \
   --go-header-file "${SCRIPT_ROOT}/hack/boilerplate.go.txt"
+
+bash "${CODEGEN_PKG}/generate-groups.sh" deepcopy \
+  github.com/clusternet/clusternet/pkg/generated \
+  github.com/clusternet/clusternet/pkg \
+  "scheduler:apis,apis/v1alpha1" \
+  --output-base "$(dirname "${BASH_SOURCE[0]}")/../../../.." \
+  --go-header-file "${SCRIPT_ROOT}/hack/boilerplate.go.txt"
@@ -140,8 +140,19 @@ func (deployer *Deployer) handleDescription(desc *appsapi.Description) error {
 			deployer.discoveryRESTMapper, desc, deployer.recorder)
 	}
 
-	return utils.ApplyDescription(context.TODO(), deployer.clusternetClient, deployer.dynamicClient,
-		deployer.discoveryRESTMapper, desc, deployer.recorder, false, deployer.ResourceCallbackHandler)
+	var err error
+	if utils.DescriptionNeedsApplying(desc) {
+		err = utils.ApplyDescription(context.TODO(), deployer.clusternetClient, deployer.dynamicClient,
+			deployer.discoveryRESTMapper, desc, deployer.recorder, false, deployer.ResourceCallbackHandler)
+	} else {
+		err = utils.RefreshDescriptionStatus(context.TODO(), deployer.clusternetClient, deployer.dynamicClient,
+			deployer.discoveryRESTMapper, desc, deployer.recorder, deployer.ResourceCallbackHandler)
+	}
+	if err == nil && desc.Status.Phase == appsapi.DescriptionPhaseProgressing {
+		// re-assess the health of resources until they settle down
+		deployer.descController.EnqueueAfter(desc, known.DefaultRetryPeriod)
+	}
+	return err
 }
 
 func (deployer *Deployer) ResourceCallbackHandler(resource *unstructured.Unstructured) error {
@@ -193,9 +204,18 @@ func (deployer *Deployer) handleResource(ownedByValue string) error {
 		}
 		return err
 	}
+	if desc.DeletionTimestamp != nil {
+		// resources are being deleted or orphaned
+		return nil
+	}
 
-	return utils.ApplyDescription(context.TODO(), deployer.clusternetClient, deployer.dynamicClient,
-		deployer.discoveryRESTMapper, desc, deployer.recorder, false, nil)
+	// drifts are corrected by applying the Description again, otherwise only reported if needed
+	if utils.DescriptionNeedsApplying(desc) {
+		return utils.ApplyDescription(context.TODO(), deployer.clusternetClient, deployer.dynamicClient,
+			deployer.discoveryRESTMapper, desc, deployer.recorder, false, nil)
+	}
+	return utils.RefreshDescriptionStatus(context.TODO(), deployer.clusternetClient, deployer.dynamicClient,
+		deployer.discoveryRESTMapper, desc, deployer.recorder, nil)
 }
 
 func (deployer *Deployer) ControllerHasStarted(gvk schema.GroupVersionKind) bool {
@@ -42,6 +42,27 @@ type BaseSpec struct {
 	// +required
 	// +kubebuilder:validation:Required
 	Feeds []Feed `json:"feeds"`
+
+	// ConflictResolution tells how to resolve the conflicts with other field managers when applying the feeds.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Force;Report
+	ConflictResolution ConflictResolution `json:"conflictResolution,omitempty"`
+
+	// DriftPolicy tells how to handle the resources that drift from the feeds.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Ignore;Report;Correct
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// DeletionPolicy tells what happens to the resources when they are no longer subscribed.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // Important: Run "make generated" to regenerate code after modifying this file
@@ -58,18 +59,83 @@ type DescriptionSpec struct {
 	//
 	// +optional
 	Raw [][]byte `json:"raw,omitempty"`
+
+	// ConflictResolution tells how to resolve the conflicts with other field managers
+	// when server-side applying Raw objects to the child cluster.
+	// If not specified, conflicts are resolved with Force.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Force;Report
+	ConflictResolution ConflictResolution `json:"conflictResolution,omitempty"`
+
+	// DriftPolicy tells how to handle the drifts of the resources in the child cluster from Raw objects.
+	// If not specified, drifts are corrected.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Ignore;Report;Correct
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// DeletionPolicy tells what happens to the resources of Raw objects in the child cluster
+	// when they are no longer subscribed, which could be overridden by the annotation
+	// "apps.clusternet.io/deletion-policy" on each object. If not specified, they are deleted.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // DescriptionStatus defines the observed state of Description
 type DescriptionStatus struct {
 	// Phase denotes the phase of Description
 	// +optional
-	// +kubebuilder:validation:Enum=Pending;Success;Failure
+	// +kubebuilder:validation:Enum=Pending;Progressing;Success;Degraded;Failure
 	Phase DescriptionPhase `json:"phase,omitempty"`
 
 	// Reason indicates the reason of DescriptionPhase
 	// +optional
 	Reason string `json:"reason,omitempty"`
+
+	// ObservedGeneration is the generation of the Description that Phase is reported for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ManifestStatuses are the apply results and live statuses of the resources deployed by this Description
+	// in the child cluster. Present only for the Generic deployer.
+	// +optional
+	ManifestStatuses []ManifestStatus `json:"manifestStatuses,omitempty"`
+}
+
+// ManifestStatus contains the apply result and the live status of a resource deployed by a Description.
+type ManifestStatus struct {
+	// Feed identifies the resource, whose Replicas is never set.
+	Feed `json:",inline"`
+
+	// Phase tells whether the resource is applied to the child cluster.
+	// +optional
+	// +kubebuilder:validation:Enum=Applied;Conflicted;Failed
+	Phase ManifestPhase `json:"phase,omitempty"`
+
+	// Health of the resource in the child cluster, which is assessed only if the resource is applied.
+	// +optional
+	// +kubebuilder:validation:Enum=Healthy;Progressing;Degraded;Unknown
+	Health HealthState `json:"health,omitempty"`
+
+	// Message indicates why the resource failed to be applied, or details about its health.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// DriftedFields are the JSON pointers to the fields of the resource whose live values in the child cluster
+	// drift from the desired ones, where "/" means the resource is missing. Present only for DriftPolicyReport.
+	// +optional
+	DriftedFields []string `json:"driftedFields,omitempty"`
+
+	// ObservedStatus is the `.status` of the resource in the child cluster.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	ObservedStatus runtime.RawExtension `json:"observedStatus,omitempty"`
 }
 
 type DescriptionDeployer string
@@ -84,6 +150,80 @@ type DescriptionPhase string
 const (
 	DescriptionPhaseSuccess DescriptionPhase = "Success"
 	DescriptionPhaseFailure DescriptionPhase = "Failure"
+
+	// DescriptionPhaseProgressing means all the resources are applied, while some of them are not healthy yet.
+	DescriptionPhaseProgressing DescriptionPhase = "Progressing"
+
+	// DescriptionPhaseDegraded means all the resources are applied, while some of them are degraded.
+	DescriptionPhaseDegraded DescriptionPhase = "Degraded"
+)
+
+type ManifestPhase string
+
+const (
+	ManifestPhaseApplied ManifestPhase = "Applied"
+	ManifestPhaseFailed  ManifestPhase = "Failed"
+
+	// ManifestPhaseConflicted means the resource is not applied, for some of its fields are owned by
+	// other field managers in the child cluster.
+	ManifestPhaseConflicted ManifestPhase = "Conflicted"
+)
+
+// ConflictResolution tells how to resolve the field ownership conflicts when server-side applying resources.
+type ConflictResolution string
+
+const (
+	// ConflictResolutionForce takes over the ownership of the conflicting fields from other field managers.
+	ConflictResolutionForce ConflictResolution = "Force"
+
+	// ConflictResolutionReport leaves the conflicting resources untouched,
+	// and reports the conflicts in the status of Description.
+	ConflictResolutionReport ConflictResolution = "Report"
+)
+
+// DriftPolicy tells how to handle the resources whose live state in the child cluster
+// drifts from the desired state.
+type DriftPolicy string
+
+const (
+	// DriftPolicyIgnore leaves the drifted resources untouched.
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+
+	// DriftPolicyReport leaves the drifted resources untouched, and records the drifted fields
+	// in the status of Description.
+	DriftPolicyReport DriftPolicy = "Report"
+
+	// DriftPolicyCorrect re-applies the drifted resources, which takes effect only if
+	// the feature gate Recovery is enabled.
+	DriftPolicyCorrect DriftPolicy = "Correct"
+)
+
+// DeletionPolicy tells what happens to the resources in the child cluster when they are no longer subscribed.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the resources from the child cluster.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyOrphan leaves the resources running in the child cluster, which are no longer managed by Clusternet.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// HealthState is the health of a resource in the child cluster.
+type HealthState string
+
+const (
+	// HealthStateHealthy means the resource has reached its desired state.
+	HealthStateHealthy HealthState = "Healthy"
+
+	// HealthStateProgressing means the resource is still moving towards its desired state.
+	HealthStateProgressing HealthState = "Progressing"
+
+	// HealthStateDegraded means the resource failed to reach its desired state.
+	HealthStateDegraded HealthState = "Degraded"
+
+	// HealthStateUnknown means the health of the resource can not be assessed.
+	HealthStateUnknown HealthState = "Unknown"
 )
 
 // +kubebuilder:object:root=true
@@ -27,6 +27,9 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope="Namespaced",shortName=sub;subs,categories=clusternet
+// +kubebuilder:printcolumn:name="SCHEDULED",type="string",JSONPath=".status.conditions[?(@.type==\"Scheduled\")].status"
+// +kubebuilder:printcolumn:name="DEPLOYED",type="string",JSONPath=".status.conditions[?(@.type==\"Deployed\")].status"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Subscription represents the policy that install a group of resources to one or more clusters.
@@ -72,6 +75,90 @@ type SubscriptionSpec struct {
 	// +optional
 	ClusterTolerations []corev1.Toleration `json:"clusterTolerations,omitempty"`
 
+	// MaxClusters is the maximum number of clusters that the Subscription will be scheduled to.
+	// The highest-scoring clusters are picked, and ties are broken randomly.
+	// If not specified, all feasible clusters will be selected.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxClusters *int32 `json:"maxClusters,omitempty"`
+
+	// MinClusters is the minimum number of feasible clusters required by the Subscription.
+	// The Subscription is unschedulable when fewer clusters are feasible.
+	// If not specified, at least one feasible cluster is required.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinClusters *int32 `json:"minClusters,omitempty"`
+
+	// TopologySpreadConstraints describes how the Subscription is spread across topology domains
+	// of ManagedClusters, such as regions and zones. All constraints are ANDed.
+	//
+	// +optional
+	TopologySpreadConstraints []ClusterTopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PreferredClusterAffinity describes the weighted preferences of ManagedClusters.
+	// The scheduler prefers the clusters matching the preferences with the greatest sum of weights,
+	// but it may still choose other feasible clusters.
+	//
+	// +optional
+	PreferredClusterAffinity []PreferredClusterSelectorTerm `json:"preferredClusterAffinity,omitempty"`
+
+	// KubernetesVersionConstraint is a semantic version constraint on the Kubernetes version of ManagedClusters,
+	// such as ">= 1.21.0". Only the clusters satisfying the constraint will be scheduled to.
+	// Pre-release and build metadata of the cluster versions, like "-eks-6b7464", are ignored.
+	// If not specified, clusters of any Kubernetes version are feasible.
+	//
+	// +optional
+	KubernetesVersionConstraint string `json:"kubernetesVersionConstraint,omitempty"`
+
+	// Priority of the Subscription in the scheduling queue.
+	// Subscriptions with higher priority are scheduled ahead of the ones with lower priority.
+	// If not specified, the priority is zero.
+	//
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// RolloutStrategy describes how updates of the feeds are rolled out to the binding clusters.
+	// If not specified, updates are rolled out to all the binding clusters at once.
+	//
+	// +optional
+	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// ConflictResolution tells how to resolve the conflicts with other field managers in child clusters,
+	// such as HorizontalPodAutoscalers or admission webhooks, when applying the feeds.
+	// Force takes over the conflicting fields, while Report leaves the resources untouched and reports
+	// the conflicts in the status of Descriptions.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Force;Report
+	// +kubebuilder:default=Force
+	ConflictResolution ConflictResolution `json:"conflictResolution,omitempty"`
+
+	// DriftPolicy tells how to handle the resources in child clusters that drift from the feeds,
+	// which are watched only if the feature gate Recovery is enabled.
+	// Ignore leaves them untouched, Report records the drifted fields in the status of Descriptions
+	// and emits events, while Correct re-applies them.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Ignore;Report;Correct
+	// +kubebuilder:default=Correct
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// DeletionPolicy tells what happens to the resources in child clusters when the Subscription is deleted,
+	// the feeds are unsubscribed, or the clusters drop out of the binding clusters.
+	// Delete deletes them, while Orphan leaves them running without being managed by Clusternet.
+	// The policy could be overridden by the annotation "apps.clusternet.io/deletion-policy" on each resource.
+	// Resources of HelmCharts are always uninstalled.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
 	// Feeds
 	//
 	// +required
@@ -97,6 +184,13 @@ type SubscriptionStatus struct {
 	// +optional
 	SpecHash uint64 `json:"specHash,omitempty"`
 
+	// Desired replicas of targeted clusters for each feed.
+	// The key is the feed key, and the indices of replicas are corresponding with BindingClusters.
+	// Present only for Dividing scheduling.
+	//
+	// +optional
+	Replicas map[string]FeedReplicas `json:"replicas,omitempty"`
+
 	// Total number of Helm releases desired by this Subscription.
 	//
 	// +optional
@@ -106,8 +200,60 @@ type SubscriptionStatus struct {
 	//
 	// +optional
 	CompletedReleases int `json:"completedReleases,omitempty"`
+
+	// Total number of feeds desired in all the binding clusters, excluding HelmCharts.
+	//
+	// +optional
+	DesiredFeeds int `json:"desiredFeeds,omitempty"`
+
+	// Total number of feeds that are available in all the binding clusters, excluding HelmCharts.
+	//
+	// +optional
+	AvailableFeeds int `json:"availableFeeds,omitempty"`
+
+	// AggregatedStatuses are the statuses of the feeds aggregated from all the binding clusters.
+	//
+	// +optional
+	AggregatedStatuses []AggregatedStatus `json:"aggregatedStatuses,omitempty"`
+
+	// ObservedGeneration is the most recent generation of this Subscription observed by the scheduler.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// UnschedulablePlugins are the plugins that rejected this Subscription in the last scheduling attempt.
+	// Present only when the Subscription is unschedulable.
+	//
+	// +optional
+	UnschedulablePlugins []string `json:"unschedulablePlugins,omitempty"`
+
+	// Rollout shows the progress of rolling out updates of the feeds.
+	// Present only if RolloutStrategy is specified.
+	//
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// Conditions of this Subscription, which are Scheduled, Deployed and Ready.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// These are valid condition types of a Subscription.
+const (
+	// SubscriptionScheduled means the Subscription has been bound to clusters.
+	SubscriptionScheduled = "Scheduled"
+
+	// SubscriptionDeployed means the Bases of the Subscription have been populated to all the binding clusters.
+	SubscriptionDeployed = "Deployed"
+
+	// SubscriptionReady means the Descriptions of the Subscription have been deployed successfully
+	// to all the binding clusters.
+	SubscriptionReady = "Ready"
+)
+
 // Subscriber defines
 type Subscriber struct {
 	// ClusterAffinity is a label query over managed clusters by labels.
@@ -116,6 +262,13 @@ type Subscriber struct {
 	// +kubebuilder:validation:Required
 	ClusterAffinity *metav1.LabelSelector `json:"clusterAffinity"`
 
+	// ClusterSelectorTerms narrows down the clusters selected by ClusterAffinity.
+	// The terms are ORed, so a cluster is selected if it matches any of them.
+	// If not specified, all the clusters selected by ClusterAffinity are kept.
+	//
+	// +optional
+	ClusterSelectorTerms []ClusterSelectorTerm `json:"clusterSelectorTerms,omitempty"`
+
 	// Static weight of subscriber when dividing replicas.
 	// Present only for static divided scheduling.
 	//
@@ -124,6 +277,47 @@ type Subscriber struct {
 	Weight *int32 `json:"weight,omitempty"`
 }
 
+// ClusterSelectorTerm represents the requirements to select ManagedClusters.
+// The requirements of a term are ANDed. An empty term matches no clusters.
+type ClusterSelectorTerm struct {
+	// A list of cluster selector requirements by labels of ManagedClusters.
+	//
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// A list of cluster selector requirements by fields of ManagedClusters.
+	// The supported keys are "metadata.name" and "metadata.namespace".
+	//
+	// +optional
+	MatchFields []metav1.LabelSelectorRequirement `json:"matchFields,omitempty"`
+}
+
+// PreferredClusterSelectorTerm represents a weighted preference of ManagedClusters.
+type PreferredClusterSelectorTerm struct {
+	// Weight associated with matching the corresponding cluster selector term, in the range 1-100.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+
+	// A cluster selector term, associated with the corresponding weight.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Preference ClusterSelectorTerm `json:"preference"`
+}
+
+// These are the supported field keys in the MatchFields of a ClusterSelectorTerm.
+const (
+	// ClusterFieldName selects ManagedClusters by their names.
+	ClusterFieldName = "metadata.name"
+
+	// ClusterFieldNamespace selects ManagedClusters by their dedicated namespaces.
+	ClusterFieldNamespace = "metadata.namespace"
+)
+
 // Feed defines the resource to be selected.
 type Feed struct {
 	// Kind is a string value representing the REST resource this object represents.
@@ -154,21 +348,63 @@ type Feed struct {
 	Name string `json:"name"`
 
 	// Number of desired pods in child clusters if necessary.
-	// The indices are corresponding with the scheduled clusters.
+	// In a Subscription, the indices are corresponding with the scheduled clusters.
+	// In a Base, at most one value is kept, which is for the cluster the Base belongs to.
 	//
 	// +optional
 	Replicas []int32 `json:"replicas,omitempty"`
 }
 
+// ClusterTopologySpreadConstraint specifies how to spread a Subscription among the given topology of ManagedClusters.
+type ClusterTopologySpreadConstraint struct {
+	// MaxSkew describes the degree to which the Subscription may be unevenly distributed.
+	// It is the maximum permitted difference between the number of selected clusters in any two topology domains.
+	// For Dividing scheduling, it also applies to the number of replicas of each feed in any two topology domains.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxSkew int32 `json:"maxSkew"`
+
+	// TopologyKey is the key of ManagedCluster labels. ManagedClusters that have a label with this key
+	// and identical values are considered to be in the same topology domain, such as a region or a zone.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Type=string
+	TopologyKey string `json:"topologyKey"`
+
+	// WhenUnsatisfiable indicates how to deal with the Subscription if it doesn't satisfy the spread constraint.
+	// DoNotSchedule (default) tells the scheduler not to select clusters that violate the constraint,
+	// and ManagedClusters without the TopologyKey label are never selected.
+	// ScheduleAnyway tells the scheduler to prefer clusters that satisfy the constraint,
+	// while still selecting others if necessary.
+	//
+	// +optional
+	// +kubebuilder:default=DoNotSchedule
+	// +kubebuilder:validation:Enum=DoNotSchedule;ScheduleAnyway
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
+}
+
+// FeedReplicas holds the desired replicas of a feed in each of the binding clusters.
+type FeedReplicas []int32
+
 // DividingSchedulingStrategy describes how to divide replicas into target clusters.
 type DividingSchedulingStrategy struct {
 	// Type of dividing replica scheduling.
 	//
 	// +required
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=Static
+	// +kubebuilder:validation:Enum=Static;Dynamic
 	// +kubebuilder:validation:Type=string
 	Type ReplicaDividingType `json:"type"`
+
+	// MinimizeMovement keeps the replicas already divided into the binding clusters when the Subscription
+	// is rescheduled. Replica changes are adjusted in place, by scaling up the clusters already running replicas
+	// and scaling down in proportion to the current replicas, instead of re-dividing all the replicas.
+	//
+	// +optional
+	MinimizeMovement bool `json:"minimizeMovement,omitempty"`
 }
 
 type SchedulingStrategyType string
@@ -186,6 +422,189 @@ type ReplicaDividingType string
 const (
 	// StaticReplicaDividingType divides replicas by a fixed weight.
 	StaticReplicaDividingType ReplicaDividingType = "Static"
+
+	// DynamicReplicaDividingType divides replicas in proportion to how many replicas could be run in each cluster,
+	// which is calculated by the available resources of the cluster.
+	DynamicReplicaDividingType ReplicaDividingType = "Dynamic"
+)
+
+// AggregatedStatus contains the statuses of a feed in all the binding clusters.
+type AggregatedStatus struct {
+	// Feed identifies the feed, whose Replicas is never set.
+	Feed `json:",inline"`
+
+	// FeedStatusSummary sums up the statuses of the feed in all the binding clusters.
+	//
+	// +optional
+	FeedStatusSummary FeedStatus `json:"feedStatusSummary,omitempty"`
+
+	// FeedStatusDetails are the statuses of the feed in each binding cluster that has reported it.
+	//
+	// +optional
+	FeedStatusDetails []FeedStatusPerCluster `json:"feedStatusDetails,omitempty"`
+}
+
+// FeedStatusPerCluster is the status of a feed in a binding cluster.
+type FeedStatusPerCluster struct {
+	// Namespaced name of the binding cluster, as in BindingClusters.
+	//
+	// +required
+	// +kubebuilder:validation:Required
+	Cluster string `json:"cluster"`
+
+	FeedStatus `json:",inline"`
+}
+
+// FeedStatus contains the well-known status fields of a feed.
+type FeedStatus struct {
+	// Available tells whether the feed is available. Workloads are available when all their replicas are available,
+	// and Jobs are available once completed. Other resources are available as long as they exist.
+	//
+	// +optional
+	Available bool `json:"available,omitempty"`
+
+	// Number of replicas observed for workloads, or desired pods for DaemonSets.
+	//
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// Number of ready replicas for workloads.
+	//
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Number of available replicas for workloads.
+	//
+	// +optional
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+
+	// Number of updated replicas for workloads.
+	//
+	// +optional
+	UpdatedReplicas int32 `json:"updatedReplicas,omitempty"`
+
+	// Number of active pods for Jobs.
+	//
+	// +optional
+	Active int32 `json:"active,omitempty"`
+
+	// Number of succeeded pods for Jobs.
+	//
+	// +optional
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Number of failed pods for Jobs.
+	//
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+}
+
+// RolloutStrategy describes how updates of the feeds are rolled out to the binding clusters wave by wave.
+// Only updates of Manifests are rolled out in waves, while HelmCharts are updated in all the clusters at once.
+type RolloutStrategy struct {
+	// Waves are the ordered groups of binding clusters to roll out updates to.
+	// A binding cluster belongs to the first wave it matches, and the binding clusters matching no waves
+	// are updated in an implicit final wave.
+	// A wave starts only after all the clusters in the previous waves have been updated successfully.
+	//
+	// +optional
+	Waves []RolloutWave `json:"waves,omitempty"`
+
+	// MaxUnavailableClusters is the maximum number of clusters in a wave that can be updating at the same time.
+	// A cluster is updating until its Descriptions of the new revision are deployed successfully.
+	// If not specified, all the clusters in a wave are updated at once.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxUnavailableClusters *int32 `json:"maxUnavailableClusters,omitempty"`
+
+	// PauseBetweenWaves is the duration to wait after a wave is completed before starting the next wave.
+	//
+	// +optional
+	PauseBetweenWaves *metav1.Duration `json:"pauseBetweenWaves,omitempty"`
+
+	// ManualPromotion requires the next wave to be promoted manually after a wave is completed,
+	// by annotating the Subscription with "apps.clusternet.io/rollout-promoted-wave" set to the index of that wave.
+	//
+	// +optional
+	ManualPromotion bool `json:"manualPromotion,omitempty"`
+}
+
+// RolloutWave selects a group of binding clusters to be updated together.
+type RolloutWave struct {
+	// Name of this wave.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ClusterSelector is a label query over the binding clusters.
+	//
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Namespaced names of the binding clusters, as in BindingClusters.
+	//
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// RolloutStatus describes the progress of rolling out a revision of the feeds.
+type RolloutStatus struct {
+	// Revision is the hash of the feeds being rolled out.
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// CurrentWave is the index of the wave being rolled out.
+	//
+	// +optional
+	CurrentWave int32 `json:"currentWave,omitempty"`
+
+	// TotalWaves is the number of waves, including the implicit final one if any.
+	//
+	// +optional
+	TotalWaves int32 `json:"totalWaves,omitempty"`
+
+	// Phase of the rollout.
+	//
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Enum=Progressing;Paused;WaitingForPromotion;Halted;Completed
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// Namespaced names of the binding clusters that are allowed to be updated to this revision.
+	//
+	// +optional
+	UpdatedClusters []string `json:"updatedClusters,omitempty"`
+
+	// A human readable message indicating details about the rollout.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the last time the phase or the current wave changed.
+	//
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+type RolloutPhase string
+
+const (
+	// RolloutProgressing means the clusters in the current wave are being updated.
+	RolloutProgressing RolloutPhase = "Progressing"
+
+	// RolloutPaused means the current wave is completed, and the next wave waits for PauseBetweenWaves.
+	RolloutPaused RolloutPhase = "Paused"
+
+	// RolloutWaitingForPromotion means the current wave is completed, and the next wave waits for promotion.
+	RolloutWaitingForPromotion RolloutPhase = "WaitingForPromotion"
+
+	// RolloutHalted means some clusters in the current wave failed to be updated.
+	RolloutHalted RolloutPhase = "Halted"
+
+	// RolloutCompleted means all the binding clusters have been updated successfully.
+	RolloutCompleted RolloutPhase = "Completed"
 )
 
 // +kubebuilder:object:root=true
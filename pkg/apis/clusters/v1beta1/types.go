@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -200,6 +201,32 @@ type ManagedClusterSpec struct {
 	// Taints has the "effect" on any resource that does not tolerate the Taint.
 	// +optional
 	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// Pricing describes the prices of the resources in the child cluster,
+	// which are used to estimate the cost of running workloads in this cluster.
+	//
+	// +optional
+	Pricing *ClusterPricing `json:"pricing,omitempty"`
+}
+
+// ClusterPricing describes the prices of the resources in a cluster.
+// All the prices should be in the same currency across clusters.
+type ClusterPricing struct {
+	// CPUHour is the price of one CPU core per hour, such as "0.035".
+	//
+	// +optional
+	CPUHour *resource.Quantity `json:"cpuHour,omitempty"`
+
+	// MemoryGBHour is the price of one GiB memory per hour, such as "0.004".
+	//
+	// +optional
+	MemoryGBHour *resource.Quantity `json:"memoryGBHour,omitempty"`
+
+	// Spot indicates whether the capacity of the cluster is made up of spot (preemptible) instances,
+	// which are cheaper but may be reclaimed at any time.
+	//
+	// +optional
+	Spot bool `json:"spot,omitempty"`
 }
 
 // ManagedClusterStatus defines the observed state of ManagedCluster
@@ -253,6 +280,10 @@ type ManagedClusterStatus struct {
 	// +optional
 	Capacity corev1.ResourceList `json:"capacity,omitempty"`
 
+	// Requested is the sum of resource requests of running pods in the cluster
+	// +optional
+	Requested corev1.ResourceList `json:"requested,omitempty"`
+
 	// ClusterCIDR is the CIDR range of the cluster
 	// +optional
 	ClusterCIDR string `json:"clusterCIDR,omitempty"`
@@ -265,6 +296,11 @@ type ManagedClusterStatus struct {
 	// +optional
 	NodeStatistics NodeStatistics `json:"nodeStatistics,omitempty"`
 
+	// ServedAPIs lists the API group versions served by the cluster, along with their kinds,
+	// including the ones defined by CustomResourceDefinitions
+	// +optional
+	ServedAPIs []ServedAPIGroupVersion `json:"servedAPIs,omitempty"`
+
 	// Conditions is an array of current cluster conditions.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -321,3 +357,13 @@ type NodeStatistics struct {
 	// +optional
 	LostNodes int32 `json:"lostNodes,omitempty"`
 }
+
+// ServedAPIGroupVersion describes an API group version served by the cluster
+type ServedAPIGroupVersion struct {
+	// GroupVersion is the group and version of the API, such as "apps/v1"
+	GroupVersion string `json:"groupVersion"`
+
+	// Kinds lists the kinds served in the group version
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+}
@@ -26,6 +26,32 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPricing) DeepCopyInto(out *ClusterPricing) {
+	*out = *in
+	if in.CPUHour != nil {
+		in, out := &in.CPUHour, &out.CPUHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryGBHour != nil {
+		in, out := &in.MemoryGBHour, &out.MemoryGBHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPricing.
+func (in *ClusterPricing) DeepCopy() *ClusterPricing {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPricing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRegistrationRequest) DeepCopyInto(out *ClusterRegistrationRequest) {
 	*out = *in
@@ -212,6 +238,11 @@ func (in *ManagedClusterSpec) DeepCopyInto(out *ManagedClusterSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(ClusterPricing)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -243,7 +274,21 @@ func (in *ManagedClusterStatus) DeepCopyInto(out *ManagedClusterStatus) {
 			(*out)[key] = val.DeepCopy()
 		}
 	}
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	out.NodeStatistics = in.NodeStatistics
+	if in.ServedAPIs != nil {
+		in, out := &in.ServedAPIs, &out.ServedAPIs
+		*out = make([]ServedAPIGroupVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -284,3 +329,24 @@ func (in *NodeStatistics) DeepCopy() *NodeStatistics {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServedAPIGroupVersion) DeepCopyInto(out *ServedAPIGroupVersion) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServedAPIGroupVersion.
+func (in *ServedAPIGroupVersion) DeepCopy() *ServedAPIGroupVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ServedAPIGroupVersion)
+	in.DeepCopyInto(out)
+	return out
+}
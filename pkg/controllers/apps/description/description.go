@@ -400,6 +400,16 @@ func (c *Controller) UpdateDescriptionStatus(desc *appsapi.Description, status *
 	})
 }
 
+// EnqueueAfter puts a Description onto the work queue after the given duration.
+func (c *Controller) EnqueueAfter(desc *appsapi.Description, duration time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(desc)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.AddAfter(key, duration)
+}
+
 // enqueue takes a Description resource and converts it into a namespace/name
 // string which is then put onto the work queue. This method should *not* be
 // passed resources of any type other than Description.
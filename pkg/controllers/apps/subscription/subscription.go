@@ -63,6 +63,7 @@ type Controller struct {
 	subsLister applisters.SubscriptionLister
 	subsSynced cache.InformerSynced
 	baseSynced cache.InformerSynced
+	descSynced cache.InformerSynced
 
 	recorder record.EventRecorder
 
@@ -73,7 +74,7 @@ type Controller struct {
 // The scheduling parts are handled by clusternet-scheduler.
 func NewController(clusternetClient clusternetclientset.Interface,
 	subsInformer appinformers.SubscriptionInformer, baseInformer appinformers.BaseInformer,
-	recorder record.EventRecorder, syncHandlerFunc SyncHandlerFunc) (*Controller, error) {
+	descInformer appinformers.DescriptionInformer, recorder record.EventRecorder, syncHandlerFunc SyncHandlerFunc) (*Controller, error) {
 	if syncHandlerFunc == nil {
 		return nil, fmt.Errorf("syncHandlerFunc must be set")
 	}
@@ -84,6 +85,7 @@ func NewController(clusternetClient clusternetclientset.Interface,
 		subsLister:       subsInformer.Lister(),
 		subsSynced:       subsInformer.Informer().HasSynced,
 		baseSynced:       baseInformer.Informer().HasSynced,
+		descSynced:       descInformer.Informer().HasSynced,
 		recorder:         recorder,
 		syncHandlerFunc:  syncHandlerFunc,
 	}
@@ -99,6 +101,11 @@ func NewController(clusternetClient clusternetclientset.Interface,
 		DeleteFunc: c.deleteBase,
 	})
 
+	// Descriptions report the readiness of a Subscription
+	descInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: c.updateDescription,
+	})
+
 	return c, nil
 }
 
@@ -117,6 +124,7 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}) {
 	if !cache.WaitForNamedCacheSync("subscription-controller", stopCh,
 		c.subsSynced,
 		c.baseSynced,
+		c.descSynced,
 	) {
 		return
 	}
@@ -145,16 +153,24 @@ func (c *Controller) updateSubscription(old, cur interface{}) {
 		return
 	}
 
+	// a rollout wave is promoted manually
+	if oldSub.Annotations[known.RolloutPromotedWaveAnnotation] != newSub.Annotations[known.RolloutPromotedWaveAnnotation] {
+		klog.V(4).Infof("promoting rollout wave of Subscription %q", klog.KObj(newSub))
+		c.enqueue(newSub)
+		return
+	}
+
 	// Decide whether discovery has reported a status change.
 	// clusternet-scheduler is responsible for spec changes.
 	if reflect.DeepEqual(oldSub.Status, newSub.Status) {
 		klog.V(4).Infof("no updates on the status of Subscription %s, skipping syncing", klog.KObj(oldSub))
 		return
 	}
-	// no changes on binding namespaces and spec hash
+	// no changes on binding namespaces, divided replicas and spec hash
 	if reflect.DeepEqual(oldSub.Status.BindingClusters, newSub.Status.BindingClusters) &&
+		reflect.DeepEqual(oldSub.Status.Replicas, newSub.Status.Replicas) &&
 		oldSub.Status.SpecHash == newSub.Status.SpecHash {
-		klog.V(4).Infof("no changes on binding namespaces and spec hash of Subscription %s, skipping syncing", klog.KObj(oldSub))
+		klog.V(4).Infof("no changes on binding namespaces, replicas and spec hash of Subscription %s, skipping syncing", klog.KObj(oldSub))
 		return
 	}
 
@@ -204,6 +220,24 @@ func (c *Controller) deleteBase(obj interface{}) {
 	c.enqueue(sub)
 }
 
+func (c *Controller) updateDescription(old, cur interface{}) {
+	oldDesc := old.(*appsapi.Description)
+	newDesc := cur.(*appsapi.Description)
+
+	if reflect.DeepEqual(oldDesc.Status, newDesc.Status) {
+		return
+	}
+
+	sub := c.resolveControllerRef(newDesc.Labels[known.ConfigSubscriptionNameLabel],
+		newDesc.Labels[known.ConfigSubscriptionNamespaceLabel],
+		types.UID(newDesc.Labels[known.ConfigSubscriptionUIDLabel]))
+	if sub == nil {
+		return
+	}
+	klog.V(4).Infof("updating Description %q", klog.KObj(newDesc))
+	c.enqueue(sub)
+}
+
 // resolveControllerRef returns the controller referenced by a ControllerRef,
 // or nil if the ControllerRef could not be resolved to a matching controller
 // of the correct Kind.
@@ -384,6 +418,21 @@ func (c *Controller) enqueue(sub *appsapi.Subscription) {
 	c.workqueue.Add(key)
 }
 
+// Enqueue puts a Subscription onto the work queue.
+func (c *Controller) Enqueue(sub *appsapi.Subscription) {
+	c.enqueue(sub)
+}
+
+// EnqueueAfter puts a Subscription onto the work queue after the given duration.
+func (c *Controller) EnqueueAfter(sub *appsapi.Subscription, duration time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(sub)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.workqueue.AddAfter(key, duration)
+}
+
 func (c *Controller) patchSubscriptionLabels(sub *appsapi.Subscription, labels map[string]*string) (*appsapi.Subscription, error) {
 	if sub.DeletionTimestamp != nil || len(labels) == 0 {
 		return sub, nil
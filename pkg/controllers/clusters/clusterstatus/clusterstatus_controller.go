@@ -19,15 +19,19 @@ package clusterstatus
 import (
 	"context"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/version"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	corev1lister "k8s.io/client-go/listers/core/v1"
@@ -38,6 +42,7 @@ import (
 	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
 	"github.com/clusternet/clusternet/pkg/features"
 	"github.com/clusternet/clusternet/pkg/known"
+	"github.com/clusternet/clusternet/pkg/utils"
 )
 
 // Controller is a controller that collects cluster status
@@ -105,6 +110,22 @@ func (c *Controller) collectingClusterStatus(ctx context.Context) {
 
 	capacity, allocatable := getNodeResource(nodes)
 
+	var requested corev1.ResourceList
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		// an empty list would report all the allocatable resources as free,
+		// so we keep the requests reported last time instead
+		lastStatus := c.GetClusterStatus()
+		if lastStatus == nil {
+			klog.Warningf("failed to list pods, skip collecting cluster status: %v", err)
+			return
+		}
+		klog.Warningf("failed to list pods, keep the requested resources reported last time: %v", err)
+		requested = lastStatus.Requested
+	} else {
+		requested = getPodResourceRequests(pods)
+	}
+
 	clusterCIDR, err := c.discoverClusterCIDR()
 	if err != nil {
 		klog.Warningf("failed to discover cluster CIDR: %v", err)
@@ -115,6 +136,16 @@ func (c *Controller) collectingClusterStatus(ctx context.Context) {
 		klog.Warningf("failed to discover service CIDR: %v", err)
 	}
 
+	servedAPIs, err := c.getServedAPIs()
+	if err != nil {
+		// clusters reporting no served APIs are not checked against API compatibility by the scheduler,
+		// so we keep the APIs reported last time instead
+		klog.Warningf("failed to discover served APIs, keep the ones reported last time: %v", err)
+		if lastStatus := c.GetClusterStatus(); lastStatus != nil {
+			servedAPIs = lastStatus.ServedAPIs
+		}
+	}
+
 	var status clusterapi.ManagedClusterStatus
 	status.KubernetesVersion = clusterVersion.GitVersion
 	status.Platform = clusterVersion.Platform
@@ -127,8 +158,10 @@ func (c *Controller) collectingClusterStatus(ctx context.Context) {
 	status.ClusterCIDR = clusterCIDR
 	status.ServiceCIDR = serviceCIDR
 	status.NodeStatistics = nodeStatistics
+	status.ServedAPIs = servedAPIs
 	status.Allocatable = allocatable
 	status.Capacity = capacity
+	status.Requested = requested
 	status.HeartbeatFrequencySeconds = utilpointer.Int64Ptr(int64(c.heartbeatFrequency.Seconds()))
 	status.Conditions = []metav1.Condition{c.getCondition(status)}
 	c.setClusterStatus(status)
@@ -162,6 +195,44 @@ func (c *Controller) getKubernetesVersion(_ context.Context) (*version.Info, err
 	return c.kubeClient.Discovery().ServerVersion()
 }
 
+// getServedAPIs returns the API group versions served by the cluster, along with their kinds.
+// The group versions failing to be discovered are skipped.
+func (c *Controller) getServedAPIs() ([]clusterapi.ServedAPIGroupVersion, error) {
+	_, resourceLists, err := c.kubeClient.Discovery().ServerGroupsAndResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, err
+	}
+	if err != nil {
+		klog.Warningf("failed to discover some API groups: %v", err)
+	}
+	return getServedAPIs(resourceLists), nil
+}
+
+func getServedAPIs(resourceLists []*metav1.APIResourceList) []clusterapi.ServedAPIGroupVersion {
+	var servedAPIs []clusterapi.ServedAPIGroupVersion
+	for _, resourceList := range resourceLists {
+		if resourceList == nil {
+			continue
+		}
+		kinds := sets.NewString()
+		for _, resource := range resourceList.APIResources {
+			// skip subresources, such as "deployments/scale"
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			kinds.Insert(resource.Kind)
+		}
+		servedAPIs = append(servedAPIs, clusterapi.ServedAPIGroupVersion{
+			GroupVersion: resourceList.GroupVersion,
+			Kinds:        kinds.List(),
+		})
+	}
+	sort.Slice(servedAPIs, func(i, j int) bool {
+		return servedAPIs[i].GroupVersion < servedAPIs[j].GroupVersion
+	})
+	return servedAPIs
+}
+
 func (c *Controller) getHealthStatus(ctx context.Context, path string) bool {
 	var statusCode int
 	c.kubeClient.Discovery().RESTClient().Get().AbsPath(path).Do(ctx).StatusCode(&statusCode)
@@ -222,14 +293,16 @@ func (c *Controller) discoverClusterCIDR() (string, error) {
 
 // get node capacity and allocatable resource
 func getNodeResource(nodes []*corev1.Node) (Capacity, Allocatable corev1.ResourceList) {
-	var capacityCpu, capacityMem, capacityGpu, allocatableCpu, allocatableMem, allocatableGpu resource.Quantity
+	var capacityCpu, capacityMem, capacityPods, capacityGpu, allocatableCpu, allocatableMem, allocatablePods, allocatableGpu resource.Quantity
 	Capacity, Allocatable = make(map[corev1.ResourceName]resource.Quantity), make(map[corev1.ResourceName]resource.Quantity)
 
 	for _, node := range nodes {
 		capacityCpu.Add(*node.Status.Capacity.Cpu())
 		capacityMem.Add(*node.Status.Capacity.Memory())
+		capacityPods.Add(*node.Status.Capacity.Pods())
 		allocatableCpu.Add(*node.Status.Allocatable.Cpu())
 		allocatableMem.Add(*node.Status.Allocatable.Memory())
+		allocatablePods.Add(*node.Status.Allocatable.Pods())
 		if _, exists := node.Status.Capacity[known.NVIDIAGPUResourceName]; exists {
 			capacityGpu.Add(node.Status.Capacity[corev1.ResourceName(known.NVIDIAGPUResourceName)])
 			allocatableGpu.Add(node.Status.Allocatable[corev1.ResourceName(known.NVIDIAGPUResourceName)])
@@ -240,6 +313,10 @@ func getNodeResource(nodes []*corev1.Node) (Capacity, Allocatable corev1.Resourc
 	Capacity[corev1.ResourceMemory] = capacityMem
 	Allocatable[corev1.ResourceCPU] = allocatableCpu
 	Allocatable[corev1.ResourceMemory] = allocatableMem
+	if !capacityPods.IsZero() {
+		Capacity[corev1.ResourcePods] = capacityPods
+		Allocatable[corev1.ResourcePods] = allocatablePods
+	}
 	if !capacityGpu.IsZero() {
 		Capacity[corev1.ResourceName(known.NVIDIAGPUResourceName)] = capacityGpu
 		Allocatable[corev1.ResourceName(known.NVIDIAGPUResourceName)] = allocatableGpu
@@ -248,6 +325,28 @@ func getNodeResource(nodes []*corev1.Node) (Capacity, Allocatable corev1.Resourc
 	return
 }
 
+// get the sum of resource requests of pods that are scheduled and not terminated,
+// along with the number of these pods
+func getPodResourceRequests(pods []*corev1.Pod) corev1.ResourceList {
+	requested := make(corev1.ResourceList)
+	var podCount int64
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) == 0 || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		podCount++
+		for name, quantity := range utils.GetPodResourceRequests(&pod.Spec) {
+			value := requested[name]
+			value.Add(quantity)
+			requested[name] = value
+		}
+	}
+	if podCount > 0 {
+		requested[corev1.ResourcePods] = *resource.NewQuantity(podCount, resource.DecimalSI)
+	}
+	return requested
+}
+
 // getNodeCondition returns the specified condition from node's status
 // Copied from k8s.io/kubernetes/pkg/controller/util/node/controller_utils.go and make some modifications
 func getNodeCondition(status *corev1.NodeStatus, conditionType corev1.NodeConditionType) (int, *corev1.NodeCondition) {
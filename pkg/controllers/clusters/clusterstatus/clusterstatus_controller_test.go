@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterstatus
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+)
+
+func TestGetServedAPIs(t *testing.T) {
+	resourceLists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "pods", Kind: "Pod"},
+				{Name: "pods/status", Kind: "Pod"},
+				{Name: "configmaps", Kind: "ConfigMap"},
+			},
+		},
+		nil,
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "deployments", Kind: "Deployment"},
+				{Name: "deployments/scale", Kind: "Scale"},
+			},
+		},
+	}
+
+	expected := []clusterapi.ServedAPIGroupVersion{
+		{GroupVersion: "apps/v1", Kinds: []string{"Deployment"}},
+		{GroupVersion: "v1", Kinds: []string{"ConfigMap", "Pod"}},
+	}
+	if got := getServedAPIs(resourceLists); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected served APIs %v, got %v", expected, got)
+	}
+}
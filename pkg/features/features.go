@@ -65,6 +65,7 @@ const (
 	// Recovery ensures the resources deployed by Clusternet exist persistently in a child cluster.
 	// This helps rollback unexpected operations (like deleting, updating) that occurred solely inside a child cluster,
 	// unless those are made explicitly from parent cluster.
+	// How the drifted resources are handled is decided by the DriftPolicy of each Subscription.
 	//
 	// owner: @dixudx
 	// alpha: v0.8.0
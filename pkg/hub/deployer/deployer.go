@@ -29,6 +29,7 @@ import (
 	"helm.sh/helm/v3/pkg/repo"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -54,6 +55,7 @@ import (
 	clusternetclientset "github.com/clusternet/clusternet/pkg/generated/clientset/versioned"
 	clusternetinformers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
 	applisters "github.com/clusternet/clusternet/pkg/generated/listers/apps/v1alpha1"
+	clusterlisters "github.com/clusternet/clusternet/pkg/generated/listers/clusters/v1beta1"
 	"github.com/clusternet/clusternet/pkg/hub/deployer/generic"
 	"github.com/clusternet/clusternet/pkg/hub/deployer/helm"
 	"github.com/clusternet/clusternet/pkg/hub/localizer"
@@ -83,6 +85,9 @@ type Deployer struct {
 	nsLister    corev1lister.NamespaceLister
 	nsSynced    cache.InformerSynced
 
+	clusterLister clusterlisters.ManagedClusterLister
+	clusterSynced cache.InformerSynced
+
 	clusternetClient *clusternetclientset.Clientset
 	kubeClient       *kubernetes.Clientset
 
@@ -126,6 +131,8 @@ func NewDeployer(apiserverURL, systemNamespace, reservedNamespace string,
 		subSynced:         clusternetInformerFactory.Apps().V1alpha1().Subscriptions().Informer().HasSynced,
 		nsLister:          kubeInformerFactory.Core().V1().Namespaces().Lister(),
 		nsSynced:          kubeInformerFactory.Core().V1().Namespaces().Informer().HasSynced,
+		clusterLister:     clusternetInformerFactory.Clusters().V1beta1().ManagedClusters().Lister(),
+		clusterSynced:     clusternetInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().HasSynced,
 		clusternetClient:  clusternetclient,
 		kubeClient:        kubeclient,
 		recorder:          recorder,
@@ -160,6 +167,7 @@ func NewDeployer(apiserverURL, systemNamespace, reservedNamespace string,
 	subsController, err := subscription.NewController(clusternetclient,
 		clusternetInformerFactory.Apps().V1alpha1().Subscriptions(),
 		clusternetInformerFactory.Apps().V1alpha1().Bases(),
+		clusternetInformerFactory.Apps().V1alpha1().Descriptions(),
 		deployer.recorder,
 		deployer.handleSubscription)
 	if err != nil {
@@ -211,6 +219,7 @@ func (deployer *Deployer) Run(workers int, stopCh <-chan struct{}) {
 		deployer.mfstSynced,
 		deployer.subSynced,
 		deployer.nsSynced,
+		deployer.clusterSynced,
 	) {
 		return
 	}
@@ -245,7 +254,7 @@ func (deployer *Deployer) handleSubscription(sub *appsapi.Subscription) error {
 			if base.DeletionTimestamp != nil {
 				continue
 			}
-			if err := deployer.deleteBase(context.TODO(), klog.KObj(base).String()); err != nil {
+			if err := deployer.deleteBase(context.TODO(), klog.KObj(base).String(), sub.Spec.DeletionPolicy); err != nil {
 				klog.ErrorDepth(5, err)
 				allErrs = append(allErrs, err)
 				continue
@@ -271,6 +280,12 @@ func (deployer *Deployer) handleSubscription(sub *appsapi.Subscription) error {
 	}
 
 	err := deployer.populateBases(sub)
+	if err == nil {
+		err = deployer.syncRollout(sub)
+	}
+	if condErr := deployer.updateSubscriptionConditions(sub, err); condErr != nil {
+		klog.ErrorDepth(5, fmt.Sprintf("failed to update conditions of Subscription %s: %v", klog.KObj(sub), condErr))
+	}
 	if err != nil {
 		return err
 	}
@@ -278,6 +293,101 @@ func (deployer *Deployer) handleSubscription(sub *appsapi.Subscription) error {
 	return nil
 }
 
+// updateSubscriptionConditions updates the Deployed and Ready conditions of a Subscription,
+// given the error of populating its Bases. The statuses of the feeds reported by the Descriptions
+// are aggregated as well.
+func (deployer *Deployer) updateSubscriptionConditions(sub *appsapi.Subscription, populateErr error) error {
+	deployedCondition := metav1.Condition{
+		Type:               appsapi.SubscriptionDeployed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "BasesSynced",
+		Message:            fmt.Sprintf("Bases have been populated to %d clusters", len(sub.Status.BindingClusters)),
+		ObservedGeneration: sub.Generation,
+	}
+	if populateErr != nil {
+		deployedCondition.Status = metav1.ConditionFalse
+		deployedCondition.Reason = "FailedSyncingBases"
+		deployedCondition.Message = populateErr.Error()
+	}
+
+	descs, err := deployer.descLister.List(labels.SelectorFromSet(labels.Set{
+		known.ConfigSubscriptionNameLabel:      sub.Name,
+		known.ConfigSubscriptionNamespaceLabel: sub.Namespace,
+		known.ConfigSubscriptionUIDLabel:       string(sub.UID),
+	}))
+	if err != nil {
+		return err
+	}
+	readyCondition := getReadyCondition(sub, descs)
+	aggregatedStatuses, desiredFeeds, availableFeeds := getAggregatedStatuses(sub, descs)
+
+	return utils.UpdateSubscriptionStatus(context.TODO(), deployer.clusternetClient, sub, func(status *appsapi.SubscriptionStatus) {
+		meta.SetStatusCondition(&status.Conditions, deployedCondition)
+		meta.SetStatusCondition(&status.Conditions, readyCondition)
+		status.AggregatedStatuses = aggregatedStatuses
+		status.DesiredFeeds = desiredFeeds
+		status.AvailableFeeds = availableFeeds
+	})
+}
+
+// getReadyCondition summarizes the Descriptions of a Subscription into its Ready condition.
+// A Subscription is ready only when every binding cluster has Descriptions, and all of them succeeded.
+func getReadyCondition(sub *appsapi.Subscription, descs []*appsapi.Description) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               appsapi.SubscriptionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: sub.Generation,
+	}
+	if len(sub.Status.BindingClusters) == 0 {
+		condition.Reason = "NoBindingClusters"
+		condition.Message = "Subscription has not been bound to any clusters"
+		return condition
+	}
+
+	descsInNamespace := make(map[string][]*appsapi.Description)
+	for _, desc := range descs {
+		if desc.DeletionTimestamp != nil {
+			continue
+		}
+		descsInNamespace[desc.Namespace] = append(descsInNamespace[desc.Namespace], desc)
+	}
+
+	var failed, pending []string
+	for _, namespacedName := range sub.Status.BindingClusters {
+		namespace, _, err := cache.SplitMetaNamespaceKey(namespacedName)
+		if err != nil {
+			continue
+		}
+		if len(descsInNamespace[namespace]) == 0 {
+			pending = append(pending, namespacedName)
+			continue
+		}
+		for _, desc := range descsInNamespace[namespace] {
+			switch desc.Status.Phase {
+			case appsapi.DescriptionPhaseSuccess:
+			case appsapi.DescriptionPhaseFailure, appsapi.DescriptionPhaseDegraded:
+				failed = append(failed, klog.KObj(desc).String())
+			default:
+				pending = append(pending, klog.KObj(desc).String())
+			}
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		condition.Reason = "DescriptionsFailed"
+		condition.Message = fmt.Sprintf("failed to deploy Descriptions %s", strings.Join(failed, ","))
+	case len(pending) > 0:
+		condition.Reason = "DescriptionsPending"
+		condition.Message = fmt.Sprintf("waiting for %s to be deployed", strings.Join(pending, ","))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "DescriptionsSucceeded"
+		condition.Message = fmt.Sprintf("Descriptions have been deployed to %d clusters", len(sub.Status.BindingClusters))
+	}
+	return condition
+}
+
 func (deployer *Deployer) populateBases(sub *appsapi.Subscription) error {
 	allExistingBases, listErr := deployer.baseLister.List(labels.SelectorFromSet(labels.Set{
 		known.ConfigKindLabel:      subscriptionKind.Kind,
@@ -295,7 +405,7 @@ func (deployer *Deployer) populateBases(sub *appsapi.Subscription) error {
 	}
 
 	var allErrs []error
-	for _, namespacedName := range sub.Status.BindingClusters {
+	for idx, namespacedName := range sub.Status.BindingClusters {
 		// Convert the namespacedName/name string into a distinct namespacedName and name
 		namespace, _, err := cache.SplitMetaNamespaceKey(namespacedName)
 		if err != nil {
@@ -332,7 +442,10 @@ func (deployer *Deployer) populateBases(sub *appsapi.Subscription) error {
 				// Base and Subscription are in different namespaces
 			},
 			Spec: appsapi.BaseSpec{
-				Feeds: sub.Spec.Feeds,
+				Feeds:              getFeedsWithReplicas(sub, idx),
+				ConflictResolution: sub.Spec.ConflictResolution,
+				DriftPolicy:        sub.Spec.DriftPolicy,
+				DeletionPolicy:     sub.Spec.DeletionPolicy,
 			},
 		}
 		if ns.Labels != nil {
@@ -351,7 +464,7 @@ func (deployer *Deployer) populateBases(sub *appsapi.Subscription) error {
 	}
 
 	for key := range basesToBeDeleted {
-		err := deployer.deleteBase(context.TODO(), key)
+		err := deployer.deleteBase(context.TODO(), key, sub.Spec.DeletionPolicy)
 		if err != nil {
 			allErrs = append(allErrs, err)
 		}
@@ -360,6 +473,29 @@ func (deployer *Deployer) populateBases(sub *appsapi.Subscription) error {
 	return utilerrors.NewAggregate(allErrs)
 }
 
+// getFeedsWithReplicas returns the feeds of a Subscription for the idx-th binding cluster.
+// The replicas of each feed are narrowed down to the ones of this cluster, which come from
+// the divided replicas in the status if any, otherwise from the per-cluster replicas in the spec.
+// Replicas that can not be mapped to this cluster are dropped, so the workload keeps its own.
+func getFeedsWithReplicas(sub *appsapi.Subscription, idx int) []appsapi.Feed {
+	numClusters := len(sub.Status.BindingClusters)
+	feeds := make([]appsapi.Feed, len(sub.Spec.Feeds))
+	for i, feed := range sub.Spec.Feeds {
+		feed.DeepCopyInto(&feeds[i])
+		feeds[i].Replicas = nil
+
+		replicas, ok := sub.Status.Replicas[utils.GetFeedKey(feed)]
+		if !ok {
+			replicas = feed.Replicas
+		}
+		if len(replicas) != numClusters || idx >= numClusters {
+			continue
+		}
+		feeds[i].Replicas = []int32{replicas[idx]}
+	}
+	return feeds
+}
+
 func (deployer *Deployer) syncBase(sub *appsapi.Subscription, base *appsapi.Base) error {
 	if curBase, err := deployer.baseLister.Bases(base.Namespace).Get(base.Name); err == nil {
 		if curBase.DeletionTimestamp != nil {
@@ -407,7 +543,9 @@ func (deployer *Deployer) syncBase(sub *appsapi.Subscription, base *appsapi.Base
 	return err
 }
 
-func (deployer *Deployer) deleteBase(ctx context.Context, namespacedKey string) error {
+// deleteBase deletes a Base, whose deletion policy is updated ahead to the latest one of the Subscription,
+// which is passed down to its Descriptions.
+func (deployer *Deployer) deleteBase(ctx context.Context, namespacedKey string, deletionPolicy appsapi.DeletionPolicy) error {
 	// Convert the namespace/name string into a distinct namespace and name
 	ns, name, err := cache.SplitMetaNamespaceKey(namespacedKey)
 	if err != nil {
@@ -418,6 +556,13 @@ func (deployer *Deployer) deleteBase(ctx context.Context, namespacedKey string)
 	if err != nil {
 		return err
 	}
+	if base.Spec.DeletionPolicy != deletionPolicy {
+		baseCopy := base.DeepCopy()
+		baseCopy.Spec.DeletionPolicy = deletionPolicy
+		if _, err = deployer.clusternetClient.AppsV1alpha1().Bases(ns).Update(ctx, baseCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
 	// remove label (baseUID="Base") from referred Manifest/HelmChart
 	if err := deployer.removeLabelsFromReferredFeeds(base.UID, baseKind.Kind); err != nil {
 		return err
@@ -452,7 +597,7 @@ func (deployer *Deployer) handleBase(base *appsapi.Base) error {
 				continue
 			}
 
-			if err := deployer.deleteDescription(context.TODO(), klog.KObj(desc).String()); err != nil {
+			if err := deployer.deleteDescription(context.TODO(), klog.KObj(desc).String(), base.Spec.DeletionPolicy); err != nil {
 				klog.ErrorDepth(5, err)
 				allErrs = append(allErrs, err)
 				continue
@@ -619,8 +764,14 @@ func (deployer *Deployer) populateDescriptions(base *appsapi.Base) error {
 		}
 		desc := descTemplate.DeepCopy()
 		desc.Name = fmt.Sprintf("%s-generic", base.Name)
+		desc.Annotations = map[string]string{
+			known.RolloutRevisionAnnotation: getManifestsRevision(allManifests),
+		}
 		desc.Spec.Deployer = appsapi.DescriptionGenericDeployer
 		desc.Spec.Raw = rawObjects
+		desc.Spec.ConflictResolution = base.Spec.ConflictResolution
+		desc.Spec.DriftPolicy = base.Spec.DriftPolicy
+		desc.Spec.DeletionPolicy = base.Spec.DeletionPolicy
 		err := deployer.syncDescriptions(base, desc)
 		if err != nil {
 			allErrs = append(allErrs, err)
@@ -632,7 +783,7 @@ func (deployer *Deployer) populateDescriptions(base *appsapi.Base) error {
 	}
 
 	for key := range descsToBeDeleted {
-		if err := deployer.deleteDescription(context.TODO(), key); err != nil {
+		if err := deployer.deleteDescription(context.TODO(), key, base.Spec.DeletionPolicy); err != nil {
 			allErrs = append(allErrs, err)
 			continue
 		}
@@ -650,10 +801,18 @@ func (deployer *Deployer) syncDescriptions(base *appsapi.Base, desc *appsapi.Des
 		return err
 	}
 
+	// apply divided replicas
+	if err := deployer.localizer.ApplyReplicasToDescription(desc, base.Spec.Feeds); err != nil {
+		msg := fmt.Sprintf("Failed to apply replicas for Description %s: %v", klog.KObj(desc), err)
+		klog.ErrorDepth(5, msg)
+		deployer.recorder.Event(base, corev1.EventTypeWarning, "FailedApplyingReplicas", msg)
+		return err
+	}
+
 	// delete Description with empty feeds
 	if len(base.Spec.Feeds) == 0 {
 		// in fact, this piece of codes will never be run. Just leave it here for the last protection.
-		return deployer.deleteDescription(context.TODO(), klog.KObj(desc).String())
+		return deployer.deleteDescription(context.TODO(), klog.KObj(desc).String(), base.Spec.DeletionPolicy)
 	}
 
 	curDesc, err := deployer.descLister.Descriptions(desc.Namespace).Get(desc.Name)
@@ -663,7 +822,15 @@ func (deployer *Deployer) syncDescriptions(base *appsapi.Base, desc *appsapi.Des
 		}
 
 		// update it
-		if !reflect.DeepEqual(curDesc.Spec, desc.Spec) {
+		if !reflect.DeepEqual(curDesc.Spec, desc.Spec) ||
+			curDesc.Annotations[known.RolloutRevisionAnnotation] != desc.Annotations[known.RolloutRevisionAnnotation] {
+			// hold the update until the cluster is admitted by the rollout of the Subscription
+			if !deployer.isRolloutAdmitted(base, curDesc, desc) {
+				klog.V(4).Infof("Description %s is waiting for the rollout of revision %s", klog.KObj(desc),
+					desc.Annotations[known.RolloutRevisionAnnotation])
+				return nil
+			}
+
 			// prune feeds that are not subscribed any longer from description
 			// for helm deployer, redundant HelmReleases will be deleted after re-calculating.
 			// Here we only need to focus on generic deployer.
@@ -682,6 +849,12 @@ func (deployer *Deployer) syncDescriptions(base *appsapi.Base, desc *appsapi.Des
 			for key, value := range desc.Labels {
 				curDescCopy.Labels[key] = value
 			}
+			if len(desc.Annotations) > 0 && curDescCopy.Annotations == nil {
+				curDescCopy.Annotations = make(map[string]string)
+			}
+			for key, value := range desc.Annotations {
+				curDescCopy.Annotations[key] = value
+			}
 
 			curDescCopy.Spec = desc.Spec
 			if !utils.ContainsString(curDescCopy.Finalizers, known.AppFinalizer) {
@@ -710,13 +883,30 @@ func (deployer *Deployer) syncDescriptions(base *appsapi.Base, desc *appsapi.Des
 	return err
 }
 
-func (deployer *Deployer) deleteDescription(ctx context.Context, namespacedKey string) error {
+// deleteDescription deletes a Description, whose deletion policy is updated ahead to the given one,
+// so that the resources in the child cluster are deleted or orphaned as expected.
+func (deployer *Deployer) deleteDescription(ctx context.Context, namespacedKey string, deletionPolicy appsapi.DeletionPolicy) error {
 	// Convert the namespace/name string into a distinct namespace and name
 	ns, name, err := cache.SplitMetaNamespaceKey(namespacedKey)
 	if err != nil {
 		return err
 	}
 
+	desc, err := deployer.descLister.Descriptions(ns).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if desc.Spec.Deployer == appsapi.DescriptionGenericDeployer && desc.Spec.DeletionPolicy != deletionPolicy {
+		descCopy := desc.DeepCopy()
+		descCopy.Spec.DeletionPolicy = deletionPolicy
+		if _, err = deployer.clusternetClient.AppsV1alpha1().Descriptions(ns).Update(ctx, descCopy, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+
 	err = deployer.clusternetClient.AppsV1alpha1().Descriptions(ns).Delete(ctx, name, metav1.DeleteOptions{
 		PropagationPolicy: &deletePropagationBackground,
 	})
@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+)
+
+func TestGetFeedsWithReplicas(t *testing.T) {
+	deployFeed := appsapi.Feed{
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+		Namespace:  "default",
+		Name:       "nginx",
+	}
+	serviceFeed := appsapi.Feed{
+		Kind:       "Service",
+		APIVersion: "v1",
+		Namespace:  "default",
+		Name:       "nginx",
+	}
+	withReplicas := func(feed appsapi.Feed, replicas ...int32) appsapi.Feed {
+		feed.Replicas = replicas
+		return feed
+	}
+
+	tests := []struct {
+		name string
+		sub  *appsapi.Subscription
+		idx  int
+		want []appsapi.Feed
+	}{
+		{
+			name: "no replicas at all",
+			sub: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					Feeds: []appsapi.Feed{deployFeed, serviceFeed},
+				},
+				Status: appsapi.SubscriptionStatus{
+					BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+				},
+			},
+			idx:  1,
+			want: []appsapi.Feed{deployFeed, serviceFeed},
+		},
+		{
+			name: "divided replicas in status",
+			sub: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					Feeds: []appsapi.Feed{deployFeed, serviceFeed},
+				},
+				Status: appsapi.SubscriptionStatus{
+					BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+					Replicas: map[string]appsapi.FeedReplicas{
+						"apps/v1/Deployment/default/nginx": {3, 2},
+					},
+				},
+			},
+			idx:  1,
+			want: []appsapi.Feed{withReplicas(deployFeed, 2), serviceFeed},
+		},
+		{
+			name: "divided replicas in status take precedence over spec",
+			sub: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					Feeds: []appsapi.Feed{withReplicas(deployFeed, 7, 8)},
+				},
+				Status: appsapi.SubscriptionStatus{
+					BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+					Replicas: map[string]appsapi.FeedReplicas{
+						"apps/v1/Deployment/default/nginx": {3, 2},
+					},
+				},
+			},
+			idx:  0,
+			want: []appsapi.Feed{withReplicas(deployFeed, 3)},
+		},
+		{
+			name: "per-cluster replicas in spec",
+			sub: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					Feeds: []appsapi.Feed{withReplicas(deployFeed, 7, 8)},
+				},
+				Status: appsapi.SubscriptionStatus{
+					BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+				},
+			},
+			idx:  1,
+			want: []appsapi.Feed{withReplicas(deployFeed, 8)},
+		},
+		{
+			name: "replicas mismatching binding clusters are dropped",
+			sub: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					Feeds: []appsapi.Feed{withReplicas(deployFeed, 7)},
+				},
+				Status: appsapi.SubscriptionStatus{
+					BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+				},
+			},
+			idx:  1,
+			want: []appsapi.Feed{deployFeed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getFeedsWithReplicas(tt.sub, tt.idx)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getFeedsWithReplicas() got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetReadyCondition(t *testing.T) {
+	sub := &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default", Generation: 2},
+		Status: appsapi.SubscriptionStatus{
+			BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+		},
+	}
+	newDesc := func(namespace, name string, phase appsapi.DescriptionPhase) *appsapi.Description {
+		return &appsapi.Description{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status:     appsapi.DescriptionStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		sub        *appsapi.Subscription
+		descs      []*appsapi.Description
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "no binding clusters",
+			sub:        &appsapi.Subscription{},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NoBindingClusters",
+		},
+		{
+			name: "missing descriptions in a binding cluster",
+			sub:  sub,
+			descs: []*appsapi.Description{
+				newDesc("ns-01", "sub-generic", appsapi.DescriptionPhaseSuccess),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "DescriptionsPending",
+		},
+		{
+			name: "failed descriptions",
+			sub:  sub,
+			descs: []*appsapi.Description{
+				newDesc("ns-01", "sub-generic", appsapi.DescriptionPhaseSuccess),
+				newDesc("ns-02", "sub-generic", appsapi.DescriptionPhaseFailure),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "DescriptionsFailed",
+		},
+		{
+			name: "all descriptions succeeded",
+			sub:  sub,
+			descs: []*appsapi.Description{
+				newDesc("ns-01", "sub-generic", appsapi.DescriptionPhaseSuccess),
+				newDesc("ns-02", "sub-generic", appsapi.DescriptionPhaseSuccess),
+				newDesc("ns-02", "sub-helm", appsapi.DescriptionPhaseSuccess),
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "DescriptionsSucceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getReadyCondition(tt.sub, tt.descs)
+			if got.Type != appsapi.SubscriptionReady {
+				t.Errorf("getReadyCondition() type = %s, want %s", got.Type, appsapi.SubscriptionReady)
+			}
+			if got.Status != tt.wantStatus || got.Reason != tt.wantReason {
+				t.Errorf("getReadyCondition() = %s/%s, want %s/%s", got.Status, got.Reason, tt.wantStatus, tt.wantReason)
+			}
+			if got.ObservedGeneration != tt.sub.Generation {
+				t.Errorf("getReadyCondition() observed generation = %d, want %d", got.ObservedGeneration, tt.sub.Generation)
+			}
+		})
+	}
+}
@@ -153,8 +153,18 @@ func (deployer *Deployer) handleDescription(desc *appsapi.Description) error {
 			discoveryRESTMapper, desc, deployer.recorder)
 	}
 
-	return utils.ApplyDescription(context.TODO(), deployer.clusternetClient, dynamicClient,
-		discoveryRESTMapper, desc, deployer.recorder, false, nil)
+	if utils.DescriptionNeedsApplying(desc) {
+		err = utils.ApplyDescription(context.TODO(), deployer.clusternetClient, dynamicClient,
+			discoveryRESTMapper, desc, deployer.recorder, false, nil)
+	} else {
+		err = utils.RefreshDescriptionStatus(context.TODO(), deployer.clusternetClient, dynamicClient,
+			discoveryRESTMapper, desc, deployer.recorder, nil)
+	}
+	if err == nil && desc.Status.Phase == appsapi.DescriptionPhaseProgressing {
+		// re-assess the health of resources until they settle down
+		deployer.descController.EnqueueAfter(desc, known.DefaultRetryPeriod)
+	}
+	return err
 }
 
 func (deployer *Deployer) getDynamicClient(desc *appsapi.Description) (dynamic.Interface, meta.RESTMapper, error) {
@@ -232,8 +242,9 @@ func (deployer *Deployer) PruneFeedsInDescription(ctx context.Context, current,
 	}
 
 	// prune unused feeds
-	for _, resource := range resourcesToBeDeleted {
-		err = utils.DeleteResourceWithRetry(ctx, dynamicClient, discoveryRESTMapper, resource)
+	for _, resource := range utils.SortResourcesForDeletion(resourcesToBeDeleted) {
+		err = utils.OffloadResourceWithRetry(ctx, dynamicClient, discoveryRESTMapper, resource,
+			utils.GetDeletionPolicy(resource, desired.Spec.DeletionPolicy))
 		if err != nil {
 			allErrs = append(allErrs, err)
 			msg := fmt.Sprintf("Failed to prune %s %s: %v", resource.GetKind(), klog.KObj(resource), err)
@@ -0,0 +1,453 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	"github.com/clusternet/clusternet/pkg/known"
+	"github.com/clusternet/clusternet/pkg/utils"
+)
+
+// clusterRolloutState is the state of the Descriptions in a binding cluster for a revision.
+type clusterRolloutState struct {
+	// updated means the Descriptions have been updated to the revision.
+	updated bool
+	// succeeded means the updated Descriptions have been deployed successfully.
+	succeeded bool
+	// failed means the updated Descriptions failed to be deployed.
+	failed bool
+}
+
+// getManifestsRevision returns the revision of the given Manifests, which changes whenever their templates change.
+// HelmCharts are not counted, since their updates are not delivered by Descriptions.
+func getManifestsRevision(manifests []*appsapi.Manifest) string {
+	templates := make([]string, 0, len(manifests))
+	for _, manifest := range manifests {
+		templates = append(templates, string(manifest.Template.Raw))
+	}
+	sort.Strings(templates)
+
+	hasher := fnv.New32a()
+	for _, template := range templates {
+		hasher.Write([]byte(template))
+	}
+	return strconv.FormatUint(uint64(hasher.Sum32()), 16)
+}
+
+// getRolloutRevision returns the revision of the Manifests that a Subscription feeds,
+// which is empty if no Manifests are fed.
+func (deployer *Deployer) getRolloutRevision(sub *appsapi.Subscription) (string, error) {
+	var allManifests []*appsapi.Manifest
+	for _, feed := range sub.Spec.Feeds {
+		if feed.Kind == helmChartKind.Kind {
+			continue
+		}
+		manifests, err := utils.ListManifestsBySelector(deployer.reservedNamespace, deployer.mfstLister, feed)
+		if err != nil {
+			return "", err
+		}
+		allManifests = append(allManifests, manifests...)
+	}
+	if len(allManifests) == 0 {
+		return "", nil
+	}
+	return getManifestsRevision(allManifests), nil
+}
+
+// assignWaves groups the binding clusters into rollout waves, keeping the order of the waves.
+// A cluster belongs to the first wave it matches, and the clusters matching no waves fall into an implicit final wave.
+// Waves without any clusters are dropped.
+func assignWaves(strategy *appsapi.RolloutStrategy, bindingClusters []string, clusterLabels map[string]labels.Set) ([][]string, error) {
+	selectors := make([]labels.Selector, len(strategy.Waves))
+	for idx, wave := range strategy.Waves {
+		if wave.ClusterSelector == nil {
+			selectors[idx] = labels.Nothing()
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(wave.ClusterSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cluster selector of rollout wave %d: %v", idx, err)
+		}
+		selectors[idx] = selector
+	}
+
+	waves := make([][]string, len(strategy.Waves)+1)
+	for _, cluster := range bindingClusters {
+		idx := 0
+		for ; idx < len(strategy.Waves); idx++ {
+			if utils.ContainsString(strategy.Waves[idx].Clusters, cluster) ||
+				selectors[idx].Matches(clusterLabels[cluster]) {
+				break
+			}
+		}
+		waves[idx] = append(waves[idx], cluster)
+	}
+
+	var result [][]string
+	for _, wave := range waves {
+		if len(wave) > 0 {
+			result = append(result, wave)
+		}
+	}
+	return result, nil
+}
+
+// getClusterRolloutStates returns the rollout states of the binding clusters for a revision,
+// which are summarized from the generic Descriptions in their namespaces.
+func getClusterRolloutStates(bindingClusters []string, descs []*appsapi.Description, revision string) map[string]clusterRolloutState {
+	descsInNamespace := make(map[string][]*appsapi.Description)
+	for _, desc := range descs {
+		if desc.DeletionTimestamp != nil || desc.Spec.Deployer != appsapi.DescriptionGenericDeployer {
+			continue
+		}
+		descsInNamespace[desc.Namespace] = append(descsInNamespace[desc.Namespace], desc)
+	}
+
+	states := make(map[string]clusterRolloutState)
+	for _, cluster := range bindingClusters {
+		namespace, _, err := cache.SplitMetaNamespaceKey(cluster)
+		if err != nil || len(descsInNamespace[namespace]) == 0 {
+			continue
+		}
+
+		state := clusterRolloutState{updated: true, succeeded: true}
+		for _, desc := range descsInNamespace[namespace] {
+			if desc.Annotations[known.RolloutRevisionAnnotation] != revision {
+				state = clusterRolloutState{}
+				break
+			}
+			// status of a former generation is not counted
+			observed := desc.Status.ObservedGeneration == 0 || desc.Status.ObservedGeneration == desc.Generation
+			switch {
+			case observed && desc.Status.Phase == appsapi.DescriptionPhaseSuccess:
+			case observed && (desc.Status.Phase == appsapi.DescriptionPhaseFailure ||
+				desc.Status.Phase == appsapi.DescriptionPhaseDegraded):
+				state.succeeded = false
+				state.failed = true
+			default:
+				state.succeeded = false
+			}
+		}
+		states[cluster] = state
+	}
+	return states
+}
+
+// planRollout moves the rollout of a revision forward, and returns the new rollout status,
+// as well as the duration after which the rollout should be checked again if it is paused.
+// promotedWave is the index of the last wave promoted manually.
+func planRollout(strategy *appsapi.RolloutStrategy, current *appsapi.RolloutStatus, revision string, waves [][]string,
+	states map[string]clusterRolloutState, promotedWave int32, now metav1.Time) (*appsapi.RolloutStatus, time.Duration) {
+	rollout := current.DeepCopy()
+	if rollout == nil || rollout.Revision != revision {
+		rollout = &appsapi.RolloutStatus{
+			Revision:           revision,
+			Phase:              appsapi.RolloutProgressing,
+			LastTransitionTime: now,
+		}
+	}
+	setPhase := func(phase appsapi.RolloutPhase, message string) {
+		if rollout.Phase != phase {
+			rollout.LastTransitionTime = now
+		}
+		rollout.Phase = phase
+		rollout.Message = message
+	}
+
+	rollout.TotalWaves = int32(len(waves))
+	if len(waves) == 0 {
+		rollout.CurrentWave = 0
+		rollout.UpdatedClusters = nil
+		setPhase(appsapi.RolloutCompleted, "no binding clusters to roll out to")
+		return rollout, 0
+	}
+	if rollout.CurrentWave >= rollout.TotalWaves {
+		rollout.CurrentWave = rollout.TotalWaves - 1
+	}
+
+	// clusters that are not bound any longer are dropped
+	bindingClusters := sets.NewString()
+	for _, wave := range waves {
+		bindingClusters.Insert(wave...)
+	}
+	admitted := sets.NewString()
+	for _, cluster := range rollout.UpdatedClusters {
+		if bindingClusters.Has(cluster) {
+			admitted.Insert(cluster)
+		}
+	}
+
+	var requeueAfter time.Duration
+	for {
+		for _, wave := range waves[:rollout.CurrentWave] {
+			admitted.Insert(wave...)
+		}
+
+		wave := waves[rollout.CurrentWave]
+		maxUnavailable := len(wave)
+		if strategy.MaxUnavailableClusters != nil {
+			maxUnavailable = int(*strategy.MaxUnavailableClusters)
+		}
+
+		var failed []string
+		var updating int
+		for _, cluster := range wave {
+			if !admitted.Has(cluster) {
+				continue
+			}
+			state := states[cluster]
+			if state.failed {
+				failed = append(failed, cluster)
+			}
+			if !state.succeeded {
+				updating++
+			}
+		}
+		if len(failed) > 0 {
+			setPhase(appsapi.RolloutHalted, fmt.Sprintf("failed to update clusters %v in wave %d", failed, rollout.CurrentWave))
+			break
+		}
+
+		// clusters that are already up to date are admitted without taking up the budget
+		completed := true
+		for _, cluster := range wave {
+			if admitted.Has(cluster) {
+				completed = completed && states[cluster].succeeded
+				continue
+			}
+			if states[cluster].succeeded {
+				admitted.Insert(cluster)
+				continue
+			}
+			completed = false
+			if updating < maxUnavailable {
+				admitted.Insert(cluster)
+				updating++
+			}
+		}
+		if !completed {
+			setPhase(appsapi.RolloutProgressing, fmt.Sprintf("updating %d clusters in wave %d", updating, rollout.CurrentWave))
+			break
+		}
+
+		if rollout.CurrentWave == rollout.TotalWaves-1 {
+			setPhase(appsapi.RolloutCompleted, fmt.Sprintf("all the %d waves are completed", rollout.TotalWaves))
+			break
+		}
+		// no need to hold the next wave if it has nothing to update
+		upToDate := true
+		for _, cluster := range waves[rollout.CurrentWave+1] {
+			if !states[cluster].succeeded {
+				upToDate = false
+				break
+			}
+		}
+
+		if !upToDate && strategy.ManualPromotion && promotedWave <= rollout.CurrentWave {
+			setPhase(appsapi.RolloutWaitingForPromotion, fmt.Sprintf("wave %d is completed, waiting for wave %d to be promoted",
+				rollout.CurrentWave, rollout.CurrentWave+1))
+			break
+		}
+		if !upToDate && strategy.PauseBetweenWaves != nil && strategy.PauseBetweenWaves.Duration > 0 {
+			setPhase(appsapi.RolloutPaused, fmt.Sprintf("wave %d is completed, pausing for %s before wave %d",
+				rollout.CurrentWave, strategy.PauseBetweenWaves.Duration, rollout.CurrentWave+1))
+			remaining := rollout.LastTransitionTime.Add(strategy.PauseBetweenWaves.Duration).Sub(now.Time)
+			if remaining > 0 {
+				requeueAfter = remaining
+				break
+			}
+		}
+
+		// start next wave
+		rollout.CurrentWave++
+		rollout.LastTransitionTime = now
+		rollout.Phase = appsapi.RolloutProgressing
+	}
+
+	rollout.UpdatedClusters = admitted.List()
+	return rollout, requeueAfter
+}
+
+// syncRollout moves the rollout of a Subscription forward, and populates Descriptions to the newly admitted clusters.
+func (deployer *Deployer) syncRollout(sub *appsapi.Subscription) error {
+	var revision string
+	if sub.Spec.RolloutStrategy != nil {
+		var err error
+		revision, err = deployer.getRolloutRevision(sub)
+		if err != nil {
+			return err
+		}
+	}
+	if len(revision) == 0 {
+		if sub.Status.Rollout == nil {
+			return nil
+		}
+		return utils.UpdateSubscriptionStatus(context.TODO(), deployer.clusternetClient, sub, func(status *appsapi.SubscriptionStatus) {
+			status.Rollout = nil
+		})
+	}
+
+	// a new rollout resets the manual promotion
+	promotedWave := int32(-1)
+	if val, ok := sub.Annotations[known.RolloutPromotedWaveAnnotation]; ok {
+		if sub.Status.Rollout == nil || sub.Status.Rollout.Revision != revision {
+			if err := deployer.removePromotedWaveAnnotation(sub); err != nil {
+				return err
+			}
+		} else if wave, err := strconv.ParseInt(val, 10, 32); err == nil {
+			promotedWave = int32(wave)
+		}
+	}
+
+	clusterLabels := make(map[string]labels.Set)
+	for _, namespacedName := range sub.Status.BindingClusters {
+		namespace, name, err := cache.SplitMetaNamespaceKey(namespacedName)
+		if err != nil {
+			continue
+		}
+		mcls, err := deployer.clusterLister.ManagedClusters(namespace).Get(name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		clusterLabels[namespacedName] = mcls.Labels
+	}
+	waves, err := assignWaves(sub.Spec.RolloutStrategy, sub.Status.BindingClusters, clusterLabels)
+	if err != nil {
+		return err
+	}
+
+	descs, err := deployer.descLister.List(labels.SelectorFromSet(labels.Set{
+		known.ConfigSubscriptionNameLabel:      sub.Name,
+		known.ConfigSubscriptionNamespaceLabel: sub.Namespace,
+		known.ConfigSubscriptionUIDLabel:       string(sub.UID),
+	}))
+	if err != nil {
+		return err
+	}
+	states := getClusterRolloutStates(sub.Status.BindingClusters, descs, revision)
+
+	rollout, requeueAfter := planRollout(sub.Spec.RolloutStrategy, sub.Status.Rollout, revision, waves, states,
+		promotedWave, metav1.Now())
+	if sub.Status.Rollout == nil || sub.Status.Rollout.Phase != rollout.Phase || sub.Status.Rollout.CurrentWave != rollout.CurrentWave {
+		deployer.recorder.Event(sub, corev1.EventTypeNormal, fmt.Sprintf("Rollout%s", rollout.Phase), rollout.Message)
+	}
+	err = utils.UpdateSubscriptionStatus(context.TODO(), deployer.clusternetClient, sub, func(status *appsapi.SubscriptionStatus) {
+		status.Rollout = rollout
+	})
+	if err != nil {
+		return err
+	}
+	if requeueAfter > 0 {
+		deployer.subsController.EnqueueAfter(sub, requeueAfter)
+	}
+
+	// populate Descriptions to the admitted clusters that have not been updated
+	var allErrs []error
+	for _, cluster := range rollout.UpdatedClusters {
+		if states[cluster].updated {
+			continue
+		}
+		namespace, _, err := cache.SplitMetaNamespaceKey(cluster)
+		if err != nil {
+			continue
+		}
+		base, err := deployer.baseLister.Bases(namespace).Get(sub.Name)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, err)
+			}
+			continue
+		}
+		if err = deployer.populateDescriptions(base); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return utilerrors.NewAggregate(allErrs)
+}
+
+// isRolloutAdmitted tells whether an existing Description can be updated to the desired one.
+// Updates of the feeds are held until the cluster is admitted by the rollout of the Subscription,
+// while other changes, such as divided replicas, are never held.
+func (deployer *Deployer) isRolloutAdmitted(base *appsapi.Base, curDesc, desc *appsapi.Description) bool {
+	revision := desc.Annotations[known.RolloutRevisionAnnotation]
+	if curDesc.Annotations[known.RolloutRevisionAnnotation] == revision {
+		return true
+	}
+
+	sub, err := deployer.subLister.Subscriptions(base.Labels[known.ConfigSubscriptionNamespaceLabel]).
+		Get(base.Labels[known.ConfigSubscriptionNameLabel])
+	if err != nil || sub.UID != types.UID(base.Labels[known.ConfigSubscriptionUIDLabel]) {
+		return true
+	}
+	if sub.Spec.RolloutStrategy == nil {
+		return true
+	}
+
+	rollout := sub.Status.Rollout
+	if rollout != nil && rollout.Revision == revision {
+		for _, cluster := range rollout.UpdatedClusters {
+			namespace, _, err := cache.SplitMetaNamespaceKey(cluster)
+			if err == nil && namespace == base.Namespace {
+				return true
+			}
+		}
+	}
+
+	// let the Subscription start or move forward its rollout
+	deployer.subsController.Enqueue(sub)
+	return false
+}
+
+func (deployer *Deployer) removePromotedWaveAnnotation(sub *appsapi.Subscription) error {
+	option := utils.MetaOption{MetaData: utils.MetaData{Annotations: map[string]*string{
+		known.RolloutPromotedWaveAnnotation: nil,
+	}}}
+	patchData, err := json.Marshal(option)
+	if err != nil {
+		return err
+	}
+
+	_, err = deployer.clusternetClient.AppsV1alpha1().Subscriptions(sub.Namespace).Patch(context.TODO(),
+		sub.Name, types.MergePatchType, patchData, metav1.PatchOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		klog.WarningDepth(4, fmt.Sprintf("failed to remove annotation %s from Subscription %s: %v",
+			known.RolloutPromotedWaveAnnotation, klog.KObj(sub), err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilpointer "k8s.io/utils/pointer"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+)
+
+func TestAssignWaves(t *testing.T) {
+	bindingClusters := []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03", "ns-04/cluster-04"}
+	clusterLabels := map[string]labels.Set{
+		"ns-01/cluster-01": {"env": "canary"},
+		"ns-02/cluster-02": {"env": "prod"},
+		"ns-03/cluster-03": {"env": "canary"},
+		"ns-04/cluster-04": {"env": "prod"},
+	}
+
+	tests := []struct {
+		name     string
+		strategy *appsapi.RolloutStrategy
+		want     [][]string
+	}{
+		{
+			name:     "no waves",
+			strategy: &appsapi.RolloutStrategy{},
+			want:     [][]string{bindingClusters},
+		},
+		{
+			name: "waves by selector and explicit clusters",
+			strategy: &appsapi.RolloutStrategy{
+				Waves: []appsapi.RolloutWave{
+					{
+						Name:     "first",
+						Clusters: []string{"ns-02/cluster-02"},
+					},
+					{
+						Name: "canary",
+						ClusterSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"env": "canary"},
+						},
+					},
+				},
+			},
+			want: [][]string{
+				{"ns-02/cluster-02"},
+				{"ns-01/cluster-01", "ns-03/cluster-03"},
+				{"ns-04/cluster-04"},
+			},
+		},
+		{
+			name: "empty waves are dropped",
+			strategy: &appsapi.RolloutStrategy{
+				Waves: []appsapi.RolloutWave{
+					{
+						Name: "nothing",
+						ClusterSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"env": "dev"},
+						},
+					},
+					{
+						Name: "all",
+						ClusterSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{
+								{Key: "env", Operator: metav1.LabelSelectorOpExists},
+							},
+						},
+					},
+				},
+			},
+			want: [][]string{bindingClusters},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := assignWaves(tt.strategy, bindingClusters, clusterLabels)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assignWaves() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetClusterRolloutStates(t *testing.T) {
+	makeDesc := func(namespace, revision string, phase appsapi.DescriptionPhase, generation, observed int64) *appsapi.Description {
+		return &appsapi.Description{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        "demo-generic",
+				Generation:  generation,
+				Annotations: map[string]string{"apps.clusternet.io/rollout-revision": revision},
+			},
+			Spec: appsapi.DescriptionSpec{
+				Deployer: appsapi.DescriptionGenericDeployer,
+			},
+			Status: appsapi.DescriptionStatus{
+				Phase:              phase,
+				ObservedGeneration: observed,
+			},
+		}
+	}
+
+	bindingClusters := []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03", "ns-04/cluster-04", "ns-05/cluster-05"}
+	descs := []*appsapi.Description{
+		makeDesc("ns-01", "new", appsapi.DescriptionPhaseSuccess, 2, 2),
+		makeDesc("ns-02", "new", appsapi.DescriptionPhaseSuccess, 2, 1),
+		makeDesc("ns-03", "new", appsapi.DescriptionPhaseFailure, 2, 2),
+		makeDesc("ns-04", "old", appsapi.DescriptionPhaseSuccess, 1, 1),
+	}
+
+	got := getClusterRolloutStates(bindingClusters, descs, "new")
+	want := map[string]clusterRolloutState{
+		"ns-01/cluster-01": {updated: true, succeeded: true},
+		"ns-02/cluster-02": {updated: true},
+		"ns-03/cluster-03": {updated: true, failed: true},
+		"ns-04/cluster-04": {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getClusterRolloutStates() = %v, want %v", got, want)
+	}
+}
+
+func TestPlanRollout(t *testing.T) {
+	now := metav1.NewTime(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	waves := [][]string{
+		{"ns-01/cluster-01"},
+		{"ns-02/cluster-02", "ns-03/cluster-03"},
+	}
+	succeeded := clusterRolloutState{updated: true, succeeded: true}
+
+	tests := []struct {
+		name             string
+		strategy         *appsapi.RolloutStrategy
+		current          *appsapi.RolloutStatus
+		states           map[string]clusterRolloutState
+		promotedWave     int32
+		want             *appsapi.RolloutStatus
+		wantRequeueAfter time.Duration
+	}{
+		{
+			name:     "start a new rollout",
+			strategy: &appsapi.RolloutStrategy{},
+			current: &appsapi.RolloutStatus{
+				Revision:        "old",
+				CurrentWave:     1,
+				Phase:           appsapi.RolloutCompleted,
+				UpdatedClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03"},
+			},
+			promotedWave: -1,
+			want: &appsapi.RolloutStatus{
+				Revision:           "new",
+				TotalWaves:         2,
+				Phase:              appsapi.RolloutProgressing,
+				Message:            "updating 1 clusters in wave 0",
+				UpdatedClusters:    []string{"ns-01/cluster-01"},
+				LastTransitionTime: now,
+			},
+		},
+		{
+			name: "limit updating clusters in a wave",
+			strategy: &appsapi.RolloutStrategy{
+				MaxUnavailableClusters: utilpointer.Int32Ptr(1),
+			},
+			current: &appsapi.RolloutStatus{
+				Revision:        "new",
+				TotalWaves:      2,
+				Phase:           appsapi.RolloutProgressing,
+				UpdatedClusters: []string{"ns-01/cluster-01"},
+			},
+			states: map[string]clusterRolloutState{
+				"ns-01/cluster-01": succeeded,
+			},
+			promotedWave: -1,
+			want: &appsapi.RolloutStatus{
+				Revision:           "new",
+				CurrentWave:        1,
+				TotalWaves:         2,
+				Phase:              appsapi.RolloutProgressing,
+				Message:            "updating 1 clusters in wave 1",
+				UpdatedClusters:    []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+				LastTransitionTime: now,
+			},
+		},
+		{
+			name: "wait for promotion",
+			strategy: &appsapi.RolloutStrategy{
+				ManualPromotion: true,
+			},
+			current: &appsapi.RolloutStatus{
+				Revision:        "new",
+				TotalWaves:      2,
+				Phase:           appsapi.RolloutProgressing,
+				UpdatedClusters: []string{"ns-01/cluster-01"},
+			},
+			states: map[string]clusterRolloutState{
+				"ns-01/cluster-01": succeeded,
+			},
+			promotedWave: -1,
+			want: &appsapi.RolloutStatus{
+				Revision:           "new",
+				TotalWaves:         2,
+				Phase:              appsapi.RolloutWaitingForPromotion,
+				Message:            "wave 0 is completed, waiting for wave 1 to be promoted",
+				UpdatedClusters:    []string{"ns-01/cluster-01"},
+				LastTransitionTime: now,
+			},
+		},
+		{
+			name: "pause between waves",
+			strategy: &appsapi.RolloutStrategy{
+				PauseBetweenWaves: &metav1.Duration{Duration: time.Minute},
+			},
+			current: &appsapi.RolloutStatus{
+				Revision:           "new",
+				TotalWaves:         2,
+				Phase:              appsapi.RolloutPaused,
+				UpdatedClusters:    []string{"ns-01/cluster-01"},
+				LastTransitionTime: metav1.NewTime(now.Add(-20 * time.Second)),
+			},
+			states: map[string]clusterRolloutState{
+				"ns-01/cluster-01": succeeded,
+			},
+			promotedWave: -1,
+			want: &appsapi.RolloutStatus{
+				Revision:           "new",
+				TotalWaves:         2,
+				Phase:              appsapi.RolloutPaused,
+				Message:            "wave 0 is completed, pausing for 1m0s before wave 1",
+				UpdatedClusters:    []string{"ns-01/cluster-01"},
+				LastTransitionTime: metav1.NewTime(now.Add(-20 * time.Second)),
+			},
+			wantRequeueAfter: 40 * time.Second,
+		},
+		{
+			name: "halt on failures",
+			strategy: &appsapi.RolloutStrategy{
+				ManualPromotion: true,
+			},
+			current: &appsapi.RolloutStatus{
+				Revision:        "new",
+				CurrentWave:     1,
+				TotalWaves:      2,
+				Phase:           appsapi.RolloutProgressing,
+				UpdatedClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03"},
+			},
+			states: map[string]clusterRolloutState{
+				"ns-01/cluster-01": succeeded,
+				"ns-02/cluster-02": succeeded,
+				"ns-03/cluster-03": {updated: true, failed: true},
+			},
+			promotedWave: 1,
+			want: &appsapi.RolloutStatus{
+				Revision:           "new",
+				CurrentWave:        1,
+				TotalWaves:         2,
+				Phase:              appsapi.RolloutHalted,
+				Message:            "failed to update clusters [ns-03/cluster-03] in wave 1",
+				UpdatedClusters:    []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03"},
+				LastTransitionTime: now,
+			},
+		},
+		{
+			name: "complete up-to-date waves without holding",
+			strategy: &appsapi.RolloutStrategy{
+				ManualPromotion: true,
+			},
+			current: nil,
+			states: map[string]clusterRolloutState{
+				"ns-01/cluster-01": succeeded,
+				"ns-02/cluster-02": succeeded,
+				"ns-03/cluster-03": succeeded,
+			},
+			promotedWave: -1,
+			want: &appsapi.RolloutStatus{
+				Revision:           "new",
+				CurrentWave:        1,
+				TotalWaves:         2,
+				Phase:              appsapi.RolloutCompleted,
+				Message:            "all the 2 waves are completed",
+				UpdatedClusters:    []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03"},
+				LastTransitionTime: now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, requeueAfter := planRollout(tt.strategy, tt.current, "new", waves, tt.states, tt.promotedWave, now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planRollout() = %+v, want %+v", got, tt.want)
+			}
+			if requeueAfter != tt.wantRequeueAfter {
+				t.Errorf("planRollout() requeueAfter = %v, want %v", requeueAfter, tt.wantRequeueAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+
+	"k8s.io/client-go/tools/cache"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	"github.com/clusternet/clusternet/pkg/utils"
+)
+
+// observedStatus holds the well-known fields in the `.status` of workloads and Jobs.
+type observedStatus struct {
+	// Deployment, ReplicaSet and StatefulSet
+	Replicas          int32 `json:"replicas"`
+	ReadyReplicas     int32 `json:"readyReplicas"`
+	AvailableReplicas int32 `json:"availableReplicas"`
+	UpdatedReplicas   int32 `json:"updatedReplicas"`
+
+	// DaemonSet
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+	NumberReady            int32 `json:"numberReady"`
+	NumberAvailable        int32 `json:"numberAvailable"`
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled"`
+
+	// Job
+	Active    int32 `json:"active"`
+	Succeeded int32 `json:"succeeded"`
+	Failed    int32 `json:"failed"`
+
+	Conditions []struct {
+		Type   string `json:"type"`
+		Status string `json:"status"`
+	} `json:"conditions"`
+}
+
+// getFeedStatus extracts the well-known status fields of a resource from its observed status.
+func getFeedStatus(manifestStatus *appsapi.ManifestStatus) appsapi.FeedStatus {
+	// resources without status are available as long as they exist
+	feedStatus := appsapi.FeedStatus{Available: true}
+	if len(manifestStatus.ObservedStatus.Raw) == 0 {
+		return feedStatus
+	}
+
+	status := &observedStatus{}
+	if err := json.Unmarshal(manifestStatus.ObservedStatus.Raw, status); err != nil {
+		return feedStatus
+	}
+	conditions := make(map[string]string)
+	for _, condition := range status.Conditions {
+		conditions[condition.Type] = condition.Status
+	}
+
+	switch manifestStatus.Kind {
+	case "DaemonSet":
+		feedStatus.Replicas = status.DesiredNumberScheduled
+		feedStatus.ReadyReplicas = status.NumberReady
+		feedStatus.AvailableReplicas = status.NumberAvailable
+		feedStatus.UpdatedReplicas = status.UpdatedNumberScheduled
+		feedStatus.Available = status.NumberAvailable >= status.DesiredNumberScheduled
+	case "Job":
+		feedStatus.Active = status.Active
+		feedStatus.Succeeded = status.Succeeded
+		feedStatus.Failed = status.Failed
+		feedStatus.Available = conditions["Complete"] == "True"
+	default:
+		feedStatus.Replicas = status.Replicas
+		feedStatus.ReadyReplicas = status.ReadyReplicas
+		feedStatus.AvailableReplicas = status.AvailableReplicas
+		feedStatus.UpdatedReplicas = status.UpdatedReplicas
+		if available, ok := conditions["Available"]; ok {
+			feedStatus.Available = available == "True"
+		} else {
+			feedStatus.Available = status.ReadyReplicas >= status.Replicas
+		}
+	}
+	return feedStatus
+}
+
+// getAggregatedStatuses aggregates the statuses of the feeds reported by the Descriptions in all the binding clusters,
+// and returns the number of desired and available feeds in all the binding clusters. HelmCharts are not counted.
+func getAggregatedStatuses(sub *appsapi.Subscription, descs []*appsapi.Description) ([]appsapi.AggregatedStatus, int, int) {
+	// manifest statuses reported in each namespace, keyed by feed
+	statusesInNamespace := make(map[string]map[string]*appsapi.ManifestStatus)
+	for _, desc := range descs {
+		if desc.DeletionTimestamp != nil || desc.Spec.Deployer != appsapi.DescriptionGenericDeployer {
+			continue
+		}
+		if statusesInNamespace[desc.Namespace] == nil {
+			statusesInNamespace[desc.Namespace] = make(map[string]*appsapi.ManifestStatus)
+		}
+		for idx := range desc.Status.ManifestStatuses {
+			manifestStatus := &desc.Status.ManifestStatuses[idx]
+			statusesInNamespace[desc.Namespace][utils.GetFeedKey(manifestStatus.Feed)] = manifestStatus
+		}
+	}
+
+	var aggregatedStatuses []appsapi.AggregatedStatus
+	var desired, available int
+	for _, feed := range sub.Spec.Feeds {
+		if feed.Kind == helmChartKind.Kind {
+			continue
+		}
+		feedKey := utils.GetFeedKey(feed)
+
+		aggregatedStatus := appsapi.AggregatedStatus{
+			Feed: appsapi.Feed{
+				Kind:       feed.Kind,
+				APIVersion: feed.APIVersion,
+				Namespace:  feed.Namespace,
+				Name:       feed.Name,
+			},
+		}
+		summary := &aggregatedStatus.FeedStatusSummary
+		summary.Available = len(sub.Status.BindingClusters) > 0
+		for _, cluster := range sub.Status.BindingClusters {
+			desired++
+			namespace, _, err := cache.SplitMetaNamespaceKey(cluster)
+			if err != nil {
+				summary.Available = false
+				continue
+			}
+			manifestStatus, ok := statusesInNamespace[namespace][feedKey]
+			if !ok {
+				summary.Available = false
+				continue
+			}
+
+			feedStatus := getFeedStatus(manifestStatus)
+			aggregatedStatus.FeedStatusDetails = append(aggregatedStatus.FeedStatusDetails, appsapi.FeedStatusPerCluster{
+				Cluster:    cluster,
+				FeedStatus: feedStatus,
+			})
+			if feedStatus.Available {
+				available++
+			} else {
+				summary.Available = false
+			}
+			summary.Replicas += feedStatus.Replicas
+			summary.ReadyReplicas += feedStatus.ReadyReplicas
+			summary.AvailableReplicas += feedStatus.AvailableReplicas
+			summary.UpdatedReplicas += feedStatus.UpdatedReplicas
+			summary.Active += feedStatus.Active
+			summary.Succeeded += feedStatus.Succeeded
+			summary.Failed += feedStatus.Failed
+		}
+		aggregatedStatuses = append(aggregatedStatuses, aggregatedStatus)
+	}
+	return aggregatedStatuses, desired, available
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+)
+
+func TestGetFeedStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   string
+		status string
+		want   appsapi.FeedStatus
+	}{
+		{
+			name: "resource without status",
+			kind: "Service",
+			want: appsapi.FeedStatus{Available: true},
+		},
+		{
+			name:   "available deployment",
+			kind:   "Deployment",
+			status: `{"replicas":3,"readyReplicas":3,"availableReplicas":3,"updatedReplicas":3,"conditions":[{"type":"Available","status":"True"}]}`,
+			want: appsapi.FeedStatus{
+				Available:         true,
+				Replicas:          3,
+				ReadyReplicas:     3,
+				AvailableReplicas: 3,
+				UpdatedReplicas:   3,
+			},
+		},
+		{
+			name:   "statefulset not ready",
+			kind:   "StatefulSet",
+			status: `{"replicas":3,"readyReplicas":1,"updatedReplicas":3}`,
+			want: appsapi.FeedStatus{
+				Replicas:        3,
+				ReadyReplicas:   1,
+				UpdatedReplicas: 3,
+			},
+		},
+		{
+			name:   "daemonset",
+			kind:   "DaemonSet",
+			status: `{"desiredNumberScheduled":2,"numberReady":2,"numberAvailable":2,"updatedNumberScheduled":2}`,
+			want: appsapi.FeedStatus{
+				Available:         true,
+				Replicas:          2,
+				ReadyReplicas:     2,
+				AvailableReplicas: 2,
+				UpdatedReplicas:   2,
+			},
+		},
+		{
+			name:   "running job",
+			kind:   "Job",
+			status: `{"active":1,"failed":1}`,
+			want: appsapi.FeedStatus{
+				Active: 1,
+				Failed: 1,
+			},
+		},
+		{
+			name:   "completed job",
+			kind:   "Job",
+			status: `{"succeeded":1,"conditions":[{"type":"Complete","status":"True"}]}`,
+			want: appsapi.FeedStatus{
+				Available: true,
+				Succeeded: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifestStatus := &appsapi.ManifestStatus{
+				Feed: appsapi.Feed{Kind: tt.kind},
+			}
+			if len(tt.status) > 0 {
+				manifestStatus.ObservedStatus = runtime.RawExtension{Raw: []byte(tt.status)}
+			}
+			if got := getFeedStatus(manifestStatus); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getFeedStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetAggregatedStatuses(t *testing.T) {
+	deployFeed := appsapi.Feed{
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+		Namespace:  "default",
+		Name:       "nginx",
+	}
+	chartFeed := appsapi.Feed{
+		Kind:       "HelmChart",
+		APIVersion: "apps.clusternet.io/v1alpha1",
+		Namespace:  "default",
+		Name:       "mysql",
+	}
+	makeDesc := func(namespace, status string) *appsapi.Description {
+		return &appsapi.Description{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "demo-generic",
+			},
+			Spec: appsapi.DescriptionSpec{
+				Deployer: appsapi.DescriptionGenericDeployer,
+			},
+			Status: appsapi.DescriptionStatus{
+				ManifestStatuses: []appsapi.ManifestStatus{
+					{
+						Feed:           deployFeed,
+						ObservedStatus: runtime.RawExtension{Raw: []byte(status)},
+					},
+				},
+			},
+		}
+	}
+
+	sub := &appsapi.Subscription{
+		Spec: appsapi.SubscriptionSpec{
+			Feeds: []appsapi.Feed{deployFeed, chartFeed},
+		},
+		Status: appsapi.SubscriptionStatus{
+			BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03"},
+		},
+	}
+	descs := []*appsapi.Description{
+		makeDesc("ns-01", `{"replicas":2,"readyReplicas":2,"availableReplicas":2,"updatedReplicas":2}`),
+		makeDesc("ns-02", `{"replicas":2,"readyReplicas":1,"availableReplicas":1,"updatedReplicas":2}`),
+	}
+
+	got, desired, available := getAggregatedStatuses(sub, descs)
+	want := []appsapi.AggregatedStatus{
+		{
+			Feed: deployFeed,
+			FeedStatusSummary: appsapi.FeedStatus{
+				Replicas:          4,
+				ReadyReplicas:     3,
+				AvailableReplicas: 3,
+				UpdatedReplicas:   4,
+			},
+			FeedStatusDetails: []appsapi.FeedStatusPerCluster{
+				{
+					Cluster: "ns-01/cluster-01",
+					FeedStatus: appsapi.FeedStatus{
+						Available:         true,
+						Replicas:          2,
+						ReadyReplicas:     2,
+						AvailableReplicas: 2,
+						UpdatedReplicas:   2,
+					},
+				},
+				{
+					Cluster: "ns-02/cluster-02",
+					FeedStatus: appsapi.FeedStatus{
+						Replicas:          2,
+						ReadyReplicas:     1,
+						AvailableReplicas: 1,
+						UpdatedReplicas:   2,
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getAggregatedStatuses() = %+v, want %+v", got, want)
+	}
+	if desired != 3 || available != 1 {
+		t.Errorf("getAggregatedStatuses() desired = %d, available = %d, want 3 and 1", desired, available)
+	}
+}
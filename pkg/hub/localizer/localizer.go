@@ -311,6 +311,42 @@ func (l *Localizer) ApplyOverridesToDescription(desc *appsapi.Description) error
 	}
 }
 
+// ApplyReplicasToDescription injects the replicas of feeds into the raw objects of a generic Description.
+// The feeds are the ones of the Base, whose replicas only hold the value for the cluster of the Description.
+func (l *Localizer) ApplyReplicasToDescription(desc *appsapi.Description, feeds []appsapi.Feed) error {
+	if desc.Spec.Deployer != appsapi.DescriptionGenericDeployer {
+		return nil
+	}
+
+	var allErrs []error
+	for idx, rawObject := range desc.Spec.Raw {
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(rawObject, obj); err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+
+		for _, feed := range feeds {
+			if len(feed.Replicas) != 1 {
+				continue
+			}
+			if feed.Kind != obj.GetKind() || feed.APIVersion != obj.GetAPIVersion() ||
+				feed.Namespace != obj.GetNamespace() || feed.Name != obj.GetName() {
+				continue
+			}
+
+			result, err := applyReplicas(rawObject, feed.Replicas[0])
+			if err != nil {
+				allErrs = append(allErrs, fmt.Errorf("failed to apply replicas to %s: %v", utils.FormatFeed(feed), err))
+				break
+			}
+			desc.Spec.Raw[idx] = result
+			break
+		}
+	}
+	return utilerrors.NewAggregate(allErrs)
+}
+
 func (l *Localizer) getOverrides(namespace string, feed appsapi.Feed) ([]appsapi.OverrideConfig, error) {
 	var uid types.UID
 	switch feed.Kind {
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localizer
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+)
+
+func TestApplyReplicasToDescription(t *testing.T) {
+	deployFeed := appsapi.Feed{
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+		Namespace:  "default",
+		Name:       "nginx",
+	}
+	deployRaw := []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},"spec":{"replicas":5}}`)
+	serviceRaw := []byte(`{"apiVersion":"v1","kind":"Service","metadata":{"name":"nginx","namespace":"default"},"spec":{"type":"ClusterIP"}}`)
+
+	tests := []struct {
+		name     string
+		deployer appsapi.DescriptionDeployer
+		replicas []int32
+		want     [][]byte
+	}{
+		{
+			name:     "inject replicas of this cluster",
+			deployer: appsapi.DescriptionGenericDeployer,
+			replicas: []int32{2},
+			want: [][]byte{
+				[]byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},"spec":{"replicas":2}}`),
+				serviceRaw,
+			},
+		},
+		{
+			name:     "no replicas",
+			deployer: appsapi.DescriptionGenericDeployer,
+			want:     [][]byte{deployRaw, serviceRaw},
+		},
+		{
+			name:     "replicas not narrowed down to a single cluster",
+			deployer: appsapi.DescriptionGenericDeployer,
+			replicas: []int32{2, 3},
+			want:     [][]byte{deployRaw, serviceRaw},
+		},
+		{
+			name:     "helm deployer",
+			deployer: appsapi.DescriptionHelmDeployer,
+			replicas: []int32{2},
+			want:     [][]byte{deployRaw, serviceRaw},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed := deployFeed
+			feed.Replicas = tt.replicas
+			desc := &appsapi.Description{
+				Spec: appsapi.DescriptionSpec{
+					Deployer: tt.deployer,
+					Raw:      [][]byte{deployRaw, serviceRaw},
+				},
+			}
+
+			l := &Localizer{}
+			if err := l.ApplyReplicasToDescription(desc, []appsapi.Feed{feed}); err != nil {
+				t.Fatalf("ApplyReplicasToDescription() error = %v", err)
+			}
+			if len(desc.Spec.Raw) != len(tt.want) {
+				t.Fatalf("ApplyReplicasToDescription() got %d objects, want %d", len(desc.Spec.Raw), len(tt.want))
+			}
+			for i := range tt.want {
+				gotObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+				wantObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+				if err := yaml.Unmarshal(desc.Spec.Raw[i], &gotObj); err != nil {
+					t.Fatalf("error decoding: %v", err)
+				}
+				if err := yaml.Unmarshal(tt.want[i], &wantObj); err != nil {
+					t.Fatalf("error decoding: %v", err)
+				}
+				if !reflect.DeepEqual(gotObj, wantObj) {
+					t.Errorf("ApplyReplicasToDescription() got %s, want %s", gotObj, wantObj)
+				}
+			}
+		})
+	}
+}
@@ -102,3 +102,8 @@ func applyHelmOverride(currentByte, overrideByte []byte) ([]byte, error) {
 	}
 	return json.Marshal(chartutil.CoalesceTables(overrideValues, currentObj))
 }
+
+// applyReplicas sets the desired replicas of a workload.
+func applyReplicas(cur []byte, replicas int32) ([]byte, error) {
+	return jsonpatch.MergePatch(cur, []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)))
+}
@@ -268,3 +268,48 @@ hole: black
 		})
 	}
 }
+
+func TestApplyReplicas(t *testing.T) {
+	tests := []struct {
+		name     string
+		original []byte
+		replicas int32
+		want     []byte
+	}{
+		{
+			name:     "override replicas",
+			original: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo"},"spec":{"replicas":5,"paused":true}}`),
+			replicas: 2,
+			want:     []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo"},"spec":{"replicas":2,"paused":true}}`),
+		},
+		{
+			name:     "replicas unset",
+			original: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo"}}`),
+			replicas: 0,
+			want:     []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo"},"spec":{"replicas":0}}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyReplicas(tt.original, tt.replicas)
+			if err != nil {
+				t.Errorf("applyReplicas() error = %v", err)
+				return
+			}
+
+			gotObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			wantObj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if err := yaml.Unmarshal(got, &gotObj); err != nil {
+				t.Fatalf("error decoding: %v", err)
+			}
+			if err := yaml.Unmarshal(tt.want, &wantObj); err != nil {
+				t.Fatalf("error decoding: %v", err)
+			}
+
+			if !reflect.DeepEqual(gotObj, wantObj) {
+				t.Errorf("applyReplicas() got %s, want %s", gotObj, wantObj)
+			}
+		})
+	}
+}
@@ -23,4 +23,18 @@ const (
 
 	// FeedProtectionAnnotation passes detailed message on protecting current object as a feed
 	FeedProtectionAnnotation = "apps.clusternet.io/feed-protection"
+
+	// RolloutRevisionAnnotation records the revision of the feeds that a Description is populated from
+	RolloutRevisionAnnotation = "apps.clusternet.io/rollout-revision"
+
+	// RolloutPromotedWaveAnnotation is the index of the last wave that is promoted manually for the ongoing rollout
+	RolloutPromotedWaveAnnotation = "apps.clusternet.io/rollout-promoted-wave"
+
+	// DeletionPolicyAnnotation overrides the deletion policy of a resource in child clusters, either "Delete" or "Orphan"
+	DeletionPolicyAnnotation = "apps.clusternet.io/deletion-policy"
+
+	// DependsOnAnnotation lists the objects in the same Subscription that an object depends on, separated by commas.
+	// Each object is referred as "<Kind>/<namespace>/<name>", or "<Kind>/<name>" for the ones in the same namespace
+	// or cluster-scoped ones. Objects are applied after their dependencies, and deleted before them.
+	DependsOnAnnotation = "apps.clusternet.io/depends-on"
 )
@@ -46,6 +46,9 @@ const (
 	// This will be also used by deployer in clusternet-hub when flag "--anonymous-auth-supported" is set to false.
 	ClusternetHubProxyServiceAccount = "clusternet-hub-proxy"
 
+	// ClusternetFieldManager is the field manager used by deployers to server-side apply resources to child clusters
+	ClusternetFieldManager = "clusternet"
+
 	// nvidia gpu name
 	NVIDIAGPUResourceName = "nvidia.com/gpu"
 )
@@ -77,6 +80,9 @@ const (
 	ManagedFields     = "/metadata/managedFields"
 	MetaUID           = "/metadata/uid"
 	MetaSelflink      = "/metadata/selfLink"
+
+	MetaResourceVersion = "/metadata/resourceVersion"
+	Status              = "/status"
 )
 
 const (
@@ -21,6 +21,8 @@ package algorithm
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,8 +33,8 @@ import (
 
 	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
 	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
-	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
 	schedulercache "github.com/clusternet/clusternet/pkg/scheduler/cache"
+	extenderv1alpha1 "github.com/clusternet/clusternet/pkg/scheduler/extender/v1alpha1"
 	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
 	"github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
 	"github.com/clusternet/clusternet/pkg/scheduler/metrics"
@@ -56,15 +58,15 @@ const (
 var ErrNoClustersAvailable = fmt.Errorf("no clusters available to schedule subscriptions")
 
 type genericScheduler struct {
-	cache                       schedulercache.Cache
-	percentageOfClustersToScore int32
-	nextStartClusterIndex       int
+	cache                 schedulercache.Cache
+	extenders             []framework.Extender
+	nextStartClusterIndex int
 }
 
 // Schedule tries to schedule the given subscription to multiple clusters.
 // If it succeeds, it will return the namespaced names of ManagedClusters.
 // If it fails, it will return a FitError error with reasons.
-func (g *genericScheduler) Schedule(ctx context.Context, fwk framework.Framework, sub *appsapi.Subscription) (result ScheduleResult, err error) {
+func (g *genericScheduler) Schedule(ctx context.Context, fwk framework.Framework, state *framework.CycleState, sub *appsapi.Subscription) (result ScheduleResult, err error) {
 	trace := utiltrace.New("Scheduling", utiltrace.Field{Key: "namespace", Value: sub.Namespace}, utiltrace.Field{Key: "name", Value: sub.Name})
 	defer trace.LogIfLong(100 * time.Millisecond)
 
@@ -72,58 +74,251 @@ func (g *genericScheduler) Schedule(ctx context.Context, fwk framework.Framework
 		return result, ErrNoClustersAvailable
 	}
 
-	feasibleClusters, diagnosis, err := g.findClustersThatFitSubscription(ctx, fwk, sub)
+	feasibleClusters, diagnosis, err := g.findClustersThatFitSubscription(ctx, fwk, state, sub)
 	if err != nil {
 		return result, err
 	}
 	trace.Step("Computing predicates done")
 
-	if len(feasibleClusters) == 0 {
+	if numRequired := numRequiredClusters(sub); len(feasibleClusters) < numRequired {
 		return result, &framework.FitError{
-			Subscription:   sub,
-			NumAllClusters: g.cache.NumClusters(),
-			Diagnosis:      diagnosis,
+			Subscription:        sub,
+			NumAllClusters:      g.cache.NumClusters(),
+			NumFeasibleClusters: len(feasibleClusters),
+			NumRequiredClusters: numRequired,
+			Diagnosis:           diagnosis,
 		}
 	}
 
-	priorityList, err := prioritizeClusters(ctx, fwk, sub, feasibleClusters)
+	priorityList, pluginScores, err := prioritizeClusters(ctx, g.extenders, fwk, state, sub, feasibleClusters)
 	if err != nil {
 		return result, err
 	}
 
-	clusters, err := g.selectClusters(priorityList, sub)
+	clusters, err := g.selectClusters(priorityList, feasibleClusters, sub)
+	if err != nil {
+		return result, err
+	}
+	if numRequired := numRequiredClusters(sub); len(clusters) < numRequired {
+		// some feasible clusters are left out to keep the skew of topology spread constraints
+		selected := sets.NewString(clusters...)
+		for _, cluster := range feasibleClusters {
+			if key := klog.KObj(cluster).String(); !selected.Has(key) {
+				diagnosis.ClusterToStatusMap[key] = framework.NewStatus(framework.Unschedulable, ErrReasonTopologySpreadNotSatisfied)
+			}
+		}
+		return result, &framework.FitError{
+			Subscription:        sub,
+			NumAllClusters:      g.cache.NumClusters(),
+			NumFeasibleClusters: len(clusters),
+			NumRequiredClusters: numRequired,
+			Diagnosis:           diagnosis,
+		}
+	}
 	trace.Step("Prioritizing done")
 
+	targetClusters, err := assignReplicas(ctx, fwk, state, sub, feasibleClusters, clusters)
+	if err != nil {
+		return result, err
+	}
+	trace.Step("Assigning replicas done")
+
 	return ScheduleResult{
-		SuggestedClusters: clusters,
+		SuggestedClusters: targetClusters,
 		EvaluatedClusters: len(feasibleClusters) + len(diagnosis.ClusterToStatusMap),
 		FeasibleClusters:  len(feasibleClusters),
-	}, err
+		Diagnosis:         diagnosis,
+		PluginScores:      pluginScores,
+		ClusterScores:     priorityList,
+	}, nil
+}
+
+// assignReplicas divides the replicas of a Dividing subscription into the selected clusters
+// by running the assign plugins. For other subscriptions, the selected clusters are returned directly.
+func assignReplicas(ctx context.Context, fwk framework.Framework, state *framework.CycleState, sub *appsapi.Subscription,
+	feasibleClusters []*clusterapi.ManagedCluster, selected []string) (framework.TargetClusters, error) {
+	if sub.Spec.SchedulingStrategy != appsapi.DividingSchedulingStrategyType {
+		return framework.TargetClusters{BindingClusters: selected}, nil
+	}
+
+	clusterMap := make(map[string]*clusterapi.ManagedCluster, len(feasibleClusters))
+	for _, cluster := range feasibleClusters {
+		clusterMap[klog.KObj(cluster).String()] = cluster
+	}
+	clusters := make([]*clusterapi.ManagedCluster, 0, len(selected))
+	for _, namespacedName := range selected {
+		if cluster, ok := clusterMap[namespacedName]; ok {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	targetClusters, status := fwk.RunAssignPlugins(ctx, state, sub, clusters)
+	if status.Code() == framework.Skip {
+		return framework.TargetClusters{BindingClusters: selected}, nil
+	}
+	if status.IsSuccess() && hasHardConstraints(sub) {
+		bindingClusters := make([]*clusterapi.ManagedCluster, 0, len(targetClusters.BindingClusters))
+		for _, namespacedName := range targetClusters.BindingClusters {
+			if cluster, ok := clusterMap[namespacedName]; ok {
+				bindingClusters = append(bindingClusters, cluster)
+			}
+		}
+		if len(bindingClusters) == len(targetClusters.BindingClusters) {
+			for feedKey, replicas := range targetClusters.Replicas {
+				targetClusters.Replicas[feedKey] = spreadReplicas(sub.Spec.TopologySpreadConstraints, bindingClusters, replicas)
+			}
+		}
+	}
+	if status.IsUnschedulable() {
+		diagnosis := framework.Diagnosis{
+			ClusterToStatusMap:   make(framework.ClusterToStatusMap),
+			UnschedulablePlugins: sets.NewString(status.FailedPlugin()),
+		}
+		for _, namespacedName := range selected {
+			diagnosis.ClusterToStatusMap[namespacedName] = status
+		}
+		return targetClusters, &framework.FitError{
+			Subscription:   sub,
+			NumAllClusters: len(selected),
+			Diagnosis:      diagnosis,
+		}
+	}
+	if !status.IsSuccess() {
+		return targetClusters, status.AsError()
+	}
+	return targetClusters, nil
 }
 
-// selectClusters takes a prioritized list of clusters and then picks a fraction of clusters
-// in a reservoir sampling manner from the clusters that had the highest score.
-func (g *genericScheduler) selectClusters(clusterScoreList framework.ClusterScoreList, _ *appsapi.Subscription) ([]string, error) {
+// selectClusters takes a prioritized list of clusters and then picks the highest-scoring clusters,
+// at most as many as the subscription's MaxClusters. Clusters that tie on the lowest selected score
+// are picked in a reservoir sampling manner.
+// If the subscription has topology spread constraints, clusters are picked one by one in the order of
+// scores, and clusters that violate the constraints are skipped.
+// When some of the bound clusters are lost, the healthy bound clusters are picked first, so that only
+// the lost ones are replaced.
+func (g *genericScheduler) selectClusters(clusterScoreList framework.ClusterScoreList,
+	feasibleClusters []*clusterapi.ManagedCluster, sub *appsapi.Subscription) ([]string, error) {
 	if len(clusterScoreList) == 0 {
 		return nil, fmt.Errorf("empty clusterScoreList")
 	}
 
-	var selected []string
+	numClusters := len(clusterScoreList)
+	if sub.Spec.MaxClusters != nil && *sub.Spec.MaxClusters > 0 && int(*sub.Spec.MaxClusters) < numClusters {
+		numClusters = int(*sub.Spec.MaxClusters)
+	}
+
+	var healthyBindings, others framework.ClusterScoreList
+	if hasLostBindingClusters(clusterScoreList, sub) {
+		bound := sets.NewString(sub.Status.BindingClusters...)
+		for _, clusterScore := range clusterScoreList {
+			if bound.Has(clusterScore.NamespacedName) {
+				healthyBindings = append(healthyBindings, clusterScore)
+			} else {
+				others = append(others, clusterScore)
+			}
+		}
+	} else {
+		others = clusterScoreList
+	}
+
+	if len(sub.Spec.TopologySpreadConstraints) > 0 {
+		sorted := append(sortClusterScores(healthyBindings), sortClusterScores(others)...)
+		return selectClustersWithSpread(sorted, feasibleClusters, sub.Spec.TopologySpreadConstraints, numClusters), nil
+	}
+
+	selected := topClusters(healthyBindings, numClusters)
+	return append(selected, topClusters(others, numClusters-len(selected))...), nil
+}
+
+// hasLostBindingClusters returns whether any cluster that the subscription is bound to is no longer feasible.
+func hasLostBindingClusters(clusterScoreList framework.ClusterScoreList, sub *appsapi.Subscription) bool {
+	feasible := sets.NewString()
 	for _, clusterScore := range clusterScoreList {
-		// TODO: sampling with scores
-		selected = append(selected, clusterScore.NamespacedName)
+		feasible.Insert(clusterScore.NamespacedName)
+	}
+	for _, bound := range sub.Status.BindingClusters {
+		if !feasible.Has(bound) {
+			return true
+		}
 	}
-	return selected, nil
+	return false
+}
+
+// sortClusterScores returns a copy of the cluster scores sorted in descending order,
+// in which ties are broken randomly.
+func sortClusterScores(clusterScoreList framework.ClusterScoreList) framework.ClusterScoreList {
+	sorted := make(framework.ClusterScoreList, len(clusterScoreList))
+	copy(sorted, clusterScoreList)
+	rand.Shuffle(len(sorted), func(i, j int) {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	})
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+	return sorted
+}
+
+// topClusters picks the highest-scoring numClusters clusters.
+// Clusters that tie on the lowest picked score are picked in a reservoir sampling manner.
+func topClusters(clusterScoreList framework.ClusterScoreList, numClusters int) []string {
+	if numClusters <= 0 {
+		return nil
+	}
+	if numClusters >= len(clusterScoreList) {
+		selected := make([]string, 0, len(clusterScoreList))
+		for _, clusterScore := range clusterScoreList {
+			selected = append(selected, clusterScore.NamespacedName)
+		}
+		return selected
+	}
+
+	sorted := make(framework.ClusterScoreList, len(clusterScoreList))
+	copy(sorted, clusterScoreList)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+
+	// Clusters scoring higher than the boundary score are always selected,
+	// while the remaining slots are sampled from the clusters tied on it.
+	boundaryScore := sorted[numClusters-1].Score
+	selected := make([]string, 0, numClusters)
+	var ties []string
+	for _, clusterScore := range sorted {
+		switch {
+		case clusterScore.Score > boundaryScore:
+			selected = append(selected, clusterScore.NamespacedName)
+		case clusterScore.Score == boundaryScore:
+			ties = append(ties, clusterScore.NamespacedName)
+		}
+	}
+
+	numTiesToSelect := numClusters - len(selected)
+	reservoir := make([]string, numTiesToSelect)
+	copy(reservoir, ties[:numTiesToSelect])
+	for i := numTiesToSelect; i < len(ties); i++ {
+		if j := rand.Intn(i + 1); j < numTiesToSelect {
+			reservoir[j] = ties[i]
+		}
+	}
+	return append(selected, reservoir...)
+}
+
+// numRequiredClusters returns the minimum number of feasible clusters required by the subscription.
+func numRequiredClusters(sub *appsapi.Subscription) int {
+	if sub.Spec.MinClusters != nil && *sub.Spec.MinClusters > 1 {
+		return int(*sub.Spec.MinClusters)
+	}
+	return 1
 }
 
 // numFeasibleClustersToFind returns the number of feasible clusters that once found, the scheduler stops
-// its search for more feasible clusters.
-func (g *genericScheduler) numFeasibleClustersToFind(numAllClusters int32, schedulingStrategy appsapi.SchedulingStrategyType) (numClusters int32) {
-	if numAllClusters < minFeasibleClustersToFind || g.percentageOfClustersToScore >= 100 || schedulingStrategy == appsapi.ReplicaSchedulingStrategyType {
+// its search for more feasible clusters. The percentageOfClustersToScore comes from the scheduling profile.
+func (g *genericScheduler) numFeasibleClustersToFind(percentageOfClustersToScore int32, numAllClusters int32, schedulingStrategy appsapi.SchedulingStrategyType) (numClusters int32) {
+	if numAllClusters < minFeasibleClustersToFind || percentageOfClustersToScore >= 100 || schedulingStrategy == appsapi.ReplicaSchedulingStrategyType {
 		return numAllClusters
 	}
 
-	adaptivePercentage := g.percentageOfClustersToScore
+	adaptivePercentage := percentageOfClustersToScore
 	if adaptivePercentage <= 0 {
 		basePercentageOfClustersToScore := int32(50)
 		adaptivePercentage = basePercentageOfClustersToScore - numAllClusters/125
@@ -141,7 +336,7 @@ func (g *genericScheduler) numFeasibleClustersToFind(numAllClusters int32, sched
 }
 
 // Filters the clusters to find the ones that fit the subscription based on the framework filter plugins.
-func (g *genericScheduler) findClustersThatFitSubscription(ctx context.Context, fwk framework.Framework, sub *appsapi.Subscription) ([]*clusterapi.ManagedCluster, framework.Diagnosis, error) {
+func (g *genericScheduler) findClustersThatFitSubscription(ctx context.Context, fwk framework.Framework, state *framework.CycleState, sub *appsapi.Subscription) ([]*clusterapi.ManagedCluster, framework.Diagnosis, error) {
 	diagnosis := framework.Diagnosis{
 		ClusterToStatusMap:   make(framework.ClusterToStatusMap),
 		UnschedulablePlugins: sets.NewString(),
@@ -162,7 +357,7 @@ func (g *genericScheduler) findClustersThatFitSubscription(ctx context.Context,
 	}
 
 	// Run "prefilter" plugins.
-	s := fwk.RunPreFilterPlugins(ctx, sub)
+	s := fwk.RunPreFilterPlugins(ctx, state, sub)
 	if !s.IsSuccess() {
 		if !s.IsUnschedulable() {
 			return nil, diagnosis, s.AsError()
@@ -177,18 +372,82 @@ func (g *genericScheduler) findClustersThatFitSubscription(ctx context.Context,
 		return nil, diagnosis, nil
 	}
 
-	feasibleClusters, err := g.findClustersThatPassFilters(ctx, fwk, sub, diagnosis, allClusters)
+	feasibleClusters, err := g.findClustersThatPassFilters(ctx, fwk, state, sub, diagnosis, allClusters)
+	if err != nil {
+		return nil, diagnosis, err
+	}
+
+	feasibleClusters, err = findClustersThatPassExtenders(g.extenders, sub, feasibleClusters, diagnosis.ClusterToStatusMap)
 	if err != nil {
 		return nil, diagnosis, err
 	}
 	return feasibleClusters, diagnosis, nil
 }
 
+// findClustersThatPassExtenders filters the feasible clusters further by calling the extenders in order.
+func findClustersThatPassExtenders(extenders []framework.Extender, sub *appsapi.Subscription,
+	feasibleClusters []*clusterapi.ManagedCluster, statuses framework.ClusterToStatusMap) ([]*clusterapi.ManagedCluster, error) {
+	// Extenders are called sequentially.
+	// Clusters in original feasibleClusters can be excluded in one extender, and pass on to the next
+	// extender in a decreasing manner.
+	for _, extender := range extenders {
+		if len(feasibleClusters) == 0 {
+			break
+		}
+
+		// Status of failed clusters in failedAndUnresolvableMap will be added or overwritten in <statuses>,
+		// so that the scheduler framework can respect the UnschedulableAndUnresolvable status for
+		// particular clusters, and this may eventually improve preemption efficiency.
+		// Note: users are recommended to configure the extenders that may return UnschedulableAndUnresolvable
+		// status ahead of others.
+		feasibleList, failedMap, failedAndUnresolvableMap, err := extender.Filter(sub, feasibleClusters)
+		if err != nil {
+			if extender.IsIgnorable() {
+				klog.InfoS("Skipping extender as it returned error and has ignorable flag set", "extender", extender.Name(), "err", err)
+				continue
+			}
+			return nil, err
+		}
+
+		for failedClusterName, failedMsg := range failedAndUnresolvableMap {
+			var aggregatedReasons []string
+			if _, found := statuses[failedClusterName]; found {
+				aggregatedReasons = statuses[failedClusterName].Reasons()
+			}
+			aggregatedReasons = append(aggregatedReasons, failedMsg)
+			statuses[failedClusterName] = framework.NewStatus(framework.UnschedulableAndUnresolvable, aggregatedReasons...)
+		}
+
+		for failedClusterName, failedMsg := range failedMap {
+			if _, found := failedAndUnresolvableMap[failedClusterName]; found {
+				// failedAndUnresolvableMap takes precedence over failedMap
+				// note that this only happens if the extender returns the cluster in both maps
+				continue
+			}
+			if _, found := statuses[failedClusterName]; !found {
+				statuses[failedClusterName] = framework.NewStatus(framework.Unschedulable, failedMsg)
+			} else {
+				statuses[failedClusterName].AppendReason(failedMsg)
+			}
+		}
+
+		feasibleClusters = feasibleList
+	}
+	return feasibleClusters, nil
+}
+
 // findClustersThatPassFilters finds the clusters that fit the filter plugins.
 func (g *genericScheduler) findClustersThatPassFilters(ctx context.Context, fwk framework.Framework,
-	sub *appsapi.Subscription, diagnosis framework.Diagnosis,
+	state *framework.CycleState, sub *appsapi.Subscription, diagnosis framework.Diagnosis,
 	clusters []*clusterapi.ManagedCluster) ([]*clusterapi.ManagedCluster, error) {
-	numClustersToFind := g.numFeasibleClustersToFind(int32(len(clusters)), sub.Spec.SchedulingStrategy)
+	numClustersToFind := g.numFeasibleClustersToFind(fwk.PercentageOfClustersToScore(), int32(len(clusters)), sub.Spec.SchedulingStrategy)
+	// Keep searching until enough clusters are found to satisfy the subscription's MinClusters.
+	if numRequired := int32(numRequiredClusters(sub)); numClustersToFind < numRequired {
+		numClustersToFind = numRequired
+		if numClustersToFind > int32(len(clusters)) {
+			numClustersToFind = int32(len(clusters))
+		}
+	}
 
 	// Create feasible list with enough space to avoid growing it
 	// and allow assigning.
@@ -212,7 +471,7 @@ func (g *genericScheduler) findClustersThatPassFilters(ctx context.Context, fwk
 		// this is to make sure all clusters have the same chance of being examined across subscriptions.
 		cluster := clusters[(g.nextStartClusterIndex+i)%len(clusters)]
 
-		status := fwk.RunFilterPlugins(ctx, sub, cluster).Merge()
+		status := fwk.RunFilterPlugins(ctx, state, sub, cluster).Merge()
 		if status.Code() == framework.Error {
 			errCh.SendErrorWithCancel(status.AsError(), cancel)
 			return
@@ -259,11 +518,13 @@ func (g *genericScheduler) findClustersThatPassFilters(ctx context.Context, fwk
 // which return a score for each cluster from the call to RunScorePlugins().
 // The scores from each plugin are added together to make the score for that cluster, then
 // any extenders are run as well.
-// All scores are finally combined (added) to get the total weighted scores of all clusters
-func prioritizeClusters(ctx context.Context, fwk framework.Framework, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (framework.ClusterScoreList, error) {
+// All scores are finally combined (added) to get the total weighted scores of all clusters.
+// The weighted scores given by each score plugin are returned as well.
+func prioritizeClusters(ctx context.Context, extenders []framework.Extender, fwk framework.Framework,
+	state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (framework.ClusterScoreList, framework.PluginToClusterScores, error) {
 	// If no priority configs are provided, then all clusters will have a score of one.
 	// This is required to generate the priority list in the required format
-	if !fwk.HasScorePlugins() {
+	if len(extenders) == 0 && !fwk.HasScorePlugins() {
 		result := make(framework.ClusterScoreList, 0, len(clusters))
 		for i := range clusters {
 			result = append(result, framework.ClusterScore{
@@ -271,19 +532,19 @@ func prioritizeClusters(ctx context.Context, fwk framework.Framework, sub *appsa
 				Score:          1,
 			})
 		}
-		return result, nil
+		return result, nil, nil
 	}
 
 	// Run PreScore plugins.
-	preScoreStatus := fwk.RunPreScorePlugins(ctx, sub, clusters)
+	preScoreStatus := fwk.RunPreScorePlugins(ctx, state, sub, clusters)
 	if !preScoreStatus.IsSuccess() {
-		return nil, preScoreStatus.AsError()
+		return nil, nil, preScoreStatus.AsError()
 	}
 
 	// Run the Score plugins.
-	scoresMap, scoreStatus := fwk.RunScorePlugins(ctx, sub, clusters)
+	scoresMap, scoreStatus := fwk.RunScorePlugins(ctx, state, sub, clusters)
 	if !scoreStatus.IsSuccess() {
-		return nil, scoreStatus.AsError()
+		return nil, nil, scoreStatus.AsError()
 	}
 
 	if klog.V(10).Enabled() {
@@ -304,19 +565,57 @@ func prioritizeClusters(ctx context.Context, fwk framework.Framework, sub *appsa
 		}
 	}
 
+	if len(extenders) != 0 && clusters != nil {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		combinedScores := make(map[string]int64, len(clusters))
+		for i := range extenders {
+			wg.Add(1)
+			go func(extIndex int) {
+				metrics.SchedulerGoroutines.WithLabelValues(metrics.PrioritizingExtender).Inc()
+				defer func() {
+					metrics.SchedulerGoroutines.WithLabelValues(metrics.PrioritizingExtender).Dec()
+					wg.Done()
+				}()
+				prioritizedList, weight, err := extenders[extIndex].Prioritize(sub, clusters)
+				if err != nil {
+					// Prioritization errors from extender can be ignored, let clusternet/other extenders determine the priorities
+					klog.V(5).InfoS("Failed to run extender's priority function. No score given by this extender.", "error", err, "subscription", klog.KObj(sub), "extender", extenders[extIndex].Name())
+					return
+				}
+				mu.Lock()
+				for i := range *prioritizedList {
+					cluster, score := (*prioritizedList)[i].Cluster, (*prioritizedList)[i].Score
+					if klog.V(10).Enabled() {
+						klog.InfoS("Extender scored cluster for subscription", "subscription", klog.KObj(sub), "extender", extenders[extIndex].Name(), "cluster", cluster, "score", score)
+					}
+					combinedScores[cluster] += score * weight
+				}
+				mu.Unlock()
+			}(i)
+		}
+		// wait for all go routines to finish
+		wg.Wait()
+		for i := range result {
+			// MaxExtenderPriority may diverge from the max priority used in the scheduler and defined by MaxClusterScore,
+			// therefore we need to scale the score returned by extenders to the score range used by the scheduler.
+			result[i].Score += combinedScores[result[i].NamespacedName] * (framework.MaxClusterScore / extenderv1alpha1.MaxExtenderPriority)
+		}
+	}
+
 	if klog.V(10).Enabled() {
 		for i := range result {
 			klog.InfoS("Calculated cluster's final score for subscription", "subscription", klog.KObj(sub), "cluster", result[i].NamespacedName, "score", result[i].Score)
 		}
 	}
-	return result, nil
+	return result, scoresMap, nil
 }
 
 // NewGenericScheduler creates a genericScheduler object.
-func NewGenericScheduler(cache schedulercache.Cache) ScheduleAlgorithm {
+func NewGenericScheduler(cache schedulercache.Cache, extenders []framework.Extender) ScheduleAlgorithm {
 	return &genericScheduler{
-		cache:                       cache,
-		percentageOfClustersToScore: schedulerapis.DefaultPercentageOfClustersToScore,
+		cache:     cache,
+		extenders: extenders,
 	}
 }
 
@@ -0,0 +1,389 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	utilpointer "k8s.io/utils/pointer"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	schedulercache "github.com/clusternet/clusternet/pkg/scheduler/cache"
+	extenderv1alpha1 "github.com/clusternet/clusternet/pkg/scheduler/extender/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+// fakeExtender rejects the clusters in failedClusters, and scores each cluster with the given scores.
+type fakeExtender struct {
+	name           string
+	failedClusters extenderv1alpha1.FailedClustersMap
+	unresolvable   bool
+	scores         map[string]int64
+	weight         int64
+	err            error
+	ignorable      bool
+}
+
+func (f *fakeExtender) Name() string {
+	return f.name
+}
+
+func (f *fakeExtender) Filter(_ *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) ([]*clusterapi.ManagedCluster,
+	extenderv1alpha1.FailedClustersMap, extenderv1alpha1.FailedClustersMap, error) {
+	if f.err != nil {
+		return nil, nil, nil, f.err
+	}
+
+	var filtered []*clusterapi.ManagedCluster
+	for _, cluster := range clusters {
+		if _, ok := f.failedClusters[klog.KObj(cluster).String()]; !ok {
+			filtered = append(filtered, cluster)
+		}
+	}
+	if f.unresolvable {
+		return filtered, nil, f.failedClusters, nil
+	}
+	return filtered, f.failedClusters, nil, nil
+}
+
+func (f *fakeExtender) Prioritize(_ *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (*extenderv1alpha1.ClusterPriorityList, int64, error) {
+	if f.err != nil {
+		return nil, 0, f.err
+	}
+
+	result := extenderv1alpha1.ClusterPriorityList{}
+	for _, cluster := range clusters {
+		key := klog.KObj(cluster).String()
+		result = append(result, extenderv1alpha1.ClusterPriority{Cluster: key, Score: f.scores[key]})
+	}
+	return &result, f.weight, nil
+}
+
+func (f *fakeExtender) Bind(_ *extenderv1alpha1.ExtenderBindingArgs) error {
+	return nil
+}
+
+func (f *fakeExtender) IsBinder() bool {
+	return false
+}
+
+func (f *fakeExtender) IsIgnorable() bool {
+	return f.ignorable
+}
+
+func makeCluster(name string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns-" + name,
+		},
+	}
+}
+
+func TestFindClustersThatPassExtenders(t *testing.T) {
+	clusters := []*clusterapi.ManagedCluster{makeCluster("c1"), makeCluster("c2"), makeCluster("c3")}
+
+	tests := []struct {
+		name             string
+		extenders        []framework.Extender
+		expectedClusters []*clusterapi.ManagedCluster
+		expectedStatuses framework.ClusterToStatusMap
+		expectErr        bool
+	}{
+		{
+			name:             "no extenders",
+			expectedClusters: clusters,
+			expectedStatuses: framework.ClusterToStatusMap{},
+		},
+		{
+			name: "clusters are filtered by the extenders in order",
+			extenders: []framework.Extender{
+				&fakeExtender{
+					name:           "compliance",
+					failedClusters: extenderv1alpha1.FailedClustersMap{"ns-c1/c1": "not in compliance zone"},
+				},
+				&fakeExtender{
+					name:           "quota",
+					failedClusters: extenderv1alpha1.FailedClustersMap{"ns-c2/c2": "out of quota"},
+					unresolvable:   true,
+				},
+			},
+			expectedClusters: []*clusterapi.ManagedCluster{clusters[2]},
+			expectedStatuses: framework.ClusterToStatusMap{
+				"ns-c1/c1": framework.NewStatus(framework.Unschedulable, "not in compliance zone"),
+				"ns-c2/c2": framework.NewStatus(framework.UnschedulableAndUnresolvable, "out of quota"),
+			},
+		},
+		{
+			name: "errors from ignorable extenders are skipped",
+			extenders: []framework.Extender{
+				&fakeExtender{name: "broken", err: fmt.Errorf("unreachable"), ignorable: true},
+			},
+			expectedClusters: clusters,
+			expectedStatuses: framework.ClusterToStatusMap{},
+		},
+		{
+			name: "errors from non-ignorable extenders fail the scheduling",
+			extenders: []framework.Extender{
+				&fakeExtender{name: "broken", err: fmt.Errorf("unreachable")},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := make(framework.ClusterToStatusMap)
+			got, err := findClustersThatPassExtenders(tt.extenders, &appsapi.Subscription{}, clusters, statuses)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("findClustersThatPassExtenders() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.expectedClusters) {
+				t.Errorf("expected clusters %v, got %v", tt.expectedClusters, got)
+			}
+			if !reflect.DeepEqual(statuses, tt.expectedStatuses) {
+				t.Errorf("expected statuses %v, got %v", tt.expectedStatuses, statuses)
+			}
+		})
+	}
+}
+
+func TestPrioritizeClustersWithExtenders(t *testing.T) {
+	clusters := []*clusterapi.ManagedCluster{makeCluster("c1"), makeCluster("c2")}
+
+	fwk, err := runtime.NewFramework(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		extenders    []framework.Extender
+		expectedList framework.ClusterScoreList
+	}{
+		{
+			name: "no extenders and no score plugins",
+			expectedList: framework.ClusterScoreList{
+				{NamespacedName: "ns-c1/c1", Score: 1},
+				{NamespacedName: "ns-c2/c2", Score: 1},
+			},
+		},
+		{
+			name: "extender scores are weighted and scaled",
+			extenders: []framework.Extender{
+				&fakeExtender{name: "cost", scores: map[string]int64{"ns-c1/c1": 1, "ns-c2/c2": 5}, weight: 2},
+				&fakeExtender{name: "zone", scores: map[string]int64{"ns-c1/c1": 10}, weight: 1},
+				&fakeExtender{name: "broken", err: fmt.Errorf("unreachable"), weight: 5},
+			},
+			expectedList: framework.ClusterScoreList{
+				{NamespacedName: "ns-c1/c1", Score: (1*2 + 10*1) * 10},
+				{NamespacedName: "ns-c2/c2", Score: (5 * 2) * 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := prioritizeClusters(context.Background(), tt.extenders, fwk, framework.NewCycleState(), &appsapi.Subscription{}, clusters)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expectedList) {
+				t.Errorf("expected %v, got %v", tt.expectedList, got)
+			}
+		})
+	}
+}
+
+func TestSelectClusters(t *testing.T) {
+	clusterScoreList := framework.ClusterScoreList{
+		{NamespacedName: "ns-c1/c1", Score: 10},
+		{NamespacedName: "ns-c2/c2", Score: 30},
+		{NamespacedName: "ns-c3/c3", Score: 20},
+		{NamespacedName: "ns-c4/c4", Score: 40},
+	}
+
+	tests := []struct {
+		name            string
+		maxClusters     *int32
+		bindingClusters []string
+		expected        []string
+	}{
+		{
+			name:     "all clusters are selected without maxClusters",
+			expected: []string{"ns-c1/c1", "ns-c2/c2", "ns-c3/c3", "ns-c4/c4"},
+		},
+		{
+			name:        "maxClusters larger than the number of clusters",
+			maxClusters: utilpointer.Int32(10),
+			expected:    []string{"ns-c1/c1", "ns-c2/c2", "ns-c3/c3", "ns-c4/c4"},
+		},
+		{
+			name:        "top 2 clusters are selected",
+			maxClusters: utilpointer.Int32(2),
+			expected:    []string{"ns-c4/c4", "ns-c2/c2"},
+		},
+		{
+			name:        "top 1 cluster is selected",
+			maxClusters: utilpointer.Int32(1),
+			expected:    []string{"ns-c4/c4"},
+		},
+		{
+			name:            "bound clusters are reselected by scores if none of them is lost",
+			maxClusters:     utilpointer.Int32(2),
+			bindingClusters: []string{"ns-c1/c1", "ns-c3/c3"},
+			expected:        []string{"ns-c4/c4", "ns-c2/c2"},
+		},
+		{
+			name:            "healthy bound clusters are kept and the lost one is replaced",
+			maxClusters:     utilpointer.Int32(2),
+			bindingClusters: []string{"ns-c1/c1", "ns-c5/c5"},
+			expected:        []string{"ns-c1/c1", "ns-c4/c4"},
+		},
+	}
+
+	g := &genericScheduler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &appsapi.Subscription{
+				Spec:   appsapi.SubscriptionSpec{MaxClusters: tt.maxClusters},
+				Status: appsapi.SubscriptionStatus{BindingClusters: tt.bindingClusters},
+			}
+			got, err := g.selectClusters(clusterScoreList, nil, sub)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSelectClustersBreaksTies(t *testing.T) {
+	clusterScoreList := framework.ClusterScoreList{
+		{NamespacedName: "ns-c1/c1", Score: 10},
+		{NamespacedName: "ns-c2/c2", Score: 50},
+		{NamespacedName: "ns-c3/c3", Score: 20},
+		{NamespacedName: "ns-c4/c4", Score: 20},
+		{NamespacedName: "ns-c5/c5", Score: 20},
+	}
+	sub := &appsapi.Subscription{Spec: appsapi.SubscriptionSpec{MaxClusters: utilpointer.Int32(2)}}
+
+	g := &genericScheduler{}
+	picked := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		got, err := g.selectClusters(clusterScoreList, nil, sub)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 2 || got[0] != "ns-c2/c2" {
+			t.Fatalf("expected the highest-scoring cluster and one tied cluster, got %v", got)
+		}
+		picked[got[1]]++
+	}
+
+	if _, ok := picked["ns-c1/c1"]; ok {
+		t.Errorf("the lowest-scoring cluster should never be selected")
+	}
+	for _, tied := range []string{"ns-c3/c3", "ns-c4/c4", "ns-c5/c5"} {
+		if picked[tied] == 0 {
+			t.Errorf("tied cluster %s was never selected in %v", tied, picked)
+		}
+	}
+}
+
+func TestScheduleMinClusters(t *testing.T) {
+	informerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+	for _, name := range []string{"c1", "c2"} {
+		if err := informerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(makeCluster(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fwk, err := runtime.NewFramework(nil, nil, runtime.WithInformerFactory(informerFactory))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scheduleAlgorithm := NewGenericScheduler(schedulercache.New(informerFactory.Clusters().V1beta1().ManagedClusters().Lister()), nil)
+
+	tests := []struct {
+		name        string
+		minClusters *int32
+		expected    []string
+		expectedErr string
+	}{
+		{
+			name:     "enough feasible clusters",
+			expected: []string{"ns-c1/c1", "ns-c2/c2"},
+		},
+		{
+			name:        "minClusters is satisfied",
+			minClusters: utilpointer.Int32(2),
+			expected:    []string{"ns-c1/c1", "ns-c2/c2"},
+		},
+		{
+			name:        "fewer feasible clusters than minClusters",
+			minClusters: utilpointer.Int32(3),
+			expectedErr: "2/2 clusters are available, while at least 3 clusters are required: .",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &appsapi.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+				Spec: appsapi.SubscriptionSpec{
+					Subscribers: []appsapi.Subscriber{{ClusterAffinity: &metav1.LabelSelector{}}},
+					MinClusters: tt.minClusters,
+				},
+			}
+			result, err := scheduleAlgorithm.Schedule(context.Background(), fwk, framework.NewCycleState(), sub)
+			if len(tt.expectedErr) > 0 {
+				var fitErr *framework.FitError
+				if !errors.As(err, &fitErr) {
+					t.Fatalf("expected a FitError, got %v", err)
+				}
+				if err.Error() != tt.expectedErr {
+					t.Errorf("expected error %q, got %q", tt.expectedErr, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := result.SuggestedClusters.BindingClusters
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -26,16 +26,22 @@ import (
 // ScheduleAlgorithm is an interface implemented by things that know how to schedule resources to target
 // managed clusters.
 type ScheduleAlgorithm interface {
-	Schedule(context.Context, framework.Framework, *appsapi.Subscription) (scheduleResult ScheduleResult, err error)
+	Schedule(context.Context, framework.Framework, *framework.CycleState, *appsapi.Subscription) (scheduleResult ScheduleResult, err error)
 }
 
 // ScheduleResult represents the result of one subscription scheduled. It will contain
 // the final selected clusters, along with the selected intermediate information.
 type ScheduleResult struct {
-	// the scheduler suggest clusters (namespaced name)
-	SuggestedClusters []string
+	// the scheduler suggest clusters (namespaced name), along with the divided replicas if any
+	SuggestedClusters framework.TargetClusters
 	// Number of clusters scheduler evaluated on one subscription scheduled
 	EvaluatedClusters int
 	// Number of feasible clusters on one subscription scheduled
 	FeasibleClusters int
+	// Statuses of the clusters that are filtered out
+	Diagnosis framework.Diagnosis
+	// Scores of feasible clusters given by each score plugin
+	PluginScores framework.PluginToClusterScores
+	// Final scores of feasible clusters, including the scores given by extenders
+	ClusterScores framework.ClusterScoreList
 }
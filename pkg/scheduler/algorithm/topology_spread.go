@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+// ErrReasonTopologySpreadNotSatisfied is used when a feasible cluster is left out to keep the skew of
+// topology spread constraints.
+const ErrReasonTopologySpreadNotSatisfied = "cluster(s) didn't satisfy topology spread constraints"
+
+// isHardConstraint returns whether the constraint is honored as DoNotSchedule.
+func isHardConstraint(constraint appsapi.ClusterTopologySpreadConstraint) bool {
+	return constraint.WhenUnsatisfiable != corev1.ScheduleAnyway
+}
+
+// hasHardConstraints returns whether the subscription has any DoNotSchedule topology spread constraint.
+func hasHardConstraints(sub *appsapi.Subscription) bool {
+	for _, constraint := range sub.Spec.TopologySpreadConstraints {
+		if isHardConstraint(constraint) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectClustersWithSpread picks at most numClusters clusters from a sorted list of cluster scores,
+// in which a cluster is picked only if the skew of every DoNotSchedule constraint is kept within its MaxSkew.
+// ScheduleAnyway constraints are honored as well at first, and are ignored when no more clusters could be
+// picked with them.
+func selectClustersWithSpread(sorted framework.ClusterScoreList, feasibleClusters []*clusterapi.ManagedCluster,
+	constraints []appsapi.ClusterTopologySpreadConstraint, numClusters int) []string {
+	clusterMap := make(map[string]*clusterapi.ManagedCluster, len(feasibleClusters))
+	for _, cluster := range feasibleClusters {
+		clusterMap[klog.KObj(cluster).String()] = cluster
+	}
+
+	// selectedPerDomain is indexed by constraint, mapping a topology value to the number of selected clusters.
+	// Every topology domain of the feasible clusters is counted, even if none of its clusters is selected.
+	selectedPerDomain := make([]map[string]int32, len(constraints))
+	for i, constraint := range constraints {
+		selectedPerDomain[i] = make(map[string]int32)
+		for _, clusterScore := range sorted {
+			cluster, ok := clusterMap[clusterScore.NamespacedName]
+			if !ok {
+				continue
+			}
+			if value, ok := cluster.Labels[constraint.TopologyKey]; ok {
+				selectedPerDomain[i][value] = 0
+			}
+		}
+	}
+
+	fits := func(cluster *clusterapi.ManagedCluster, ignoreSoftConstraints bool) bool {
+		for i, constraint := range constraints {
+			if ignoreSoftConstraints && !isHardConstraint(constraint) {
+				continue
+			}
+			value, ok := cluster.Labels[constraint.TopologyKey]
+			if !ok {
+				if isHardConstraint(constraint) {
+					return false
+				}
+				continue
+			}
+			if selectedPerDomain[i][value]+1-minCount(selectedPerDomain[i]) > constraint.MaxSkew {
+				return false
+			}
+		}
+		return true
+	}
+
+	picked := make([]bool, len(sorted))
+	selected := make([]string, 0, numClusters)
+	for _, ignoreSoftConstraints := range []bool{false, true} {
+		for len(selected) < numClusters {
+			found := false
+			for idx, clusterScore := range sorted {
+				cluster, ok := clusterMap[clusterScore.NamespacedName]
+				if picked[idx] || !ok || !fits(cluster, ignoreSoftConstraints) {
+					continue
+				}
+
+				picked[idx] = true
+				selected = append(selected, clusterScore.NamespacedName)
+				for i, constraint := range constraints {
+					if value, ok := cluster.Labels[constraint.TopologyKey]; ok {
+						selectedPerDomain[i][value]++
+					}
+				}
+				found = true
+				break
+			}
+			if !found {
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// spreadReplicas moves replicas between clusters until the skew of replicas among the topology domains is
+// kept within the MaxSkew of every DoNotSchedule constraint. Replicas are moved from the cluster with the most
+// replicas in the largest domain to the cluster with the fewest replicas in the smallest domain, and the
+// total number of replicas is kept.
+// When constraints conflict with each other, the latter ones take precedence.
+// The clusters are corresponding with the replicas by indices.
+func spreadReplicas(constraints []appsapi.ClusterTopologySpreadConstraint, clusters []*clusterapi.ManagedCluster, replicas []int32) []int32 {
+	result := make([]int32, len(replicas))
+	copy(result, replicas)
+
+	for _, constraint := range constraints {
+		if !isHardConstraint(constraint) {
+			continue
+		}
+
+		for {
+			totals := make(map[string]int32)
+			members := make(map[string][]int)
+			for idx, cluster := range clusters {
+				value, ok := cluster.Labels[constraint.TopologyKey]
+				if !ok || idx >= len(result) {
+					continue
+				}
+				totals[value] += result[idx]
+				members[value] = append(members[value], idx)
+			}
+			if len(totals) < 2 {
+				break
+			}
+
+			domains := make([]string, 0, len(totals))
+			for value := range totals {
+				domains = append(domains, value)
+			}
+			sort.Strings(domains)
+			largest, smallest := domains[0], domains[0]
+			for _, value := range domains {
+				if totals[value] > totals[largest] {
+					largest = value
+				}
+				if totals[value] < totals[smallest] {
+					smallest = value
+				}
+			}
+			if totals[largest]-totals[smallest] <= constraint.MaxSkew {
+				break
+			}
+
+			from, to := members[largest][0], members[smallest][0]
+			for _, idx := range members[largest] {
+				if result[idx] > result[from] {
+					from = idx
+				}
+			}
+			for _, idx := range members[smallest] {
+				if result[idx] < result[to] {
+					to = idx
+				}
+			}
+			result[from]--
+			result[to]++
+		}
+	}
+	return result
+}
+
+// minCount returns the minimum number in the map, or zero for an empty map.
+func minCount(counts map[string]int32) int32 {
+	var result int32
+	first := true
+	for _, count := range counts {
+		if first || count < result {
+			result = count
+			first = false
+		}
+	}
+	return result
+}
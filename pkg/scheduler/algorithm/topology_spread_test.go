@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+func makeClusterInRegion(name, region string) *clusterapi.ManagedCluster {
+	cluster := makeCluster(name)
+	if len(region) > 0 {
+		cluster.Labels = map[string]string{"region": region}
+	}
+	return cluster
+}
+
+func TestSelectClustersWithSpread(t *testing.T) {
+	clusters := []*clusterapi.ManagedCluster{
+		makeClusterInRegion("c1", "r1"),
+		makeClusterInRegion("c2", "r1"),
+		makeClusterInRegion("c3", "r1"),
+		makeClusterInRegion("c4", "r2"),
+		makeClusterInRegion("c5", "r3"),
+		makeClusterInRegion("c6", ""),
+	}
+	sorted := framework.ClusterScoreList{
+		{NamespacedName: "ns-c6/c6", Score: 100},
+		{NamespacedName: "ns-c1/c1", Score: 90},
+		{NamespacedName: "ns-c2/c2", Score: 80},
+		{NamespacedName: "ns-c3/c3", Score: 70},
+		{NamespacedName: "ns-c4/c4", Score: 60},
+		{NamespacedName: "ns-c5/c5", Score: 50},
+	}
+
+	tests := []struct {
+		name        string
+		constraint  appsapi.ClusterTopologySpreadConstraint
+		numClusters int
+		expected    []string
+	}{
+		{
+			name:        "top clusters are spread across regions",
+			constraint:  appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.DoNotSchedule},
+			numClusters: 3,
+			expected:    []string{"ns-c1/c1", "ns-c4/c4", "ns-c5/c5"},
+		},
+		{
+			name:        "clusters violating the max skew are left out",
+			constraint:  appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.DoNotSchedule},
+			numClusters: 6,
+			expected:    []string{"ns-c1/c1", "ns-c4/c4", "ns-c5/c5", "ns-c2/c2"},
+		},
+		{
+			name:        "a larger max skew",
+			constraint:  appsapi.ClusterTopologySpreadConstraint{MaxSkew: 2, TopologyKey: "region", WhenUnsatisfiable: corev1.DoNotSchedule},
+			numClusters: 3,
+			expected:    []string{"ns-c1/c1", "ns-c2/c2", "ns-c4/c4"},
+		},
+		{
+			name:        "soft constraints are ignored when no more clusters could be picked",
+			constraint:  appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.ScheduleAnyway},
+			numClusters: 6,
+			expected:    []string{"ns-c6/c6", "ns-c1/c1", "ns-c4/c4", "ns-c5/c5", "ns-c2/c2", "ns-c3/c3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := selectClustersWithSpread(sorted, clusters, []appsapi.ClusterTopologySpreadConstraint{tt.constraint}, tt.numClusters)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSpreadReplicas(t *testing.T) {
+	clusters := []*clusterapi.ManagedCluster{
+		makeClusterInRegion("c1", "r1"),
+		makeClusterInRegion("c2", "r1"),
+		makeClusterInRegion("c3", "r2"),
+	}
+
+	tests := []struct {
+		name       string
+		constraint appsapi.ClusterTopologySpreadConstraint
+		replicas   []int32
+		expected   []int32
+	}{
+		{
+			name:       "replicas are moved to the smaller region",
+			constraint: appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.DoNotSchedule},
+			replicas:   []int32{4, 4, 1},
+			expected:   []int32{2, 3, 4},
+		},
+		{
+			name:       "replicas within the max skew are kept",
+			constraint: appsapi.ClusterTopologySpreadConstraint{MaxSkew: 3, TopologyKey: "region", WhenUnsatisfiable: corev1.DoNotSchedule},
+			replicas:   []int32{2, 2, 1},
+			expected:   []int32{2, 2, 1},
+		},
+		{
+			name:       "soft constraints don't move replicas",
+			constraint: appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.ScheduleAnyway},
+			replicas:   []int32{4, 4, 1},
+			expected:   []int32{4, 4, 1},
+		},
+		{
+			name:       "a single topology domain",
+			constraint: appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "zone", WhenUnsatisfiable: corev1.DoNotSchedule},
+			replicas:   []int32{4, 4, 1},
+			expected:   []int32{4, 4, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spreadReplicas([]appsapi.ClusterTopologySpreadConstraint{tt.constraint}, clusters, tt.replicas)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
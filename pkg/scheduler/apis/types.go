@@ -21,9 +21,56 @@ package apis
 import (
 	"math"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusternetSchedulerConfiguration configures a scheduler
+type ClusternetSchedulerConfiguration struct {
+	metav1.TypeMeta
+
+	// Profiles are scheduling profiles that clusternet-scheduler supports. Subscriptions can
+	// choose to be scheduled under a particular profile by setting its associated
+	// scheduler name. Subscriptions that don't specify any scheduler name are scheduled
+	// with the "default" profile, if present here.
+	Profiles []ClusternetSchedulerProfile
+
+	// Extenders are the list of scheduler extenders, each holding the values of how to communicate
+	// with the extender. These extenders are shared by all scheduler profiles.
+	Extenders []Extender
+}
+
+// ClusternetSchedulerProfile is a scheduling profile.
+type ClusternetSchedulerProfile struct {
+	// SchedulerName is the name of the scheduler associated to this profile.
+	// If SchedulerName matches with the Subscription's "spec.schedulerName", then the Subscription
+	// is scheduled with this profile.
+	SchedulerName string
+
+	// PercentageOfClustersToScore is the percentage of all clusters that once found feasible
+	// for running a Subscription, the scheduler stops its search for more feasible clusters in
+	// the cluster. This helps improve scheduler's performance. Scheduler always tries to find
+	// at least "minFeasibleClustersToFind" feasible clusters no matter what the value of this flag is.
+	// If this value is 0, a default percentage (5%--50% based on the size of the clusters) will be used.
+	PercentageOfClustersToScore int32
+
+	// Plugins specify the set of plugins that should be enabled or disabled.
+	// Enabled plugins are the ones that should be enabled in addition to the
+	// default plugins. Disabled plugins are any of the default plugins that
+	// should be disabled.
+	// When no enabled or disabled plugin is specified for an extension point,
+	// default plugins for that extension point will be used if there is any.
+	Plugins *Plugins
+
+	// PluginConfig is an optional set of custom plugin arguments for each plugin.
+	// Omitting config args for a plugin is equivalent to using the default config
+	// for that plugin.
+	PluginConfig []PluginConfig
+}
+
 // Plugins include multiple extension points. When specified, the list of plugins for
 // a particular extension point are the only ones enabled. If an extension point is
 // omitted from the config, then the default set of plugins is used for that extension point.
@@ -45,6 +92,10 @@ type Plugins struct {
 	// Score is a list of plugins that should be invoked when ranking clusters that have passed the filtering phase.
 	Score PluginSet
 
+	// Assign is a list of plugins that should be invoked when dividing replicas into the selected clusters.
+	// The scheduler call these plugins in order. Scheduler skips the rest of these plugins as soon as one returns success.
+	Assign PluginSet
+
 	// Reserve is a list of plugins invoked when reserving/unreserving resources
 	// after a cluster is assigned to run the Subscription.
 	Reserve PluginSet
@@ -82,6 +133,72 @@ type Plugin struct {
 	Weight int32
 }
 
+// PluginConfig specifies arguments that should be passed to a plugin at the time of initialization.
+// A plugin that is invoked at multiple extension points is initialized once. Args can have arbitrary structure.
+// It is up to the plugin to process these Args.
+type PluginConfig struct {
+	// Name defines the name of plugin being configured
+	Name string
+	// Args defines the arguments passed to the plugins at the time of initialization. Args can have arbitrary structure.
+	Args runtime.Object
+}
+
+// Extender holds the parameters used to communicate with the extender. If a verb is unspecified/empty,
+// it is assumed that the extender chose not to provide that extension.
+type Extender struct {
+	// URLPrefix at which the extender is available
+	URLPrefix string
+	// Verb for the filter call, empty if not supported. This verb is appended to the URLPrefix when issuing the filter call to extender.
+	FilterVerb string
+	// Verb for the prioritize call, empty if not supported. This verb is appended to the URLPrefix when issuing the prioritize call to extender.
+	PrioritizeVerb string
+	// The numeric multiplier for the cluster scores that the prioritize call generates.
+	// The weight should be a positive integer
+	Weight int64
+	// Verb for the bind call, empty if not supported. This verb is appended to the URLPrefix when issuing the bind call to extender.
+	// If this method is implemented by the extender, it is the extender's responsibility to bind the Subscription to clusters,
+	// which means updating the binding clusters and the divided replicas in the Subscription status.
+	// A binder extender replaces the Bind plugins of all the profiles, and at most one extender could implement bind.
+	BindVerb string
+	// EnableHTTPS specifies whether https should be used to communicate with the extender
+	EnableHTTPS bool
+	// TLSConfig specifies the transport layer security config
+	TLSConfig *ExtenderTLSConfig
+	// HTTPTimeout specifies the timeout duration for a call to the extender. Filter timeout fails the scheduling of the Subscription.
+	// Prioritize timeout is ignored, clusternet/other extenders priorities are used to select the clusters.
+	HTTPTimeout metav1.Duration
+	// Ignorable specifies if the extender is ignorable, i.e. scheduling should not
+	// fail when the extender returns an error or is not reachable.
+	Ignorable bool
+}
+
+// ExtenderTLSConfig contains settings to enable TLS with extender
+type ExtenderTLSConfig struct {
+	// Server should be accessed without verifying the TLS certificate. For testing only.
+	Insecure bool
+	// ServerName is passed to the server for SNI and is used in the client to check server
+	// certificates against. If ServerName is empty, the hostname used to contact the
+	// server is used.
+	ServerName string
+
+	// Server requires TLS client certificate authentication
+	CertFile string
+	// Server requires TLS client certificate authentication
+	KeyFile string
+	// Trusted root certificates for server
+	CAFile string
+
+	// CertData holds PEM-encoded bytes (typically read from a client certificate file).
+	// CertData takes precedence over CertFile
+	CertData []byte
+	// KeyData holds PEM-encoded bytes (typically read from a client certificate key file).
+	// KeyData takes precedence over KeyFile
+	KeyData []byte
+	// CAData holds PEM-encoded bytes (typically read from a root certificates bundle).
+	// CAData takes precedence over CAFile
+	CAData []byte
+}
+
 /*
  * NOTE: The following variables and methods are intentionally left out of the staging mirror.
  */
@@ -91,6 +208,9 @@ const (
 	// A value of 0 means adaptive, meaning the scheduler figures out a proper default.
 	DefaultPercentageOfClustersToScore = 0
 
+	// DefaultSchedulerName defines the name of default scheduler.
+	DefaultSchedulerName = "default"
+
 	// MaxCustomPriorityScore is the max score UtilizationShapePoint expects.
 	MaxCustomPriorityScore int64 = 10
 
@@ -113,6 +233,7 @@ func (p *Plugins) Names() []string {
 		p.Reserve,
 		p.PreScore,
 		p.Score,
+		p.Assign,
 		p.PreBind,
 		p.Bind,
 		p.PostBind,
@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apis
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultToleratedMissedHeartbeats is the default number of consecutive heartbeats
+	// that a cluster could miss before being regarded as unready.
+	DefaultToleratedMissedHeartbeats = 3
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterReadyArgs holds arguments used to configure ClusterReady plugin.
+type ClusterReadyArgs struct {
+	metav1.TypeMeta
+
+	// ToleratedMissedHeartbeats is the number of consecutive heartbeats that a cluster could miss,
+	// which is measured by the HeartbeatFrequencySeconds reported by the cluster.
+	// Short heartbeat gaps within this number are tolerated, while the cluster is still regarded as ready.
+	// Zero means a cluster will be regarded as unready once it misses a heartbeat.
+	ToleratedMissedHeartbeats int32
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterResourcesRequestedToCapacityRatioArgs holds arguments used to configure ClusterResourcesRequestedToCapacityRatio plugin.
+type ClusterResourcesRequestedToCapacityRatioArgs struct {
+	metav1.TypeMeta
+
+	// Shape is a list of points defining the scoring function shape.
+	Shape []UtilizationShapePoint
+	// Resources to be considered when scoring.
+	// The default resource set includes "cpu" and "memory" with an equal weight.
+	// Weights should be larger than 0.
+	Resources []ResourceSpec
+}
+
+// UtilizationShapePoint represents a single point of a priority function shape.
+type UtilizationShapePoint struct {
+	// Utilization (x axis). Valid values are 0 to 100. Fully utilized cluster maps to 100.
+	Utilization int32
+	// Score assigned to a given utilization (y axis). Valid values are 0 to 10.
+	Score int32
+}
+
+// ResourceSpec represents single resource.
+type ResourceSpec struct {
+	// Name of the resource.
+	Name string
+	// Weight of the resource.
+	Weight int64
+}
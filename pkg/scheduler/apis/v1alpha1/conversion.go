@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+)
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Only conversions from v1alpha1 to the internal version are needed, since
+// the scheduler configuration is only read but never written back.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddConversionFunc((*ClusternetSchedulerConfiguration)(nil), (*schedulerapis.ClusternetSchedulerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusternetSchedulerConfiguration_To_apis_ClusternetSchedulerConfiguration(a.(*ClusternetSchedulerConfiguration), b.(*schedulerapis.ClusternetSchedulerConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*ClusterReadyArgs)(nil), (*schedulerapis.ClusterReadyArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterReadyArgs_To_apis_ClusterReadyArgs(a.(*ClusterReadyArgs), b.(*schedulerapis.ClusterReadyArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*ClusterResourcesRequestedToCapacityRatioArgs)(nil), (*schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterResourcesRequestedToCapacityRatioArgs_To_apis_ClusterResourcesRequestedToCapacityRatioArgs(a.(*ClusterResourcesRequestedToCapacityRatioArgs), b.(*schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ClusternetSchedulerConfiguration_To_apis_ClusternetSchedulerConfiguration converts
+// a versioned ClusternetSchedulerConfiguration to the internal one.
+func Convert_v1alpha1_ClusternetSchedulerConfiguration_To_apis_ClusternetSchedulerConfiguration(in *ClusternetSchedulerConfiguration, out *schedulerapis.ClusternetSchedulerConfiguration, s conversion.Scope) error {
+	out.TypeMeta = in.TypeMeta
+
+	out.Profiles = nil
+	if in.Profiles != nil {
+		out.Profiles = make([]schedulerapis.ClusternetSchedulerProfile, len(in.Profiles))
+		for i := range in.Profiles {
+			if err := convertProfile(&in.Profiles[i], &out.Profiles[i], s); err != nil {
+				return err
+			}
+		}
+	}
+
+	out.Extenders = nil
+	if in.Extenders != nil {
+		out.Extenders = make([]schedulerapis.Extender, len(in.Extenders))
+		for i := range in.Extenders {
+			convertExtender(&in.Extenders[i], &out.Extenders[i])
+		}
+	}
+	return nil
+}
+
+func convertExtender(in *Extender, out *schedulerapis.Extender) {
+	out.URLPrefix = in.URLPrefix
+	out.FilterVerb = in.FilterVerb
+	out.PrioritizeVerb = in.PrioritizeVerb
+	out.Weight = in.Weight
+	out.BindVerb = in.BindVerb
+	out.EnableHTTPS = in.EnableHTTPS
+	out.HTTPTimeout = in.HTTPTimeout
+	out.Ignorable = in.Ignorable
+
+	out.TLSConfig = nil
+	if in.TLSConfig != nil {
+		out.TLSConfig = &schedulerapis.ExtenderTLSConfig{
+			Insecure:   in.TLSConfig.Insecure,
+			ServerName: in.TLSConfig.ServerName,
+			CertFile:   in.TLSConfig.CertFile,
+			KeyFile:    in.TLSConfig.KeyFile,
+			CAFile:     in.TLSConfig.CAFile,
+			CertData:   in.TLSConfig.CertData,
+			KeyData:    in.TLSConfig.KeyData,
+			CAData:     in.TLSConfig.CAData,
+		}
+	}
+}
+
+func convertProfile(in *ClusternetSchedulerProfile, out *schedulerapis.ClusternetSchedulerProfile, s conversion.Scope) error {
+	if in.SchedulerName != nil {
+		out.SchedulerName = *in.SchedulerName
+	}
+	if in.PercentageOfClustersToScore != nil {
+		out.PercentageOfClustersToScore = *in.PercentageOfClustersToScore
+	}
+
+	if in.Plugins != nil {
+		out.Plugins = &schedulerapis.Plugins{}
+		convertPlugins(in.Plugins, out.Plugins)
+	}
+
+	if in.PluginConfig != nil {
+		out.PluginConfig = make([]schedulerapis.PluginConfig, len(in.PluginConfig))
+		for i := range in.PluginConfig {
+			if err := convertPluginConfig(&in.PluginConfig[i], &out.PluginConfig[i], s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func convertPlugins(in *Plugins, out *schedulerapis.Plugins) {
+	convertPluginSet(&in.PreFilter, &out.PreFilter)
+	convertPluginSet(&in.Filter, &out.Filter)
+	convertPluginSet(&in.PostFilter, &out.PostFilter)
+	convertPluginSet(&in.PreScore, &out.PreScore)
+	convertPluginSet(&in.Score, &out.Score)
+	convertPluginSet(&in.Assign, &out.Assign)
+	convertPluginSet(&in.Reserve, &out.Reserve)
+	convertPluginSet(&in.Permit, &out.Permit)
+	convertPluginSet(&in.PreBind, &out.PreBind)
+	convertPluginSet(&in.Bind, &out.Bind)
+	convertPluginSet(&in.PostBind, &out.PostBind)
+}
+
+func convertPluginSet(in *PluginSet, out *schedulerapis.PluginSet) {
+	convert := func(plugins []Plugin) []schedulerapis.Plugin {
+		if plugins == nil {
+			return nil
+		}
+		result := make([]schedulerapis.Plugin, len(plugins))
+		for i, p := range plugins {
+			result[i].Name = p.Name
+			if p.Weight != nil {
+				result[i].Weight = *p.Weight
+			}
+		}
+		return result
+	}
+	out.Enabled = convert(in.Enabled)
+	out.Disabled = convert(in.Disabled)
+}
+
+func convertPluginConfig(in *PluginConfig, out *schedulerapis.PluginConfig, s conversion.Scope) error {
+	out.Name = in.Name
+
+	switch args := in.Args.Object.(type) {
+	case *ClusterReadyArgs:
+		internalArgs := &schedulerapis.ClusterReadyArgs{}
+		if err := Convert_v1alpha1_ClusterReadyArgs_To_apis_ClusterReadyArgs(args, internalArgs, s); err != nil {
+			return err
+		}
+		out.Args = internalArgs
+	case *ClusterResourcesRequestedToCapacityRatioArgs:
+		internalArgs := &schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs{}
+		if err := Convert_v1alpha1_ClusterResourcesRequestedToCapacityRatioArgs_To_apis_ClusterResourcesRequestedToCapacityRatioArgs(args, internalArgs, s); err != nil {
+			return err
+		}
+		out.Args = internalArgs
+	case nil:
+		// args of out-of-tree plugins are kept as they are, and left to the plugins to parse
+		if len(in.Args.Raw) > 0 {
+			out.Args = &runtime.Unknown{
+				Raw:         in.Args.Raw,
+				ContentType: runtime.ContentTypeJSON,
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported args type %T for plugin %s", args, in.Name)
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ClusterReadyArgs_To_apis_ClusterReadyArgs converts a versioned ClusterReadyArgs to the internal one.
+func Convert_v1alpha1_ClusterReadyArgs_To_apis_ClusterReadyArgs(in *ClusterReadyArgs, out *schedulerapis.ClusterReadyArgs, _ conversion.Scope) error {
+	out.ToleratedMissedHeartbeats = schedulerapis.DefaultToleratedMissedHeartbeats
+	if in.ToleratedMissedHeartbeats != nil {
+		out.ToleratedMissedHeartbeats = *in.ToleratedMissedHeartbeats
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ClusterResourcesRequestedToCapacityRatioArgs_To_apis_ClusterResourcesRequestedToCapacityRatioArgs
+// converts a versioned ClusterResourcesRequestedToCapacityRatioArgs to the internal one.
+func Convert_v1alpha1_ClusterResourcesRequestedToCapacityRatioArgs_To_apis_ClusterResourcesRequestedToCapacityRatioArgs(in *ClusterResourcesRequestedToCapacityRatioArgs, out *schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs, _ conversion.Scope) error {
+	out.Shape = nil
+	for _, point := range in.Shape {
+		out.Shape = append(out.Shape, schedulerapis.UtilizationShapePoint{
+			Utilization: point.Utilization,
+			Score:       point.Score,
+		})
+	}
+	out.Resources = nil
+	for _, resource := range in.Resources {
+		out.Resources = append(out.Resources, schedulerapis.ResourceSpec{
+			Name:   resource.Name,
+			Weight: resource.Weight,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+)
+
+// DefaultExtenderTimeout defines the default timeout duration for a call to the extender.
+const DefaultExtenderTimeout = 5 * time.Second
+
+var (
+	defaultFunctionShape = []UtilizationShapePoint{
+		{Utilization: 0, Score: int32(schedulerapis.MaxCustomPriorityScore)},
+		{Utilization: 100, Score: 0},
+	}
+
+	defaultResourceSpec = []ResourceSpec{
+		{Name: string(corev1.ResourceCPU), Weight: 1},
+		{Name: string(corev1.ResourceMemory), Weight: 1},
+	}
+)
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ClusternetSchedulerConfiguration{}, func(obj interface{}) {
+		SetDefaults_ClusternetSchedulerConfiguration(obj.(*ClusternetSchedulerConfiguration))
+	})
+	scheme.AddTypeDefaultingFunc(&ClusterReadyArgs{}, func(obj interface{}) {
+		SetDefaults_ClusterReadyArgs(obj.(*ClusterReadyArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&ClusterResourcesRequestedToCapacityRatioArgs{}, func(obj interface{}) {
+		SetDefaults_ClusterResourcesRequestedToCapacityRatioArgs(obj.(*ClusterResourcesRequestedToCapacityRatioArgs))
+	})
+	return nil
+}
+
+// SetDefaults_ClusternetSchedulerConfiguration sets additional defaults
+func SetDefaults_ClusternetSchedulerConfiguration(obj *ClusternetSchedulerConfiguration) {
+	if len(obj.Profiles) == 0 {
+		obj.Profiles = append(obj.Profiles, ClusternetSchedulerProfile{})
+	}
+	// Only apply a default scheduler name when there is a single profile.
+	// Validation will ensure that every profile has a non-empty unique name.
+	if len(obj.Profiles) == 1 && obj.Profiles[0].SchedulerName == nil {
+		schedulerName := schedulerapis.DefaultSchedulerName
+		obj.Profiles[0].SchedulerName = &schedulerName
+	}
+
+	for i := range obj.Profiles {
+		setDefaults_ClusternetSchedulerProfile(&obj.Profiles[i])
+	}
+
+	for i := range obj.Extenders {
+		if obj.Extenders[i].HTTPTimeout.Duration == 0 {
+			obj.Extenders[i].HTTPTimeout = metav1.Duration{Duration: DefaultExtenderTimeout}
+		}
+	}
+}
+
+func setDefaults_ClusternetSchedulerProfile(prof *ClusternetSchedulerProfile) {
+	if prof.PercentageOfClustersToScore == nil {
+		percentageOfClustersToScore := int32(schedulerapis.DefaultPercentageOfClustersToScore)
+		prof.PercentageOfClustersToScore = &percentageOfClustersToScore
+	}
+
+	// a weight of zero is not permitted for score plugins, so enabled score plugins
+	// without any weight get a weight of 1.
+	if prof.Plugins != nil {
+		for i := range prof.Plugins.Score.Enabled {
+			if prof.Plugins.Score.Enabled[i].Weight == nil {
+				weight := int32(1)
+				prof.Plugins.Score.Enabled[i].Weight = &weight
+			}
+		}
+	}
+
+	// default the args of in-tree plugins, which have been decoded as nested objects
+	for i := range prof.PluginConfig {
+		switch args := prof.PluginConfig[i].Args.Object.(type) {
+		case *ClusterReadyArgs:
+			SetDefaults_ClusterReadyArgs(args)
+		case *ClusterResourcesRequestedToCapacityRatioArgs:
+			SetDefaults_ClusterResourcesRequestedToCapacityRatioArgs(args)
+		}
+	}
+}
+
+// SetDefaults_ClusterReadyArgs sets the default parameters for ClusterReady plugin.
+func SetDefaults_ClusterReadyArgs(obj *ClusterReadyArgs) {
+	if obj.ToleratedMissedHeartbeats == nil {
+		toleratedMissedHeartbeats := int32(schedulerapis.DefaultToleratedMissedHeartbeats)
+		obj.ToleratedMissedHeartbeats = &toleratedMissedHeartbeats
+	}
+}
+
+// SetDefaults_ClusterResourcesRequestedToCapacityRatioArgs sets the default parameters for
+// ClusterResourcesRequestedToCapacityRatio plugin.
+func SetDefaults_ClusterResourcesRequestedToCapacityRatioArgs(obj *ClusterResourcesRequestedToCapacityRatioArgs) {
+	if len(obj.Shape) == 0 {
+		obj.Shape = append([]UtilizationShapePoint{}, defaultFunctionShape...)
+	}
+
+	if len(obj.Resources) == 0 {
+		obj.Resources = append([]ResourceSpec{}, defaultResourceSpec...)
+		return
+	}
+
+	// If the weight is not set or it is explicitly set to 0, then apply the default weight(1) instead.
+	for i := range obj.Resources {
+		if obj.Resources[i].Weight == 0 {
+			obj.Resources[i].Weight = 1
+		}
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusternetSchedulerConfiguration configures a scheduler
+type ClusternetSchedulerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Profiles are scheduling profiles that clusternet-scheduler supports. Subscriptions can
+	// choose to be scheduled under a particular profile by setting its associated
+	// scheduler name. Subscriptions that don't specify any scheduler name are scheduled
+	// with the "default" profile, if present here.
+	//
+	// +optional
+	Profiles []ClusternetSchedulerProfile `json:"profiles,omitempty"`
+
+	// Extenders are the list of scheduler extenders, each holding the values of how to communicate
+	// with the extender. These extenders are shared by all scheduler profiles.
+	//
+	// +optional
+	Extenders []Extender `json:"extenders,omitempty"`
+}
+
+// ClusternetSchedulerProfile is a scheduling profile.
+type ClusternetSchedulerProfile struct {
+	// SchedulerName is the name of the scheduler associated to this profile.
+	// If SchedulerName matches with the Subscription's "spec.schedulerName", then the Subscription
+	// is scheduled with this profile. Defaults to "default".
+	//
+	// +optional
+	SchedulerName *string `json:"schedulerName,omitempty"`
+
+	// PercentageOfClustersToScore is the percentage of all clusters that once found feasible
+	// for running a Subscription, the scheduler stops its search for more feasible clusters.
+	// If this value is 0, a default percentage (5%--50% based on the size of the clusters) will be used.
+	//
+	// +optional
+	PercentageOfClustersToScore *int32 `json:"percentageOfClustersToScore,omitempty"`
+
+	// Plugins specify the set of plugins that should be enabled or disabled.
+	// Enabled plugins are the ones that should be enabled in addition to the
+	// default plugins. Disabled plugins are any of the default plugins that
+	// should be disabled.
+	// When no enabled or disabled plugin is specified for an extension point,
+	// default plugins for that extension point will be used if there is any.
+	//
+	// +optional
+	Plugins *Plugins `json:"plugins,omitempty"`
+
+	// PluginConfig is an optional set of custom plugin arguments for each plugin.
+	// Omitting config args for a plugin is equivalent to using the default config
+	// for that plugin.
+	//
+	// +optional
+	PluginConfig []PluginConfig `json:"pluginConfig,omitempty"`
+}
+
+// Plugins include multiple extension points. When specified, the list of plugins for
+// a particular extension point are the only ones enabled. If an extension point is
+// omitted from the config, then the default set of plugins is used for that extension point.
+// Enabled plugins are called in the order specified here, after default plugins. If they need to
+// be invoked before default plugins, default plugins must be disabled and re-enabled here in desired order.
+type Plugins struct {
+	// PreFilter is a list of plugins that should be invoked at "PreFilter" extension point of the scheduling framework.
+	PreFilter PluginSet `json:"preFilter,omitempty"`
+
+	// Filter is a list of plugins that should be invoked when filtering out clusters that cannot run the Subscription.
+	Filter PluginSet `json:"filter,omitempty"`
+
+	// PostFilter is a list of plugins that are invoked after filtering phase, no matter whether filtering succeeds or not.
+	PostFilter PluginSet `json:"postFilter,omitempty"`
+
+	// PreScore is a list of plugins that are invoked before scoring.
+	PreScore PluginSet `json:"preScore,omitempty"`
+
+	// Score is a list of plugins that should be invoked when ranking clusters that have passed the filtering phase.
+	Score PluginSet `json:"score,omitempty"`
+
+	// Assign is a list of plugins that should be invoked when dividing replicas into the selected clusters.
+	Assign PluginSet `json:"assign,omitempty"`
+
+	// Reserve is a list of plugins invoked when reserving/unreserving resources
+	// after a cluster is assigned to run the Subscription.
+	Reserve PluginSet `json:"reserve,omitempty"`
+
+	// Permit is a list of plugins that control binding of a Subscription. These plugins can prevent or delay binding of a Subscription.
+	Permit PluginSet `json:"permit,omitempty"`
+
+	// PreBind is a list of plugins that should be invoked before a Subscription is bound.
+	PreBind PluginSet `json:"preBind,omitempty"`
+
+	// Bind is a list of plugins that should be invoked at "Bind" extension point of the scheduling framework.
+	Bind PluginSet `json:"bind,omitempty"`
+
+	// PostBind is a list of plugins that should be invoked after a Subscription is successfully bound.
+	PostBind PluginSet `json:"postBind,omitempty"`
+}
+
+// PluginSet specifies enabled and disabled plugins for an extension point.
+// If an array is empty, missing, or nil, default plugins at that extension point will be used.
+type PluginSet struct {
+	// Enabled specifies plugins that should be enabled in addition to default plugins.
+	// These are called after default plugins and in the same order specified here.
+	//
+	// +optional
+	Enabled []Plugin `json:"enabled,omitempty"`
+
+	// Disabled specifies default plugins that should be disabled.
+	// When all default plugins need to be disabled, an array containing only one "*" should be provided.
+	//
+	// +optional
+	Disabled []Plugin `json:"disabled,omitempty"`
+}
+
+// Plugin specifies a plugin name and its weight when applicable. Weight is used only for Score plugins.
+type Plugin struct {
+	// Name defines the name of plugin
+	Name string `json:"name"`
+
+	// Weight defines the weight of plugin, only used for Score plugins.
+	//
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// PluginConfig specifies arguments that should be passed to a plugin at the time of initialization.
+// A plugin that is invoked at multiple extension points is initialized once. Args can have arbitrary structure.
+// It is up to the plugin to process these Args.
+type PluginConfig struct {
+	// Name defines the name of plugin being configured
+	Name string `json:"name"`
+
+	// Args defines the arguments passed to the plugins at the time of initialization. Args can have arbitrary structure.
+	//
+	// +optional
+	Args runtime.RawExtension `json:"args,omitempty"`
+}
+
+// Extender holds the parameters used to communicate with the extender. If a verb is unspecified/empty,
+// it is assumed that the extender chose not to provide that extension.
+type Extender struct {
+	// URLPrefix at which the extender is available
+	URLPrefix string `json:"urlPrefix"`
+	// Verb for the filter call, empty if not supported. This verb is appended to the URLPrefix when issuing the filter call to extender.
+	//
+	// +optional
+	FilterVerb string `json:"filterVerb,omitempty"`
+	// Verb for the prioritize call, empty if not supported. This verb is appended to the URLPrefix when issuing the prioritize call to extender.
+	//
+	// +optional
+	PrioritizeVerb string `json:"prioritizeVerb,omitempty"`
+	// The numeric multiplier for the cluster scores that the prioritize call generates.
+	// The weight should be a positive integer
+	//
+	// +optional
+	Weight int64 `json:"weight,omitempty"`
+	// Verb for the bind call, empty if not supported. This verb is appended to the URLPrefix when issuing the bind call to extender.
+	// If this method is implemented by the extender, it is the extender's responsibility to bind the Subscription to clusters,
+	// which means updating the binding clusters and the divided replicas in the Subscription status.
+	// A binder extender replaces the Bind plugins of all the profiles, and at most one extender could implement bind.
+	//
+	// +optional
+	BindVerb string `json:"bindVerb,omitempty"`
+	// EnableHTTPS specifies whether https should be used to communicate with the extender
+	//
+	// +optional
+	EnableHTTPS bool `json:"enableHTTPS,omitempty"`
+	// TLSConfig specifies the transport layer security config
+	//
+	// +optional
+	TLSConfig *ExtenderTLSConfig `json:"tlsConfig,omitempty"`
+	// HTTPTimeout specifies the timeout duration for a call to the extender. Filter timeout fails the scheduling of the Subscription.
+	// Prioritize timeout is ignored, clusternet/other extenders priorities are used to select the clusters.
+	// Defaults to 5s.
+	//
+	// +optional
+	HTTPTimeout metav1.Duration `json:"httpTimeout,omitempty"`
+	// Ignorable specifies if the extender is ignorable, i.e. scheduling should not
+	// fail when the extender returns an error or is not reachable.
+	//
+	// +optional
+	Ignorable bool `json:"ignorable,omitempty"`
+}
+
+// ExtenderTLSConfig contains settings to enable TLS with extender
+type ExtenderTLSConfig struct {
+	// Server should be accessed without verifying the TLS certificate. For testing only.
+	//
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+	// ServerName is passed to the server for SNI and is used in the client to check server
+	// certificates against. If ServerName is empty, the hostname used to contact the
+	// server is used.
+	//
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Server requires TLS client certificate authentication
+	//
+	// +optional
+	CertFile string `json:"certFile,omitempty"`
+	// Server requires TLS client certificate authentication
+	//
+	// +optional
+	KeyFile string `json:"keyFile,omitempty"`
+	// Trusted root certificates for server
+	//
+	// +optional
+	CAFile string `json:"caFile,omitempty"`
+
+	// CertData holds PEM-encoded bytes (typically read from a client certificate file).
+	// CertData takes precedence over CertFile
+	//
+	// +optional
+	CertData []byte `json:"certData,omitempty"`
+	// KeyData holds PEM-encoded bytes (typically read from a client certificate key file).
+	// KeyData takes precedence over KeyFile
+	//
+	// +optional
+	KeyData []byte `json:"keyData,omitempty"`
+	// CAData holds PEM-encoded bytes (typically read from a root certificates bundle).
+	// CAData takes precedence over CAFile
+	//
+	// +optional
+	CAData []byte `json:"caData,omitempty"`
+}
+
+// DecodeNestedObjects decodes plugin args for known types.
+func (c *ClusternetSchedulerConfiguration) DecodeNestedObjects(d runtime.Decoder) error {
+	for i := range c.Profiles {
+		prof := &c.Profiles[i]
+		for j := range prof.PluginConfig {
+			if err := prof.PluginConfig[j].decodeNestedObjects(d); err != nil {
+				return fmt.Errorf("decoding .profiles[%d].pluginConfig[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// EncodeNestedObjects encodes plugin args.
+func (c *ClusternetSchedulerConfiguration) EncodeNestedObjects(e runtime.Encoder) error {
+	for i := range c.Profiles {
+		prof := &c.Profiles[i]
+		for j := range prof.PluginConfig {
+			if err := prof.PluginConfig[j].encodeNestedObjects(e); err != nil {
+				return fmt.Errorf("encoding .profiles[%d].pluginConfig[%d]: %w", i, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// decodeNestedObjects decodes the args of a plugin into the registered type named "<PluginName>Args".
+func (c *PluginConfig) decodeNestedObjects(d runtime.Decoder) error {
+	gvk := SchemeGroupVersion.WithKind(c.Name + "Args")
+	// dry-run to detect and skip out-of-tree plugin args.
+	if _, _, err := d.Decode(nil, &gvk, nil); runtime.IsNotRegisteredError(err) {
+		return nil
+	}
+
+	obj, parsedGvk, err := d.Decode(c.Args.Raw, &gvk, nil)
+	if err != nil {
+		return fmt.Errorf("decoding args for plugin %s: %w", c.Name, err)
+	}
+	if parsedGvk.GroupKind() != gvk.GroupKind() {
+		return fmt.Errorf("args for plugin %s were not of type %s, got %s", c.Name, gvk.GroupKind(), parsedGvk.GroupKind())
+	}
+	c.Args.Object = obj
+	return nil
+}
+
+func (c *PluginConfig) encodeNestedObjects(e runtime.Encoder) error {
+	if c.Args.Object == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := e.Encode(c.Args.Object, &buf); err != nil {
+		return err
+	}
+	// The <e> encoder might be a YAML encoder, but the parent encoder expects
+	// JSON output, so we convert YAML back to JSON.
+	// This is a no-op if <e> produces JSON.
+	json, err := yaml.YAMLToJSON(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	c.Args.Raw = json
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterReadyArgs holds arguments used to configure ClusterReady plugin.
+type ClusterReadyArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ToleratedMissedHeartbeats is the number of consecutive heartbeats that a cluster could miss,
+	// which is measured by the HeartbeatFrequencySeconds reported by the cluster.
+	// Defaults to 3.
+	//
+	// +optional
+	ToleratedMissedHeartbeats *int32 `json:"toleratedMissedHeartbeats,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterResourcesRequestedToCapacityRatioArgs holds arguments used to configure ClusterResourcesRequestedToCapacityRatio plugin.
+type ClusterResourcesRequestedToCapacityRatioArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Shape is a list of points defining the scoring function shape.
+	//
+	// +optional
+	Shape []UtilizationShapePoint `json:"shape,omitempty"`
+
+	// Resources to be considered when scoring.
+	// The default resource set includes "cpu" and "memory" with an equal weight.
+	//
+	// +optional
+	Resources []ResourceSpec `json:"resources,omitempty"`
+}
+
+// UtilizationShapePoint represents a single point of a priority function shape.
+type UtilizationShapePoint struct {
+	// Utilization (x axis). Valid values are 0 to 100. Fully utilized cluster maps to 100.
+	Utilization int32 `json:"utilization"`
+	// Score assigned to a given utilization (y axis). Valid values are 0 to 10.
+	Score int32 `json:"score"`
+}
+
+// ResourceSpec represents single resource.
+type ResourceSpec struct {
+	// Name of the resource.
+	Name string `json:"name"`
+	// Weight of the resource.
+	Weight int64 `json:"weight,omitempty"`
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+// ValidateClusternetSchedulerConfiguration ensures validation of the ClusternetSchedulerConfiguration struct
+func ValidateClusternetSchedulerConfiguration(cc *schedulerapis.ClusternetSchedulerConfiguration) utilerrors.Aggregate {
+	var errs field.ErrorList
+
+	profilesPath := field.NewPath("profiles")
+	if len(cc.Profiles) == 0 {
+		errs = append(errs, field.Required(profilesPath, ""))
+	}
+
+	existingProfiles := sets.NewString()
+	for i := range cc.Profiles {
+		profile := &cc.Profiles[i]
+		path := profilesPath.Index(i)
+		errs = append(errs, validateClusternetSchedulerProfile(path, profile)...)
+		if existingProfiles.Has(profile.SchedulerName) {
+			errs = append(errs, field.Duplicate(path.Child("schedulerName"), profile.SchedulerName))
+		}
+		existingProfiles.Insert(profile.SchedulerName)
+	}
+
+	errs = append(errs, validateExtenders(field.NewPath("extenders"), cc.Extenders)...)
+	return errs.ToAggregate()
+}
+
+func validateExtenders(path *field.Path, extenders []schedulerapis.Extender) field.ErrorList {
+	var errs field.ErrorList
+
+	binders := 0
+	for i, extender := range extenders {
+		extenderPath := path.Index(i)
+		if len(extender.URLPrefix) == 0 {
+			errs = append(errs, field.Required(extenderPath.Child("urlPrefix"), ""))
+		}
+		if len(extender.PrioritizeVerb) > 0 && extender.Weight <= 0 {
+			errs = append(errs, field.Invalid(extenderPath.Child("weight"),
+				extender.Weight, "must have a positive weight applied to it"))
+		}
+		if extender.HTTPTimeout.Duration < 0 {
+			errs = append(errs, field.Invalid(extenderPath.Child("httpTimeout"),
+				extender.HTTPTimeout.Duration.String(), "must be greater than or equal to 0"))
+		}
+		if len(extender.BindVerb) > 0 {
+			binders++
+		}
+	}
+	if binders > 1 {
+		errs = append(errs, field.Invalid(path, fmt.Sprintf("found %d extenders implementing bind", binders),
+			"only one extender can implement bind"))
+	}
+	return errs
+}
+
+func validateClusternetSchedulerProfile(path *field.Path, profile *schedulerapis.ClusternetSchedulerProfile) field.ErrorList {
+	var errs field.ErrorList
+
+	if len(profile.SchedulerName) == 0 {
+		errs = append(errs, field.Required(path.Child("schedulerName"), ""))
+	}
+
+	if profile.PercentageOfClustersToScore < 0 || profile.PercentageOfClustersToScore > 100 {
+		errs = append(errs, field.Invalid(path.Child("percentageOfClustersToScore"),
+			profile.PercentageOfClustersToScore, "not in valid range [0-100]"))
+	}
+
+	registry := plugins.NewInTreeRegistry()
+	if profile.Plugins != nil {
+		errs = append(errs, validatePluginNames(path.Child("plugins"), profile.Plugins, registry)...)
+
+		scorePath := path.Child("plugins", "score", "enabled")
+		for i, plugin := range profile.Plugins.Score.Enabled {
+			if plugin.Weight <= 0 || int64(plugin.Weight) > schedulerapis.MaxWeight {
+				errs = append(errs, field.Invalid(scorePath.Index(i).Child("weight"),
+					plugin.Weight, fmt.Sprintf("not in valid range [1-%d]", schedulerapis.MaxWeight)))
+			}
+		}
+	}
+
+	errs = append(errs, validatePluginConfig(path.Child("pluginConfig"), profile.PluginConfig, registry)...)
+	return errs
+}
+
+// validatePluginNames ensures all the plugins enabled or disabled at each extension point are registered,
+// except for "*" which disables all the default plugins.
+func validatePluginNames(path *field.Path, plugins *schedulerapis.Plugins, registry runtime.Registry) field.ErrorList {
+	var errs field.ErrorList
+
+	extensionPoints := []struct {
+		name      string
+		pluginSet schedulerapis.PluginSet
+	}{
+		{"preFilter", plugins.PreFilter},
+		{"filter", plugins.Filter},
+		{"postFilter", plugins.PostFilter},
+		{"preScore", plugins.PreScore},
+		{"score", plugins.Score},
+		{"assign", plugins.Assign},
+		{"reserve", plugins.Reserve},
+		{"permit", plugins.Permit},
+		{"preBind", plugins.PreBind},
+		{"bind", plugins.Bind},
+		{"postBind", plugins.PostBind},
+	}
+	for _, e := range extensionPoints {
+		for i, plugin := range e.pluginSet.Enabled {
+			if _, ok := registry[plugin.Name]; !ok {
+				errs = append(errs, field.NotSupported(path.Child(e.name, "enabled").Index(i).Child("name"),
+					plugin.Name, sets.StringKeySet(registry).List()))
+			}
+		}
+		for i, plugin := range e.pluginSet.Disabled {
+			if _, ok := registry[plugin.Name]; !ok && plugin.Name != "*" {
+				errs = append(errs, field.NotSupported(path.Child(e.name, "disabled").Index(i).Child("name"),
+					plugin.Name, append(sets.StringKeySet(registry).List(), "*")))
+			}
+		}
+	}
+	return errs
+}
+
+func validatePluginConfig(path *field.Path, pluginConfig []schedulerapis.PluginConfig, registry runtime.Registry) field.ErrorList {
+	var errs field.ErrorList
+
+	seenPluginConfig := sets.NewString()
+	for i := range pluginConfig {
+		pluginConfigPath := path.Index(i)
+		name := pluginConfig[i].Name
+		if seenPluginConfig.Has(name) {
+			errs = append(errs, field.Duplicate(pluginConfigPath, name))
+		}
+		seenPluginConfig.Insert(name)
+		if _, ok := registry[name]; !ok {
+			errs = append(errs, field.NotSupported(pluginConfigPath.Child("name"), name, sets.StringKeySet(registry).List()))
+		}
+
+		argsPath := pluginConfigPath.Child("args")
+		switch args := pluginConfig[i].Args.(type) {
+		case *schedulerapis.ClusterReadyArgs:
+			errs = append(errs, validateClusterReadyArgs(argsPath, args)...)
+		case *schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs:
+			errs = append(errs, validateRequestedToCapacityRatioArgs(argsPath, args)...)
+		}
+	}
+	return errs
+}
+
+func validateClusterReadyArgs(path *field.Path, args *schedulerapis.ClusterReadyArgs) field.ErrorList {
+	var errs field.ErrorList
+	if args.ToleratedMissedHeartbeats < 0 {
+		errs = append(errs, field.Invalid(path.Child("toleratedMissedHeartbeats"),
+			args.ToleratedMissedHeartbeats, "must be greater than or equal to 0"))
+	}
+	return errs
+}
+
+func validateRequestedToCapacityRatioArgs(path *field.Path, args *schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs) field.ErrorList {
+	var errs field.ErrorList
+
+	shapePath := path.Child("shape")
+	if len(args.Shape) == 0 {
+		errs = append(errs, field.Required(shapePath, "at least one point must be specified"))
+	}
+	for i, point := range args.Shape {
+		if point.Utilization < 0 || point.Utilization > 100 {
+			errs = append(errs, field.Invalid(shapePath.Index(i).Child("utilization"),
+				point.Utilization, "not in valid range [0-100]"))
+		}
+		if i > 0 && point.Utilization <= args.Shape[i-1].Utilization {
+			errs = append(errs, field.Invalid(shapePath.Index(i).Child("utilization"),
+				point.Utilization, "values must be sorted in strictly increasing order"))
+		}
+		if point.Score < 0 || int64(point.Score) > schedulerapis.MaxCustomPriorityScore {
+			errs = append(errs, field.Invalid(shapePath.Index(i).Child("score"),
+				point.Score, fmt.Sprintf("not in valid range [0-%d]", schedulerapis.MaxCustomPriorityScore)))
+		}
+	}
+
+	for i, resource := range args.Resources {
+		if resource.Weight <= 0 || resource.Weight > 100 {
+			errs = append(errs, field.Invalid(path.Child("resources").Index(i).Child("weight"),
+				resource.Weight, "not in valid range [1-100]"))
+		}
+	}
+	return errs
+}
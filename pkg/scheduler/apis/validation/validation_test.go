@@ -0,0 +1,241 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+)
+
+func TestValidateClusternetSchedulerConfiguration(t *testing.T) {
+	validProfile := func(name string) schedulerapis.ClusternetSchedulerProfile {
+		return schedulerapis.ClusternetSchedulerProfile{
+			SchedulerName: name,
+			Plugins: &schedulerapis.Plugins{
+				Score: schedulerapis.PluginSet{
+					Enabled:  []schedulerapis.Plugin{{Name: "ClusterResourcesRequestedToCapacityRatio", Weight: 1}},
+					Disabled: []schedulerapis.Plugin{{Name: "*"}},
+				},
+			},
+			PluginConfig: []schedulerapis.PluginConfig{
+				{
+					Name: "ClusterReady",
+					Args: &schedulerapis.ClusterReadyArgs{ToleratedMissedHeartbeats: 3},
+				},
+				{
+					Name: "ClusterResourcesRequestedToCapacityRatio",
+					Args: &schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs{
+						Shape: []schedulerapis.UtilizationShapePoint{
+							{Utilization: 0, Score: 0},
+							{Utilization: 100, Score: 10},
+						},
+						Resources: []schedulerapis.ResourceSpec{{Name: "cpu", Weight: 1}},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		config    func() *schedulerapis.ClusternetSchedulerConfiguration
+		expectErr bool
+	}{
+		{
+			name: "valid config with multiple profiles",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{validProfile("default"), validProfile("binpacking")},
+					Extenders: []schedulerapis.Extender{
+						{
+							URLPrefix:      "http://127.0.0.1:8888",
+							FilterVerb:     "filter",
+							PrioritizeVerb: "prioritize",
+							Weight:         1,
+							BindVerb:       "bind",
+						},
+					},
+				}
+			},
+		},
+		{
+			name: "no profiles",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				return &schedulerapis.ClusternetSchedulerConfiguration{}
+			},
+			expectErr: true,
+		},
+		{
+			name: "duplicated scheduler names",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{validProfile("default"), validProfile("default")},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "empty scheduler name",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{validProfile("")},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid percentageOfClustersToScore",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.PercentageOfClustersToScore = 120
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "zero score weight",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.Plugins.Score.Enabled[0].Weight = 0
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "unknown enabled plugin",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.Plugins.Filter.Enabled = []schedulerapis.Plugin{{Name: "UnknownPlugin"}}
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "unknown disabled plugin",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.Plugins.Filter.Disabled = []schedulerapis.Plugin{{Name: "UnknownPlugin"}}
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "plugin config for unknown plugin",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.PluginConfig = append(profile.PluginConfig, schedulerapis.PluginConfig{Name: "UnknownPlugin"})
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "duplicated plugin config",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.PluginConfig = append(profile.PluginConfig, profile.PluginConfig[0])
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "negative tolerated missed heartbeats",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.PluginConfig[0].Args = &schedulerapis.ClusterReadyArgs{ToleratedMissedHeartbeats: -1}
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "unsorted utilization shape",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				profile := validProfile("default")
+				profile.PluginConfig[1].Args = &schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs{
+					Shape: []schedulerapis.UtilizationShapePoint{
+						{Utilization: 50, Score: 5},
+						{Utilization: 10, Score: 10},
+					},
+				}
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{profile},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "extender without a positive weight",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{validProfile("default")},
+					Extenders: []schedulerapis.Extender{
+						{URLPrefix: "http://127.0.0.1:8888", PrioritizeVerb: "prioritize"},
+					},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "extender without a url prefix",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{validProfile("default")},
+					Extenders: []schedulerapis.Extender{
+						{FilterVerb: "filter"},
+					},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "multiple binder extenders",
+			config: func() *schedulerapis.ClusternetSchedulerConfiguration {
+				return &schedulerapis.ClusternetSchedulerConfiguration{
+					Profiles: []schedulerapis.ClusternetSchedulerProfile{validProfile("default")},
+					Extenders: []schedulerapis.Extender{
+						{URLPrefix: "http://127.0.0.1:8888", BindVerb: "bind"},
+						{URLPrefix: "http://127.0.0.1:9999", BindVerb: "bind"},
+					},
+				}
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateClusternetSchedulerConfiguration(tt.config())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("ValidateClusternetSchedulerConfiguration() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -18,7 +18,10 @@ package cache
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/tools/cache"
@@ -37,10 +40,32 @@ type Cache interface {
 
 	// Get returns the ManagedCluster of the given managed cluster.
 	Get(namespacedName string) (*clusterapi.ManagedCluster, error)
+
+	// AssumeSubscription assumes the resources requested by a subscription on each cluster,
+	// which is indexed by the namespaced name of clusters, until the clusters report their usages in next heartbeats.
+	// The assumed resources are added to the Requested of the clusters returned by List and Get.
+	// The previous assumption of the subscription is overwritten.
+	AssumeSubscription(subKey string, clusterRequests map[string]corev1.ResourceList)
+
+	// ForgetSubscription removes the assumption of a subscription.
+	ForgetSubscription(subKey string)
+}
+
+// assumedSubscription holds the resources assumed to be consumed by a subscription.
+type assumedSubscription struct {
+	// clusterRequests is indexed by the namespaced name of clusters
+	clusterRequests map[string]corev1.ResourceList
+	// assumedAt is the time when the resources are assumed
+	assumedAt time.Time
 }
 
 type schedulerCache struct {
 	clusterListers clusterlisters.ManagedClusterLister
+
+	// protects assumedSubscriptions
+	lock sync.Mutex
+	// assumedSubscriptions is indexed by the namespaced name of subscriptions
+	assumedSubscriptions map[string]*assumedSubscription
 }
 
 // NumClusters returns the number of clusters in the cache.
@@ -59,7 +84,17 @@ func (s *schedulerCache) List(labelSelector *metav1.LabelSelector) ([]*clusterap
 	if err != nil {
 		return nil, err
 	}
-	return s.clusterListers.List(selector)
+	clusters, err := s.clusterListers.List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i := range clusters {
+		clusters[i] = s.withAssumedRequests(clusters[i])
+	}
+	return clusters, nil
 }
 
 // Get returns the ManagedCluster of the given cluster.
@@ -71,11 +106,90 @@ func (s *schedulerCache) Get(namespacedName string) (*clusterapi.ManagedCluster,
 		return nil, err
 	}
 
-	return s.clusterListers.ManagedClusters(ns).Get(name)
+	cluster, err := s.clusterListers.ManagedClusters(ns).Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.withAssumedRequests(cluster), nil
+}
+
+// AssumeSubscription assumes the resources requested by a subscription on each cluster.
+func (s *schedulerCache) AssumeSubscription(subKey string, clusterRequests map[string]corev1.ResourceList) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(clusterRequests) == 0 {
+		delete(s.assumedSubscriptions, subKey)
+		return
+	}
+	s.assumedSubscriptions[subKey] = &assumedSubscription{
+		clusterRequests: clusterRequests,
+		assumedAt:       time.Now(),
+	}
+	klog.V(5).InfoS("Assumed resources of subscription", "subscription", subKey, "clusters", len(clusterRequests))
+}
+
+// ForgetSubscription removes the assumption of a subscription.
+func (s *schedulerCache) ForgetSubscription(subKey string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.assumedSubscriptions, subKey)
+}
+
+// withAssumedRequests returns a copy of the cluster with the assumed resources added to its Requested,
+// or the cluster itself if nothing is assumed on it.
+// The assumptions expire once the cluster reports its status after the resources are assumed,
+// since the reported usage is supposed to reflect the real consumption.
+// The caller must hold the lock.
+func (s *schedulerCache) withAssumedRequests(cluster *clusterapi.ManagedCluster) *clusterapi.ManagedCluster {
+	clusterKey := klog.KObj(cluster).String()
+
+	var assumed corev1.ResourceList
+	for subKey, assumedSub := range s.assumedSubscriptions {
+		requests, ok := assumedSub.clusterRequests[clusterKey]
+		if !ok {
+			continue
+		}
+		if cluster.Status.LastObservedTime.Time.After(assumedSub.assumedAt) {
+			delete(assumedSub.clusterRequests, clusterKey)
+			if len(assumedSub.clusterRequests) == 0 {
+				delete(s.assumedSubscriptions, subKey)
+			}
+			continue
+		}
+
+		if assumed == nil {
+			assumed = make(corev1.ResourceList)
+		}
+		for name, quantity := range requests {
+			value := assumed[name]
+			value.Add(quantity)
+			assumed[name] = value
+		}
+	}
+	if len(assumed) == 0 {
+		return cluster
+	}
+
+	cluster = cluster.DeepCopy()
+	if cluster.Status.Requested == nil {
+		cluster.Status.Requested = make(corev1.ResourceList)
+	}
+	for name, quantity := range assumed {
+		value := cluster.Status.Requested[name]
+		value.Add(quantity)
+		cluster.Status.Requested[name] = value
+	}
+	return cluster
 }
 
 func New(clusterListers clusterlisters.ManagedClusterLister) Cache {
 	return &schedulerCache{
-		clusterListers: clusterListers,
+		clusterListers:       clusterListers,
+		assumedSubscriptions: make(map[string]*assumedSubscription),
 	}
 }
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+)
+
+func makeCluster(name string, lastObservedTime time.Time, requestedCPU string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns-" + name},
+		Status: clusterapi.ManagedClusterStatus{
+			LastObservedTime: metav1.NewTime(lastObservedTime),
+			Requested: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse(requestedCPU),
+			},
+		},
+	}
+}
+
+func TestAssumeSubscription(t *testing.T) {
+	now := time.Now()
+	fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+	store := fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore()
+	for _, cluster := range []*clusterapi.ManagedCluster{
+		makeCluster("c1", now.Add(-time.Minute), "1"),
+		makeCluster("c2", now.Add(-time.Minute), "1"),
+	} {
+		if err := store.Add(cluster); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := New(fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Lister())
+
+	requests := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}
+	c.AssumeSubscription("default/sub1", map[string]corev1.ResourceList{"ns-c1/c1": requests})
+	c.AssumeSubscription("default/sub2", map[string]corev1.ResourceList{"ns-c1/c1": requests, "ns-c2/c2": requests})
+
+	expectRequestedCPU := func(namespacedName string, expected string) {
+		t.Helper()
+		cluster, err := c.Get(namespacedName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		requested := cluster.Status.Requested[corev1.ResourceCPU]
+		if requested.Cmp(resource.MustParse(expected)) != 0 {
+			t.Errorf("expected requested cpu of %s to be %s, got %s", namespacedName, expected, requested.String())
+		}
+	}
+	expectRequestedCPU("ns-c1/c1", "5")
+	expectRequestedCPU("ns-c2/c2", "3")
+
+	clusters, err := c.List(&metav1.LabelSelector{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cluster := range clusters {
+		if cluster.Name == "c1" {
+			requested := cluster.Status.Requested[corev1.ResourceCPU]
+			if requested.Cmp(resource.MustParse("5")) != 0 {
+				t.Errorf("expected listed requested cpu of c1 to be 5, got %s", requested.String())
+			}
+		}
+	}
+
+	// the clusters in the lister should never be modified
+	cluster, err := fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Lister().ManagedClusters("ns-c1").Get("c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requested := cluster.Status.Requested[corev1.ResourceCPU]; requested.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("expected the cluster in lister unchanged, got requested cpu %s", requested.String())
+	}
+
+	c.ForgetSubscription("default/sub1")
+	expectRequestedCPU("ns-c1/c1", "3")
+
+	// a heartbeat after the assumption expires it
+	if err = store.Update(makeCluster("c2", now.Add(time.Hour), "3")); err != nil {
+		t.Fatal(err)
+	}
+	expectRequestedCPU("ns-c2/c2", "3")
+	expectRequestedCPU("ns-c1/c1", "3")
+}
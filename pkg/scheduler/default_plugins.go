@@ -17,6 +17,9 @@ limitations under the License.
 package scheduler
 
 import (
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
 	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
 	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
 )
@@ -24,20 +27,54 @@ import (
 // getDefaultPlugins returns the default set of plugins.
 func getDefaultPlugins() *schedulerapis.Plugins {
 	return &schedulerapis.Plugins{
-		PreFilter: schedulerapis.PluginSet{},
+		PreFilter: schedulerapis.PluginSet{
+			Enabled: []schedulerapis.Plugin{
+				{Name: names.ClusterAffinity},
+				{Name: names.APICompatibility},
+				{Name: names.ClusterResourcesFit},
+				{Name: names.ClusterTopologySpread},
+			},
+		},
 		Filter: schedulerapis.PluginSet{
 			Enabled: []schedulerapis.Plugin{
+				{Name: names.ClusterReady},
+				{Name: names.ClusterAffinity},
+				{Name: names.APICompatibility},
 				{Name: names.TaintToleration},
+				{Name: names.ClusterResourcesFit},
+				{Name: names.ClusterTopologySpread},
 			},
 		},
 		PostFilter: schedulerapis.PluginSet{},
-		PreScore:   schedulerapis.PluginSet{},
+		PreScore: schedulerapis.PluginSet{
+			Enabled: []schedulerapis.Plugin{
+				{Name: names.ClusterAffinity},
+				{Name: names.ClusterResourcesLeastAllocated},
+				{Name: names.ClusterTopologySpread},
+				{Name: names.CostScore},
+			},
+		},
 		Score: schedulerapis.PluginSet{
 			Enabled: []schedulerapis.Plugin{
 				{Name: names.TaintToleration, Weight: 3},
+				{Name: names.ClusterResourcesLeastAllocated, Weight: 1},
+				{Name: names.ClusterTopologySpread, Weight: 2},
+				{Name: names.ClusterAffinity, Weight: 2},
+				{Name: names.StickyBinding, Weight: 5},
+				{Name: names.CostScore, Weight: 1},
+			},
+		},
+		Assign: schedulerapis.PluginSet{
+			Enabled: []schedulerapis.Plugin{
+				{Name: names.StaticAssigner},
+				{Name: names.DynamicAssigner},
+			},
+		},
+		Reserve: schedulerapis.PluginSet{
+			Enabled: []schedulerapis.Plugin{
+				{Name: names.ClusterResourcesReservation},
 			},
 		},
-		Reserve: schedulerapis.PluginSet{},
 		Permit:  schedulerapis.PluginSet{},
 		PreBind: schedulerapis.PluginSet{},
 		Bind: schedulerapis.PluginSet{
@@ -48,3 +85,69 @@ func getDefaultPlugins() *schedulerapis.Plugins {
 		PostBind: schedulerapis.PluginSet{},
 	}
 }
+
+// mergePlugins merges the custom plugins of a profile into the default plugins.
+func mergePlugins(defaultPlugins, customPlugins *schedulerapis.Plugins) *schedulerapis.Plugins {
+	if customPlugins == nil {
+		return defaultPlugins
+	}
+
+	defaultPlugins.PreFilter = mergePluginSet(defaultPlugins.PreFilter, customPlugins.PreFilter)
+	defaultPlugins.Filter = mergePluginSet(defaultPlugins.Filter, customPlugins.Filter)
+	defaultPlugins.PostFilter = mergePluginSet(defaultPlugins.PostFilter, customPlugins.PostFilter)
+	defaultPlugins.PreScore = mergePluginSet(defaultPlugins.PreScore, customPlugins.PreScore)
+	defaultPlugins.Score = mergePluginSet(defaultPlugins.Score, customPlugins.Score)
+	defaultPlugins.Assign = mergePluginSet(defaultPlugins.Assign, customPlugins.Assign)
+	defaultPlugins.Reserve = mergePluginSet(defaultPlugins.Reserve, customPlugins.Reserve)
+	defaultPlugins.Permit = mergePluginSet(defaultPlugins.Permit, customPlugins.Permit)
+	defaultPlugins.PreBind = mergePluginSet(defaultPlugins.PreBind, customPlugins.PreBind)
+	defaultPlugins.Bind = mergePluginSet(defaultPlugins.Bind, customPlugins.Bind)
+	defaultPlugins.PostBind = mergePluginSet(defaultPlugins.PostBind, customPlugins.PostBind)
+	return defaultPlugins
+}
+
+type pluginIndex struct {
+	index  int
+	plugin schedulerapis.Plugin
+}
+
+// mergePluginSet is copied from k8s.io/kubernetes/pkg/scheduler/apis/config/v1beta2/default_plugins.go and modified
+func mergePluginSet(defaultPluginSet, customPluginSet schedulerapis.PluginSet) schedulerapis.PluginSet {
+	disabledPlugins := sets.NewString()
+	enabledCustomPlugins := make(map[string]pluginIndex)
+	// replacedPluginIndex is a set of index of plugins, which have replaced the default plugins.
+	replacedPluginIndex := sets.NewInt()
+	for _, disabledPlugin := range customPluginSet.Disabled {
+		disabledPlugins.Insert(disabledPlugin.Name)
+	}
+	for index, enabledPlugin := range customPluginSet.Enabled {
+		enabledCustomPlugins[enabledPlugin.Name] = pluginIndex{index, enabledPlugin}
+	}
+
+	var enabledPlugins []schedulerapis.Plugin
+	if !disabledPlugins.Has("*") {
+		for _, defaultEnabledPlugin := range defaultPluginSet.Enabled {
+			if disabledPlugins.Has(defaultEnabledPlugin.Name) {
+				continue
+			}
+			// The default plugin is explicitly re-configured, update the default plugin accordingly.
+			if customPlugin, ok := enabledCustomPlugins[defaultEnabledPlugin.Name]; ok {
+				klog.InfoS("Default plugin is explicitly re-configured; overriding", "plugin", defaultEnabledPlugin.Name)
+				// Update the default plugin in place to preserve order.
+				defaultEnabledPlugin = customPlugin.plugin
+				replacedPluginIndex.Insert(customPlugin.index)
+			}
+			enabledPlugins = append(enabledPlugins, defaultEnabledPlugin)
+		}
+	}
+
+	// Append all the custom plugins which haven't replaced any default plugins.
+	// Note: duplicated custom plugins will still be appended here.
+	// If so, the instantiation of scheduler framework will detect it and abort.
+	for index, plugin := range customPluginSet.Enabled {
+		if !replacedPluginIndex.Has(index) {
+			enabledPlugins = append(enabledPlugins, plugin)
+		}
+	}
+	return schedulerapis.PluginSet{Enabled: enabledPlugins}
+}
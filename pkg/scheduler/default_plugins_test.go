@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+func TestMergePlugins(t *testing.T) {
+	tests := []struct {
+		name            string
+		customPlugins   *schedulerapis.Plugins
+		expectedFilter  schedulerapis.PluginSet
+		expectedScore   schedulerapis.PluginSet
+		expectedDefault bool
+	}{
+		{
+			name:            "no custom plugins",
+			customPlugins:   nil,
+			expectedDefault: true,
+		},
+		{
+			name: "disable some default plugins and enable custom ones",
+			customPlugins: &schedulerapis.Plugins{
+				Filter: schedulerapis.PluginSet{
+					Disabled: []schedulerapis.Plugin{{Name: names.ClusterResourcesFit}},
+				},
+				Score: schedulerapis.PluginSet{
+					Enabled: []schedulerapis.Plugin{
+						{Name: names.ClusterResourcesMostAllocated, Weight: 2},
+					},
+					Disabled: []schedulerapis.Plugin{{Name: names.ClusterResourcesLeastAllocated}},
+				},
+			},
+			expectedFilter: schedulerapis.PluginSet{
+				Enabled: []schedulerapis.Plugin{
+					{Name: names.ClusterReady},
+					{Name: names.ClusterAffinity},
+					{Name: names.APICompatibility},
+					{Name: names.TaintToleration},
+					{Name: names.ClusterTopologySpread},
+				},
+			},
+			expectedScore: schedulerapis.PluginSet{
+				Enabled: []schedulerapis.Plugin{
+					{Name: names.TaintToleration, Weight: 3},
+					{Name: names.ClusterTopologySpread, Weight: 2},
+					{Name: names.ClusterAffinity, Weight: 2},
+					{Name: names.StickyBinding, Weight: 5},
+					{Name: names.CostScore, Weight: 1},
+					{Name: names.ClusterResourcesMostAllocated, Weight: 2},
+				},
+			},
+		},
+		{
+			name: "disable all default plugins with '*'",
+			customPlugins: &schedulerapis.Plugins{
+				Filter: schedulerapis.PluginSet{
+					Enabled: []schedulerapis.Plugin{
+						{Name: names.ClusterResourcesFit},
+						{Name: names.ClusterReady},
+					},
+					Disabled: []schedulerapis.Plugin{{Name: "*"}},
+				},
+				Score: schedulerapis.PluginSet{
+					Disabled: []schedulerapis.Plugin{{Name: "*"}},
+				},
+			},
+			expectedFilter: schedulerapis.PluginSet{
+				Enabled: []schedulerapis.Plugin{
+					{Name: names.ClusterResourcesFit},
+					{Name: names.ClusterReady},
+				},
+			},
+			expectedScore: schedulerapis.PluginSet{},
+		},
+		{
+			name: "re-configure the weight of a default plugin",
+			customPlugins: &schedulerapis.Plugins{
+				Score: schedulerapis.PluginSet{
+					Enabled: []schedulerapis.Plugin{
+						{Name: names.ClusterResourcesLeastAllocated, Weight: 5},
+					},
+				},
+			},
+			expectedFilter: getDefaultPlugins().Filter,
+			expectedScore: schedulerapis.PluginSet{
+				Enabled: []schedulerapis.Plugin{
+					{Name: names.TaintToleration, Weight: 3},
+					{Name: names.ClusterResourcesLeastAllocated, Weight: 5},
+					{Name: names.ClusterTopologySpread, Weight: 2},
+					{Name: names.ClusterAffinity, Weight: 2},
+					{Name: names.StickyBinding, Weight: 5},
+					{Name: names.CostScore, Weight: 1},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergePlugins(getDefaultPlugins(), tt.customPlugins)
+			if tt.expectedDefault {
+				if !reflect.DeepEqual(got, getDefaultPlugins()) {
+					t.Errorf("expected default plugins, got %+v", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got.Filter, tt.expectedFilter) {
+				t.Errorf("expected filter plugins %+v, got %+v", tt.expectedFilter, got.Filter)
+			}
+			if !reflect.DeepEqual(got.Score, tt.expectedScore) {
+				t.Errorf("expected score plugins %+v, got %+v", tt.expectedScore, got.Score)
+			}
+			if !reflect.DeepEqual(got.Bind, getDefaultPlugins().Bind) {
+				t.Errorf("expected default bind plugins, got %+v", got.Bind)
+			}
+		})
+	}
+}
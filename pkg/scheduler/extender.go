@@ -0,0 +1,263 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/extender.go and modified
+
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	"github.com/clusternet/clusternet/pkg/scheduler/apis/v1alpha1"
+	extenderv1alpha1 "github.com/clusternet/clusternet/pkg/scheduler/extender/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+// HTTPExtender implements the Extender interface.
+type HTTPExtender struct {
+	extenderURL    string
+	filterVerb     string
+	prioritizeVerb string
+	bindVerb       string
+	weight         int64
+	client         *http.Client
+	ignorable      bool
+}
+
+func makeTransport(config *schedulerapis.Extender) (http.RoundTripper, error) {
+	var cfg restclient.Config
+	if config.TLSConfig != nil {
+		cfg.TLSClientConfig.Insecure = config.TLSConfig.Insecure
+		cfg.TLSClientConfig.ServerName = config.TLSConfig.ServerName
+		cfg.TLSClientConfig.CertFile = config.TLSConfig.CertFile
+		cfg.TLSClientConfig.KeyFile = config.TLSConfig.KeyFile
+		cfg.TLSClientConfig.CAFile = config.TLSConfig.CAFile
+		cfg.TLSClientConfig.CertData = config.TLSConfig.CertData
+		cfg.TLSClientConfig.KeyData = config.TLSConfig.KeyData
+		cfg.TLSClientConfig.CAData = config.TLSConfig.CAData
+	}
+	// the system root CAs are used to verify the extender if no CA is specified,
+	// verification is skipped only if TLSConfig.Insecure is set explicitly
+	tlsConfig, err := restclient.TLSConfigFor(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		return utilnet.SetTransportDefaults(&http.Transport{
+			TLSClientConfig: tlsConfig,
+		}), nil
+	}
+	return utilnet.SetTransportDefaults(&http.Transport{}), nil
+}
+
+// NewHTTPExtender creates an HTTPExtender object.
+func NewHTTPExtender(config *schedulerapis.Extender) (framework.Extender, error) {
+	timeout := config.HTTPTimeout.Duration
+	if timeout == 0 {
+		timeout = v1alpha1.DefaultExtenderTimeout
+	}
+
+	transport, err := makeTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+	return &HTTPExtender{
+		extenderURL:    config.URLPrefix,
+		filterVerb:     config.FilterVerb,
+		prioritizeVerb: config.PrioritizeVerb,
+		bindVerb:       config.BindVerb,
+		weight:         config.Weight,
+		client:         client,
+		ignorable:      config.Ignorable,
+	}, nil
+}
+
+// buildExtenders creates the HTTP extenders from given configuration.
+func buildExtenders(configs []schedulerapis.Extender) ([]framework.Extender, error) {
+	var extenders []framework.Extender
+	for i := range configs {
+		extender, err := NewHTTPExtender(&configs[i])
+		if err != nil {
+			return nil, err
+		}
+		extenders = append(extenders, extender)
+	}
+	return extenders, nil
+}
+
+// Name returns extenderURL to identify the extender.
+func (h *HTTPExtender) Name() string {
+	return h.extenderURL
+}
+
+// IsIgnorable returns true indicates scheduling should not fail when this extender
+// is unavailable
+func (h *HTTPExtender) IsIgnorable() bool {
+	return h.ignorable
+}
+
+// IsBinder returns whether this extender is configured for the Bind method.
+func (h *HTTPExtender) IsBinder() bool {
+	return h.bindVerb != ""
+}
+
+// Filter based on extender implemented predicate functions. The filtered list is
+// expected to be a subset of the supplied list; otherwise the function returns an error.
+// The failedClusters and failedAndUnresolvableClusters optionally contains the list
+// of failed clusters and failure reasons, except clusters in the latter are
+// unresolvable.
+func (h *HTTPExtender) Filter(sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) ([]*clusterapi.ManagedCluster,
+	extenderv1alpha1.FailedClustersMap, extenderv1alpha1.FailedClustersMap, error) {
+	if h.filterVerb == "" {
+		return clusters, extenderv1alpha1.FailedClustersMap{}, extenderv1alpha1.FailedClustersMap{}, nil
+	}
+
+	fromClusterName := make(map[string]*clusterapi.ManagedCluster, len(clusters))
+	clusterList := &clusterapi.ManagedClusterList{}
+	for _, cluster := range clusters {
+		fromClusterName[klog.KObj(cluster).String()] = cluster
+		clusterList.Items = append(clusterList.Items, *cluster)
+	}
+
+	args := &extenderv1alpha1.ExtenderArgs{
+		Subscription: sub,
+		Clusters:     clusterList,
+	}
+	var result extenderv1alpha1.ExtenderFilterResult
+	if err := h.send(h.filterVerb, args, &result); err != nil {
+		return nil, nil, nil, err
+	}
+	if result.Error != "" {
+		return nil, nil, nil, errors.New(result.Error)
+	}
+
+	var clusterResult []*clusterapi.ManagedCluster
+	if result.ClusterNames != nil {
+		clusterResult = make([]*clusterapi.ManagedCluster, len(*result.ClusterNames))
+		for i, clusterName := range *result.ClusterNames {
+			if cluster, ok := fromClusterName[clusterName]; ok {
+				clusterResult[i] = cluster
+			} else {
+				return nil, nil, nil, fmt.Errorf(
+					"extender %q claims a filtered cluster %q which is not found in the input cluster list",
+					h.extenderURL, clusterName)
+			}
+		}
+	} else if result.Clusters != nil {
+		clusterResult = make([]*clusterapi.ManagedCluster, len(result.Clusters.Items))
+		for i := range result.Clusters.Items {
+			clusterName := klog.KObj(&result.Clusters.Items[i]).String()
+			cluster, ok := fromClusterName[clusterName]
+			if !ok {
+				return nil, nil, nil, fmt.Errorf(
+					"extender %q claims a filtered cluster %q which is not found in the input cluster list",
+					h.extenderURL, clusterName)
+			}
+			clusterResult[i] = cluster
+		}
+	}
+
+	return clusterResult, result.FailedClusters, result.FailedAndUnresolvableClusters, nil
+}
+
+// Prioritize based on extender implemented priority functions. Weight*priority is added
+// up for each such priority function. The returned score is added to the score computed
+// by clusternet scheduler. The total score is used to do the cluster selection.
+func (h *HTTPExtender) Prioritize(sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (*extenderv1alpha1.ClusterPriorityList, int64, error) {
+	if h.prioritizeVerb == "" {
+		result := extenderv1alpha1.ClusterPriorityList{}
+		for _, cluster := range clusters {
+			result = append(result, extenderv1alpha1.ClusterPriority{Cluster: klog.KObj(cluster).String(), Score: 0})
+		}
+		return &result, 0, nil
+	}
+
+	clusterList := &clusterapi.ManagedClusterList{}
+	for _, cluster := range clusters {
+		clusterList.Items = append(clusterList.Items, *cluster)
+	}
+
+	args := &extenderv1alpha1.ExtenderArgs{
+		Subscription: sub,
+		Clusters:     clusterList,
+	}
+	var result extenderv1alpha1.ClusterPriorityList
+	if err := h.send(h.prioritizeVerb, args, &result); err != nil {
+		return nil, 0, err
+	}
+	return &result, h.weight, nil
+}
+
+// Bind delegates the action of binding a subscription to clusters to the extender.
+func (h *HTTPExtender) Bind(binding *extenderv1alpha1.ExtenderBindingArgs) error {
+	var result extenderv1alpha1.ExtenderBindingResult
+	if !h.IsBinder() {
+		// This shouldn't happen as this extender wouldn't have become a Binder.
+		return fmt.Errorf("unexpected empty bindVerb in extender %q", h.extenderURL)
+	}
+	if err := h.send(h.bindVerb, binding, &result); err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return errors.New(result.Error)
+	}
+	return nil
+}
+
+// Helper function to send messages to the extender
+func (h *HTTPExtender) send(action string, args interface{}, result interface{}) error {
+	out, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimRight(h.extenderURL, "/") + "/" + action
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed %v with extender at URL %v, code %v", action, url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kube-scheduler/extender/v1/types.go and modified
+
+// Package v1alpha1 contains the types exchanged between clusternet-scheduler and the HTTP extenders.
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+)
+
+const (
+	// MinExtenderPriority defines the min priority value for extender.
+	MinExtenderPriority int64 = 0
+
+	// MaxExtenderPriority defines the max priority value for extender.
+	MaxExtenderPriority int64 = 10
+)
+
+// ExtenderArgs represents the arguments needed by the extender to filter/prioritize
+// clusters for a subscription.
+type ExtenderArgs struct {
+	// Subscription being scheduled
+	Subscription *appsapi.Subscription `json:"subscription"`
+	// List of candidate clusters where the subscription can be scheduled
+	Clusters *clusterapi.ManagedClusterList `json:"clusters,omitempty"`
+}
+
+// FailedClustersMap represents the filtered out clusters, with namespaced names of clusters as keys
+// and failure messages as values
+type FailedClustersMap map[string]string
+
+// ExtenderFilterResult represents the results of a filter call to an extender
+type ExtenderFilterResult struct {
+	// Filtered set of clusters where the subscription can be scheduled.
+	// Either Clusters or ClusterNames should be set.
+	Clusters *clusterapi.ManagedClusterList `json:"clusters,omitempty"`
+	// Filtered set of namespaced names of clusters where the subscription can be scheduled.
+	// Either Clusters or ClusterNames should be set.
+	ClusterNames *[]string `json:"clusterNames,omitempty"`
+	// Filtered out clusters where the subscription can't be scheduled and the failure messages
+	FailedClusters FailedClustersMap `json:"failedClusters,omitempty"`
+	// Filtered out clusters where the subscription can't be scheduled and preemption would
+	// not change anything. The value is the failure message same as FailedClusters.
+	// Clusters specified here takes precedence over FailedClusters.
+	FailedAndUnresolvableClusters FailedClustersMap `json:"failedAndUnresolvableClusters,omitempty"`
+	// Error message indicating failure
+	Error string `json:"error,omitempty"`
+}
+
+// ClusterPriority represents the priority of scheduling to a particular cluster, higher priority is better.
+type ClusterPriority struct {
+	// Namespaced name of the cluster
+	Cluster string `json:"cluster"`
+	// Score associated with the cluster
+	Score int64 `json:"score"`
+}
+
+// ClusterPriorityList declares a []ClusterPriority type.
+type ClusterPriorityList []ClusterPriority
+
+// ExtenderBindingArgs represents the arguments to an extender for binding a subscription to clusters.
+type ExtenderBindingArgs struct {
+	// SubscriptionName is the name of the subscription being bound
+	SubscriptionName string `json:"subscriptionName"`
+	// SubscriptionNamespace is the namespace of the subscription being bound
+	SubscriptionNamespace string `json:"subscriptionNamespace"`
+	// SubscriptionUID is the UID of the subscription being bound
+	SubscriptionUID types.UID `json:"subscriptionUID"`
+	// BindingClusters are the namespaced names of the clusters selected by the scheduler
+	BindingClusters []string `json:"bindingClusters"`
+	// Replicas are the desired replicas of the selected clusters for each feed, which is indexed by the feed key
+	Replicas map[string][]int32 `json:"replicas,omitempty"`
+}
+
+// ExtenderBindingResult represents the result of binding of a subscription to clusters from an extender.
+type ExtenderBindingResult struct {
+	// Error message indicating failure
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,441 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	"github.com/clusternet/clusternet/pkg/scheduler/algorithm"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	schedulercache "github.com/clusternet/clusternet/pkg/scheduler/cache"
+	extenderv1alpha1 "github.com/clusternet/clusternet/pkg/scheduler/extender/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func makeExtenderCluster(name string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns-" + name,
+		},
+	}
+}
+
+// fakeExtenderServer returns a handler that rejects cluster "c2", regards cluster "c3" as unresolvable,
+// scores each cluster with its index in the cluster list and records the binding args.
+func fakeExtenderServer(t *testing.T, useClusterNames bool, binding *extenderv1alpha1.ExtenderBindingArgs) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/filter", func(w http.ResponseWriter, r *http.Request) {
+		var args extenderv1alpha1.ExtenderArgs
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			t.Errorf("failed to decode filter args: %v", err)
+		}
+		result := extenderv1alpha1.ExtenderFilterResult{
+			FailedClusters:                extenderv1alpha1.FailedClustersMap{},
+			FailedAndUnresolvableClusters: extenderv1alpha1.FailedClustersMap{},
+		}
+		var clusterNames []string
+		clusterList := &clusterapi.ManagedClusterList{}
+		for _, cluster := range args.Clusters.Items {
+			switch cluster.Name {
+			case "c2":
+				result.FailedClusters[klog.KObj(&cluster).String()] = "not in compliance zone"
+			case "c3":
+				result.FailedAndUnresolvableClusters[klog.KObj(&cluster).String()] = "out of quota"
+			default:
+				clusterNames = append(clusterNames, klog.KObj(&cluster).String())
+				clusterList.Items = append(clusterList.Items, cluster)
+			}
+		}
+		if useClusterNames {
+			result.ClusterNames = &clusterNames
+		} else {
+			result.Clusters = clusterList
+		}
+		_ = json.NewEncoder(w).Encode(&result)
+	})
+	mux.HandleFunc("/prioritize", func(w http.ResponseWriter, r *http.Request) {
+		var args extenderv1alpha1.ExtenderArgs
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			t.Errorf("failed to decode prioritize args: %v", err)
+		}
+		result := extenderv1alpha1.ClusterPriorityList{}
+		for i, cluster := range args.Clusters.Items {
+			result = append(result, extenderv1alpha1.ClusterPriority{Cluster: klog.KObj(&cluster).String(), Score: int64(i)})
+		}
+		_ = json.NewEncoder(w).Encode(&result)
+	})
+	mux.HandleFunc("/bind", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(binding); err != nil {
+			t.Errorf("failed to decode binding args: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(&extenderv1alpha1.ExtenderBindingResult{})
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(&extenderv1alpha1.ExtenderFilterResult{Error: "internal error"})
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Second)
+		_ = json.NewEncoder(w).Encode(&extenderv1alpha1.ClusterPriorityList{})
+	})
+	return mux
+}
+
+func TestHTTPExtenderFilter(t *testing.T) {
+	clusters := []*clusterapi.ManagedCluster{
+		makeExtenderCluster("c1"),
+		makeExtenderCluster("c2"),
+		makeExtenderCluster("c3"),
+		makeExtenderCluster("c4"),
+	}
+	sub := &appsapi.Subscription{ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"}}
+
+	tests := []struct {
+		name                          string
+		useClusterNames               bool
+		filterVerb                    string
+		expectedClusters              []*clusterapi.ManagedCluster
+		expectedFailed                extenderv1alpha1.FailedClustersMap
+		expectedFailedAndUnresolvable extenderv1alpha1.FailedClustersMap
+		expectErr                     bool
+	}{
+		{
+			name:                          "extender returns cluster list",
+			filterVerb:                    "filter",
+			expectedClusters:              []*clusterapi.ManagedCluster{clusters[0], clusters[3]},
+			expectedFailed:                extenderv1alpha1.FailedClustersMap{"ns-c2/c2": "not in compliance zone"},
+			expectedFailedAndUnresolvable: extenderv1alpha1.FailedClustersMap{"ns-c3/c3": "out of quota"},
+		},
+		{
+			name:                          "extender returns cluster names",
+			useClusterNames:               true,
+			filterVerb:                    "filter",
+			expectedClusters:              []*clusterapi.ManagedCluster{clusters[0], clusters[3]},
+			expectedFailed:                extenderv1alpha1.FailedClustersMap{"ns-c2/c2": "not in compliance zone"},
+			expectedFailedAndUnresolvable: extenderv1alpha1.FailedClustersMap{"ns-c3/c3": "out of quota"},
+		},
+		{
+			name:                          "extender does not support filter",
+			filterVerb:                    "",
+			expectedClusters:              clusters,
+			expectedFailed:                extenderv1alpha1.FailedClustersMap{},
+			expectedFailedAndUnresolvable: extenderv1alpha1.FailedClustersMap{},
+		},
+		{
+			name:       "extender returns an error",
+			filterVerb: "error",
+			expectErr:  true,
+		},
+		{
+			name:       "extender does not serve the verb",
+			filterVerb: "unknown",
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(fakeExtenderServer(t, tt.useClusterNames, nil))
+			defer server.Close()
+
+			extender, err := NewHTTPExtender(&schedulerapis.Extender{
+				URLPrefix:  server.URL,
+				FilterVerb: tt.filterVerb,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, failed, failedAndUnresolvable, err := extender.Filter(sub, clusters)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Filter() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.expectedClusters) {
+				t.Errorf("expected clusters %v, got %v", tt.expectedClusters, got)
+			}
+			if !reflect.DeepEqual(failed, tt.expectedFailed) {
+				t.Errorf("expected failed clusters %v, got %v", tt.expectedFailed, failed)
+			}
+			if !reflect.DeepEqual(failedAndUnresolvable, tt.expectedFailedAndUnresolvable) {
+				t.Errorf("expected failed and unresolvable clusters %v, got %v", tt.expectedFailedAndUnresolvable, failedAndUnresolvable)
+			}
+		})
+	}
+}
+
+func TestHTTPExtenderFilterTimeout(t *testing.T) {
+	server := httptest.NewServer(fakeExtenderServer(t, false, nil))
+	defer server.Close()
+
+	informerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+	if err := informerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(makeExtenderCluster("c1")); err != nil {
+		t.Fatal(err)
+	}
+	fwk, err := frameworkruntime.NewFramework(nil, nil, frameworkruntime.WithInformerFactory(informerFactory))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+		Spec: appsapi.SubscriptionSpec{
+			Subscribers: []appsapi.Subscriber{{ClusterAffinity: &metav1.LabelSelector{}}},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		ignorable        bool
+		expectedClusters []string
+		expectErr        bool
+	}{
+		{
+			name:             "ignorable extender times out",
+			ignorable:        true,
+			expectedClusters: []string{"ns-c1/c1"},
+		},
+		{
+			name:      "non-ignorable extender times out",
+			ignorable: false,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extender, err := NewHTTPExtender(&schedulerapis.Extender{
+				URLPrefix:   server.URL,
+				FilterVerb:  "slow",
+				HTTPTimeout: metav1.Duration{Duration: 100 * time.Millisecond},
+				Ignorable:   tt.ignorable,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			scheduleAlgorithm := algorithm.NewGenericScheduler(
+				schedulercache.New(informerFactory.Clusters().V1beta1().ManagedClusters().Lister()),
+				[]framework.Extender{extender},
+			)
+			result, err := scheduleAlgorithm.Schedule(context.Background(), fwk, framework.NewCycleState(), sub)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Schedule() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if !reflect.DeepEqual(result.SuggestedClusters.BindingClusters, tt.expectedClusters) {
+				t.Errorf("expected clusters %v, got %v", tt.expectedClusters, result.SuggestedClusters.BindingClusters)
+			}
+		})
+	}
+}
+
+func TestHTTPExtenderPrioritize(t *testing.T) {
+	clusters := []*clusterapi.ManagedCluster{
+		makeExtenderCluster("c1"),
+		makeExtenderCluster("c2"),
+	}
+	sub := &appsapi.Subscription{ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"}}
+
+	tests := []struct {
+		name           string
+		prioritizeVerb string
+		weight         int64
+		timeout        time.Duration
+		expectedList   *extenderv1alpha1.ClusterPriorityList
+		expectedWeight int64
+		expectErr      bool
+	}{
+		{
+			name:           "extender prioritizes clusters",
+			prioritizeVerb: "prioritize",
+			weight:         3,
+			expectedList: &extenderv1alpha1.ClusterPriorityList{
+				{Cluster: "ns-c1/c1", Score: 0},
+				{Cluster: "ns-c2/c2", Score: 1},
+			},
+			expectedWeight: 3,
+		},
+		{
+			name:           "extender does not support prioritize",
+			prioritizeVerb: "",
+			weight:         3,
+			expectedList: &extenderv1alpha1.ClusterPriorityList{
+				{Cluster: "ns-c1/c1", Score: 0},
+				{Cluster: "ns-c2/c2", Score: 0},
+			},
+			expectedWeight: 0,
+		},
+		{
+			name:           "extender times out",
+			prioritizeVerb: "slow",
+			weight:         3,
+			timeout:        100 * time.Millisecond,
+			expectErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(fakeExtenderServer(t, false, nil))
+			defer server.Close()
+
+			extender, err := NewHTTPExtender(&schedulerapis.Extender{
+				URLPrefix:      server.URL,
+				PrioritizeVerb: tt.prioritizeVerb,
+				Weight:         tt.weight,
+				HTTPTimeout:    metav1.Duration{Duration: tt.timeout},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, weight, err := extender.Prioritize(sub, clusters)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("Prioritize() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.expectedList) {
+				t.Errorf("expected priority list %v, got %v", tt.expectedList, got)
+			}
+			if weight != tt.expectedWeight {
+				t.Errorf("expected weight %d, got %d", tt.expectedWeight, weight)
+			}
+		})
+	}
+}
+
+func TestHTTPExtenderBind(t *testing.T) {
+	binding := &extenderv1alpha1.ExtenderBindingArgs{}
+	server := httptest.NewServer(fakeExtenderServer(t, false, binding))
+	defer server.Close()
+
+	extender, err := NewHTTPExtender(&schedulerapis.Extender{
+		URLPrefix: server.URL,
+		BindVerb:  "bind",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !extender.IsBinder() {
+		t.Fatalf("extender with a bind verb should be a binder")
+	}
+
+	expected := &extenderv1alpha1.ExtenderBindingArgs{
+		SubscriptionName:      "sub",
+		SubscriptionNamespace: "default",
+		SubscriptionUID:       "uid",
+		BindingClusters:       []string{"ns-c1/c1", "ns-c2/c2"},
+		Replicas: map[string][]int32{
+			"apps/v1/Deployment/default/nginx": {1, 2},
+		},
+	}
+	if err = extender.Bind(expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(binding, expected) {
+		t.Errorf("expected binding args %v, got %v", expected, binding)
+	}
+}
+
+func TestHTTPExtenderTLS(t *testing.T) {
+	server := httptest.NewTLSServer(fakeExtenderServer(t, false, nil))
+	defer server.Close()
+
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	clusters := []*clusterapi.ManagedCluster{makeExtenderCluster("c1")}
+	sub := &appsapi.Subscription{ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"}}
+
+	tests := []struct {
+		name      string
+		config    *schedulerapis.Extender
+		expectErr bool
+	}{
+		{
+			name: "https without a CA verifies the server certificate with system roots",
+			config: &schedulerapis.Extender{
+				URLPrefix:   server.URL,
+				FilterVerb:  "filter",
+				EnableHTTPS: true,
+			},
+			expectErr: true,
+		},
+		{
+			name: "https skips verifying the server certificate explicitly",
+			config: &schedulerapis.Extender{
+				URLPrefix:   server.URL,
+				FilterVerb:  "filter",
+				EnableHTTPS: true,
+				TLSConfig: &schedulerapis.ExtenderTLSConfig{
+					Insecure: true,
+				},
+			},
+		},
+		{
+			name: "https with a trusted CA",
+			config: &schedulerapis.Extender{
+				URLPrefix:   server.URL,
+				FilterVerb:  "filter",
+				EnableHTTPS: true,
+				TLSConfig: &schedulerapis.ExtenderTLSConfig{
+					CAData: caData,
+				},
+			},
+		},
+		{
+			name: "https with an untrusted server certificate",
+			config: &schedulerapis.Extender{
+				URLPrefix:  server.URL,
+				FilterVerb: "filter",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extender, err := NewHTTPExtender(tt.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, _, _, err = extender.Filter(sub, clusters)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("Filter() error = %v, expectErr %v", err, tt.expectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failover
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	clusterinformers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions/clusters/v1beta1"
+	applisters "github.com/clusternet/clusternet/pkg/generated/listers/apps/v1alpha1"
+	clusterlisters "github.com/clusternet/clusternet/pkg/generated/listers/clusters/v1beta1"
+)
+
+// DefaultGracePeriod is the default period that a bound cluster may stay not ready,
+// before the subscriptions bound to it are rescheduled.
+const DefaultGracePeriod = 5 * time.Minute
+
+// Controller watches the readiness of ManagedClusters. Once a cluster has not been ready for longer than
+// the grace period, the subscriptions bound to it are re-queued, so that the scheduler could replace the lost
+// cluster. When the cluster recovers, these subscriptions are re-queued again.
+type Controller struct {
+	gracePeriod time.Duration
+	// migrateDividingReplicas indicates whether Dividing subscriptions are rescheduled as well,
+	// which migrates their replicas to the remaining clusters.
+	migrateDividingReplicas bool
+
+	// queue holds the keys of not ready clusters, which are checked after the grace period.
+	queue workqueue.DelayingInterface
+
+	clusterLister clusterlisters.ManagedClusterLister
+	subsLister    applisters.SubscriptionLister
+
+	// handlesSubscription returns whether the subscription is handled by the scheduler.
+	handlesSubscription func(sub *appsapi.Subscription) bool
+	// enqueueSubscription re-queues a subscription for scheduling.
+	enqueueSubscription func(key string)
+
+	lock sync.Mutex
+	// failedOver maps the key of a lost cluster to the keys of subscriptions rescheduled because of it.
+	failedOver map[string]sets.String
+}
+
+// NewController returns a new failover Controller.
+func NewController(gracePeriod time.Duration, migrateDividingReplicas bool,
+	clusterInformer clusterinformers.ManagedClusterInformer, subsLister applisters.SubscriptionLister,
+	handlesSubscription func(sub *appsapi.Subscription) bool, enqueueSubscription func(key string)) *Controller {
+	c := &Controller{
+		gracePeriod:             gracePeriod,
+		migrateDividingReplicas: migrateDividingReplicas,
+		queue:                   workqueue.NewNamedDelayingQueue("failover"),
+		clusterLister:           clusterInformer.Lister(),
+		subsLister:              subsLister,
+		handlesSubscription:     handlesSubscription,
+		enqueueSubscription:     enqueueSubscription,
+		failedOver:              make(map[string]sets.String),
+	}
+
+	clusterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addCluster,
+		UpdateFunc: c.updateCluster,
+	})
+	return c
+}
+
+// Run starts checking the not ready clusters until the context is done.
+func (c *Controller) Run(ctx context.Context) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	klog.Info("Starting failover controller")
+	defer klog.Info("Shutting down failover controller")
+
+	go wait.UntilWithContext(ctx, c.runWorker, time.Second)
+	<-ctx.Done()
+}
+
+func (c *Controller) addCluster(obj interface{}) {
+	mcls := obj.(*clusterapi.ManagedCluster)
+	if mcls.DeletionTimestamp != nil {
+		return
+	}
+
+	// clusters that are already not ready when the scheduler starts
+	if since, notReady := NotReadySince(mcls); notReady {
+		c.queue.AddAfter(klog.KObj(mcls).String(), c.gracePeriod-time.Since(since))
+	}
+}
+
+func (c *Controller) updateCluster(old, cur interface{}) {
+	oldMcls := old.(*clusterapi.ManagedCluster)
+	newMcls := cur.(*clusterapi.ManagedCluster)
+
+	if newMcls.DeletionTimestamp != nil {
+		return
+	}
+
+	_, wasNotReady := NotReadySince(oldMcls)
+	since, notReady := NotReadySince(newMcls)
+	switch {
+	case notReady && !wasNotReady:
+		klog.V(4).Infof("ManagedCluster %s becomes not ready, checking it after %v", klog.KObj(newMcls), c.gracePeriod)
+		c.queue.AddAfter(klog.KObj(newMcls).String(), c.gracePeriod-time.Since(since))
+	case !notReady && wasNotReady:
+		c.recover(klog.KObj(newMcls).String())
+	}
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextCluster() {
+	}
+}
+
+func (c *Controller) processNextCluster() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.checkCluster(key.(string)); err != nil {
+		utilruntime.HandleError(err)
+	}
+	return true
+}
+
+// checkCluster re-queues the subscriptions bound to the cluster, if it has not been ready for longer than
+// the grace period.
+func (c *Controller) checkCluster(key string) error {
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return fmt.Errorf("invalid resource key: %s", key)
+	}
+	cluster, err := c.clusterLister.ManagedClusters(ns).Get(name)
+	if err != nil {
+		return err
+	}
+	if cluster.DeletionTimestamp != nil {
+		return nil
+	}
+
+	since, notReady := NotReadySince(cluster)
+	if !notReady {
+		return nil
+	}
+	if remaining := c.gracePeriod - time.Since(since); remaining > 0 {
+		c.queue.AddAfter(key, remaining)
+		return nil
+	}
+
+	subs, err := c.subsLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions: %v", err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, sub := range subs {
+		if sub.DeletionTimestamp != nil || !c.handlesSubscription(sub) || !sets.NewString(sub.Status.BindingClusters...).Has(key) {
+			continue
+		}
+		if sub.Spec.SchedulingStrategy == appsapi.DividingSchedulingStrategyType && !c.migrateDividingReplicas {
+			continue
+		}
+
+		klog.V(3).InfoS("Rescheduling subscription bound to a lost cluster", "subscription", klog.KObj(sub), "cluster", key)
+		if c.failedOver[key] == nil {
+			c.failedOver[key] = sets.NewString()
+		}
+		c.failedOver[key].Insert(klog.KObj(sub).String())
+		c.enqueueSubscription(klog.KObj(sub).String())
+	}
+	return nil
+}
+
+// recover re-queues the subscriptions that were rescheduled because of the cluster, so that they could be
+// scheduled to the recovered cluster again.
+func (c *Controller) recover(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, subKey := range c.failedOver[key].List() {
+		klog.V(3).InfoS("Rescheduling subscription as the lost cluster recovers", "subscription", subKey, "cluster", key)
+		c.enqueueSubscription(subKey)
+	}
+	delete(c.failedOver, key)
+}
+
+// NotReadySince returns since when the cluster has not been ready. It returns false if the cluster is ready,
+// or its readiness is never reported.
+func NotReadySince(cluster *clusterapi.ManagedCluster) (time.Time, bool) {
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, clusterapi.ClusterReady)
+	if condition == nil || condition.Status == metav1.ConditionTrue {
+		return time.Time{}, false
+	}
+	return condition.LastTransitionTime.Time, true
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failover
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+)
+
+func makeCluster(name string, status metav1.ConditionStatus, since time.Duration) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns-" + name},
+		Status: clusterapi.ManagedClusterStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               clusterapi.ClusterReady,
+					Status:             status,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+				},
+			},
+		},
+	}
+}
+
+func makeSubscription(name, schedulerName string, strategy appsapi.SchedulingStrategyType, bindingClusters ...string) *appsapi.Subscription {
+	return &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: appsapi.SubscriptionSpec{
+			SchedulerName:      schedulerName,
+			SchedulingStrategy: strategy,
+		},
+		Status: appsapi.SubscriptionStatus{BindingClusters: bindingClusters},
+	}
+}
+
+func TestCheckCluster(t *testing.T) {
+	subs := []*appsapi.Subscription{
+		makeSubscription("replication", "default", appsapi.ReplicaSchedulingStrategyType, "ns-c1/c1", "ns-c2/c2"),
+		makeSubscription("dividing", "default", appsapi.DividingSchedulingStrategyType, "ns-c1/c1"),
+		makeSubscription("other-cluster", "default", appsapi.ReplicaSchedulingStrategyType, "ns-c2/c2"),
+		makeSubscription("other-scheduler", "other", appsapi.ReplicaSchedulingStrategyType, "ns-c1/c1"),
+	}
+
+	tests := []struct {
+		name                    string
+		cluster                 *clusterapi.ManagedCluster
+		migrateDividingReplicas bool
+		expected                []string
+	}{
+		{
+			name:    "ready cluster",
+			cluster: makeCluster("c1", metav1.ConditionTrue, 10*time.Minute),
+		},
+		{
+			name:    "not ready cluster within the grace period",
+			cluster: makeCluster("c1", metav1.ConditionUnknown, time.Minute),
+		},
+		{
+			name:     "lost cluster",
+			cluster:  makeCluster("c1", metav1.ConditionUnknown, 10*time.Minute),
+			expected: []string{"default/replication"},
+		},
+		{
+			name:                    "lost cluster with replicas migrated",
+			cluster:                 makeCluster("c1", metav1.ConditionFalse, 10*time.Minute),
+			migrateDividingReplicas: true,
+			expected:                []string{"default/dividing", "default/replication"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			informerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+			if err := informerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(tt.cluster); err != nil {
+				t.Fatal(err)
+			}
+			for _, sub := range subs {
+				if err := informerFactory.Apps().V1alpha1().Subscriptions().Informer().GetStore().Add(sub); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var enqueued []string
+			c := NewController(5*time.Minute, tt.migrateDividingReplicas,
+				informerFactory.Clusters().V1beta1().ManagedClusters(),
+				informerFactory.Apps().V1alpha1().Subscriptions().Lister(),
+				func(sub *appsapi.Subscription) bool {
+					return sub.Spec.SchedulerName == "default"
+				},
+				func(key string) {
+					enqueued = append(enqueued, key)
+				},
+			)
+			defer c.queue.ShutDown()
+
+			if err := c.checkCluster("ns-c1/c1"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sort.Strings(enqueued)
+			if !reflect.DeepEqual(enqueued, tt.expected) {
+				t.Errorf("expected enqueued subscriptions %v, got %v", tt.expected, enqueued)
+			}
+
+			// the subscriptions are re-queued again when the cluster recovers
+			enqueued = nil
+			c.recover("ns-c1/c1")
+			sort.Strings(enqueued)
+			if !reflect.DeepEqual(enqueued, tt.expected) {
+				t.Errorf("expected enqueued subscriptions %v on recovery, got %v", tt.expected, enqueued)
+			}
+			if len(c.failedOver) != 0 {
+				t.Errorf("expected no failed over subscriptions after recovery, got %v", c.failedOver)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/framework/extender.go and modified
+
+package interfaces
+
+import (
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	extenderv1alpha1 "github.com/clusternet/clusternet/pkg/scheduler/extender/v1alpha1"
+)
+
+// Extender is an interface for external processes to influence scheduling
+// decisions made by clusternet. This is typically needed for resources not directly
+// managed by clusternet.
+type Extender interface {
+	// Name returns a unique name that identifies the extender.
+	Name() string
+
+	// Filter based on extender-implemented predicate functions. The filtered list is
+	// expected to be a subset of the supplied list.
+	// The failedClusters and failedAndUnresolvableClusters optionally contains the list
+	// of failed clusters and failure reasons, except clusters in the latter are
+	// unresolvable.
+	Filter(sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (filteredClusters []*clusterapi.ManagedCluster,
+		failedClusters extenderv1alpha1.FailedClustersMap, failedAndUnresolvableClusters extenderv1alpha1.FailedClustersMap, err error)
+
+	// Prioritize based on extender-implemented priority functions. The returned scores & weight
+	// are used to compute the weighted score for an extender. The weighted scores are added to
+	// the scores computed by clusternet scheduler. The total scores are used to do the cluster selection.
+	Prioritize(sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (clusterPriorityList *extenderv1alpha1.ClusterPriorityList, weight int64, err error)
+
+	// Bind delegates the action of binding a subscription to clusters to the extender.
+	Bind(binding *extenderv1alpha1.ExtenderBindingArgs) error
+
+	// IsBinder returns whether this extender is configured for the Bind method.
+	IsBinder() bool
+
+	// IsIgnorable returns true indicates scheduling should not fail when this extender
+	// is unavailable. This gives scheduler ability to fail fast and tolerate non-critical extenders as well.
+	IsIgnorable() bool
+}
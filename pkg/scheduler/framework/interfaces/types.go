@@ -20,12 +20,52 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
 )
 
+// TargetClusters represents the scheduling result of a subscription.
+type TargetClusters struct {
+	// Namespaced names of targeted clusters that Subscription binds to.
+	BindingClusters []string
+
+	// Desired replicas of targeted clusters for each feed, which is indexed by the feed key.
+	// The indices of replicas are corresponding with BindingClusters.
+	Replicas map[string][]int32
+}
+
+// QueuedSubscriptionInfo is a Subscription wrapper with additional information related to
+// the subscription's status in the scheduling queue, such as the timestamp when it's added to the queue.
+type QueuedSubscriptionInfo struct {
+	Subscription *appsapi.Subscription
+	// The time subscription added to the scheduling queue.
+	Timestamp time.Time
+	// Number of schedule attempts before successfully scheduled.
+	// It's used to record the # attempts metric.
+	Attempts int
+	// The time when the subscription is added to the queue for the first time. The subscription may be added
+	// back to the queue multiple times before it's successfully scheduled.
+	// It shouldn't be updated once initialized. It's used to record the e2e scheduling
+	// latency for a subscription.
+	InitialAttemptTimestamp time.Time
+	// If a subscription failed in a scheduling cycle, record the plugin names it failed by.
+	UnschedulablePlugins sets.String
+}
+
+// DeepCopy returns a deep copy of the QueuedSubscriptionInfo object.
+func (sqi *QueuedSubscriptionInfo) DeepCopy() *QueuedSubscriptionInfo {
+	return &QueuedSubscriptionInfo{
+		Subscription:            sqi.Subscription.DeepCopy(),
+		Timestamp:               sqi.Timestamp,
+		Attempts:                sqi.Attempts,
+		InitialAttemptTimestamp: sqi.InitialAttemptTimestamp,
+		UnschedulablePlugins:    sets.NewString(sqi.UnschedulablePlugins.List()...),
+	}
+}
+
 // Diagnosis records the details to diagnose a scheduling failure.
 type Diagnosis struct {
 	ClusterToStatusMap   ClusterToStatusMap
@@ -36,12 +76,18 @@ type Diagnosis struct {
 type FitError struct {
 	Subscription   *appsapi.Subscription
 	NumAllClusters int
-	Diagnosis      Diagnosis
+	// NumFeasibleClusters is the number of clusters that fit the subscription.
+	NumFeasibleClusters int
+	// NumRequiredClusters is the minimum number of feasible clusters required by the subscription.
+	NumRequiredClusters int
+	Diagnosis           Diagnosis
 }
 
 const (
 	// NoClusterAvailableMsg is used to format message when no clusters available.
 	NoClusterAvailableMsg = "0/%v clusters are available"
+	// InsufficientClustersMsg is used to format message when fewer clusters than required are available.
+	InsufficientClustersMsg = "%v/%v clusters are available, while at least %v clusters are required"
 )
 
 // Error returns detailed information of why the subscription failed to fit on each cluster
@@ -61,6 +107,10 @@ func (f *FitError) Error() string {
 		sort.Strings(reasonStrings)
 		return reasonStrings
 	}
+	if f.NumFeasibleClusters > 0 && f.NumFeasibleClusters < f.NumRequiredClusters {
+		return fmt.Sprintf(InsufficientClustersMsg+": %v.", f.NumFeasibleClusters, f.NumAllClusters,
+			f.NumRequiredClusters, strings.Join(sortReasonsHistogram(), ", "))
+	}
 	reasonMsg := fmt.Sprintf(NoClusterAvailableMsg+": %v.", f.NumAllClusters, strings.Join(sortReasonsHistogram(), ", "))
 	return reasonMsg
 }
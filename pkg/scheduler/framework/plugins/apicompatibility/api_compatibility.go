@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apicompatibility
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+const (
+	// preFilterStateKey is the key in CycleState to APICompatibility pre-computed data for filtering.
+	preFilterStateKey = "PreFilter" + names.APICompatibility
+
+	// ErrReasonKubernetesVersionNotMatch is used when the Kubernetes version of a cluster doesn't satisfy the constraint.
+	ErrReasonKubernetesVersionNotMatch = "cluster(s) didn't match Subscription's Kubernetes version constraint"
+	// ErrReasonAPINotServed is used when a cluster doesn't serve the APIs of the feeds.
+	ErrReasonAPINotServed = "cluster(s) didn't serve the APIs of Subscription's feeds"
+)
+
+// crdKind is the kind of CustomResourceDefinitions, whose names are in the form of "<plural>.<group>".
+var crdKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+// APICompatibility is a plugin that checks if a cluster serves the APIs of the feeds of a subscription,
+// and satisfies the Kubernetes version constraint of the subscription.
+// Clusters not reporting their served APIs are not checked against the APIs of feeds.
+type APICompatibility struct {
+	handle framework.Handle
+}
+
+var _ framework.PreFilterPlugin = &APICompatibility{}
+var _ framework.FilterPlugin = &APICompatibility{}
+
+// New initializes a new plugin and returns it.
+func New(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &APICompatibility{handle: h}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *APICompatibility) Name() string {
+	return names.APICompatibility
+}
+
+// preFilterState holds the parsed version constraint and the kinds referenced by the feeds.
+type preFilterState struct {
+	versionConstraint *semver.Constraints
+	kinds             []schema.GroupVersionKind
+}
+
+// Clone the prefilter state.
+func (s *preFilterState) Clone() framework.StateData {
+	return s
+}
+
+// getRequiredKinds returns the kinds of feeds that have to be served by the clusters.
+// Feeds of Clusternet, such as HelmCharts, aren't deployed as they are, and the kinds
+// defined by the CustomResourceDefinitions in the same feeds won't be served until deployed,
+// so they are skipped.
+func getRequiredKinds(feeds []appsapi.Feed) ([]schema.GroupVersionKind, error) {
+	crdGroups := sets.NewString()
+	for _, feed := range feeds {
+		gv, err := schema.ParseGroupVersion(feed.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		if gv.WithKind(feed.Kind).GroupKind() != crdKind {
+			continue
+		}
+		if parts := strings.SplitN(feed.Name, ".", 2); len(parts) == 2 {
+			crdGroups.Insert(parts[1])
+		}
+	}
+
+	var kinds []schema.GroupVersionKind
+	for _, feed := range feeds {
+		gvk := schema.FromAPIVersionAndKind(feed.APIVersion, feed.Kind)
+		if gvk.Group == appsapi.SchemeGroupVersion.Group || crdGroups.Has(gvk.Group) {
+			continue
+		}
+		kinds = append(kinds, gvk)
+	}
+	return kinds, nil
+}
+
+// getClusterVersion returns the Kubernetes version of a cluster without pre-release and build metadata,
+// since a version like "v1.21.3-eks-6b7464" doesn't satisfy constraints like ">= 1.21.0" as a pre-release.
+func getClusterVersion(cluster *clusterapi.ManagedCluster) (*semver.Version, error) {
+	v, err := semver.NewVersion(cluster.Status.KubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+	return semver.NewVersion(fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch()))
+}
+
+// PreFilter invoked at the prefilter extension point.
+func (pl *APICompatibility) PreFilter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription) *framework.Status {
+	s := &preFilterState{}
+	if len(sub.Spec.KubernetesVersionConstraint) > 0 {
+		constraint, err := semver.NewConstraint(sub.Spec.KubernetesVersionConstraint)
+		if err != nil {
+			return framework.NewStatus(framework.UnschedulableAndUnresolvable,
+				fmt.Sprintf("invalid Kubernetes version constraint %q: %v", sub.Spec.KubernetesVersionConstraint, err))
+		}
+		s.versionConstraint = constraint
+	}
+
+	kinds, err := getRequiredKinds(sub.Spec.Feeds)
+	if err != nil {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, fmt.Sprintf("invalid feeds: %v", err))
+	}
+	s.kinds = kinds
+
+	state.Write(preFilterStateKey, s)
+	return nil
+}
+
+// Filter invoked at the filter extension point.
+func (pl *APICompatibility) Filter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) *framework.Status {
+	if cluster == nil {
+		return framework.AsStatus(fmt.Errorf("invalid cluster"))
+	}
+
+	c, err := state.Read(preFilterStateKey)
+	if err != nil {
+		return framework.AsStatus(fmt.Errorf("reading %q from cycleState: %w", preFilterStateKey, err))
+	}
+	s, ok := c.(*preFilterState)
+	if !ok {
+		return framework.AsStatus(fmt.Errorf("%+v cannot be converted to apicompatibility.preFilterState", c))
+	}
+
+	if s.versionConstraint != nil {
+		version, err := getClusterVersion(cluster)
+		if err != nil || !s.versionConstraint.Check(version) {
+			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonKubernetesVersionNotMatch)
+		}
+	}
+
+	if len(s.kinds) == 0 || len(cluster.Status.ServedAPIs) == 0 {
+		return nil
+	}
+	servedKinds := make(map[string]sets.String, len(cluster.Status.ServedAPIs))
+	for _, api := range cluster.Status.ServedAPIs {
+		servedKinds[api.GroupVersion] = sets.NewString(api.Kinds...)
+	}
+	for _, gvk := range s.kinds {
+		kinds, ok := servedKinds[gvk.GroupVersion().String()]
+		if !ok || !kinds.Has(gvk.Kind) {
+			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonAPINotServed)
+		}
+	}
+	return nil
+}
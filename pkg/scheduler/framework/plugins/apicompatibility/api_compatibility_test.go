@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apicompatibility
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+func makeCluster(version string, servedAPIs ...clusterapi.ServedAPIGroupVersion) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "ns-c1"},
+		Status: clusterapi.ManagedClusterStatus{
+			KubernetesVersion: version,
+			ServedAPIs:        servedAPIs,
+		},
+	}
+}
+
+func makeSubscription(versionConstraint string, feeds ...appsapi.Feed) *appsapi.Subscription {
+	return &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+		Spec: appsapi.SubscriptionSpec{
+			KubernetesVersionConstraint: versionConstraint,
+			Feeds:                       feeds,
+		},
+	}
+}
+
+func TestAPICompatibilityFilter(t *testing.T) {
+	servedAPIs := []clusterapi.ServedAPIGroupVersion{
+		{GroupVersion: "apps/v1", Kinds: []string{"Deployment", "StatefulSet"}},
+		{GroupVersion: "policy/v1beta1", Kinds: []string{"PodDisruptionBudget"}},
+		{GroupVersion: "v1", Kinds: []string{"ConfigMap", "Service"}},
+	}
+
+	tests := []struct {
+		name         string
+		subscription *appsapi.Subscription
+		cluster      *clusterapi.ManagedCluster
+		wantStatus   *framework.Status
+	}{
+		{
+			name:         "no version constraint and all the APIs are served",
+			subscription: makeSubscription("", appsapi.Feed{APIVersion: "apps/v1", Kind: "Deployment", Name: "foo"}),
+			cluster:      makeCluster("v1.20.4", servedAPIs...),
+		},
+		{
+			name:         "cluster version satisfies the constraint",
+			subscription: makeSubscription(">= 1.20.0"),
+			cluster:      makeCluster("v1.20.4-eks-6b7464"),
+		},
+		{
+			name:         "cluster version doesn't satisfy the constraint",
+			subscription: makeSubscription(">= 1.21.0"),
+			cluster:      makeCluster("v1.20.4"),
+			wantStatus:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonKubernetesVersionNotMatch),
+		},
+		{
+			name:         "cluster without a version doesn't satisfy the constraint",
+			subscription: makeSubscription(">= 1.21.0"),
+			cluster:      makeCluster(""),
+			wantStatus:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonKubernetesVersionNotMatch),
+		},
+		{
+			name:         "API group version not served",
+			subscription: makeSubscription("", appsapi.Feed{APIVersion: "policy/v1", Kind: "PodDisruptionBudget", Name: "foo"}),
+			cluster:      makeCluster("v1.20.4", servedAPIs...),
+			wantStatus:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonAPINotServed),
+		},
+		{
+			name:         "kind not served",
+			subscription: makeSubscription("", appsapi.Feed{APIVersion: "v1", Kind: "Secret", Name: "foo"}),
+			cluster:      makeCluster("v1.20.4", servedAPIs...),
+			wantStatus:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonAPINotServed),
+		},
+		{
+			name:         "cluster not reporting served APIs",
+			subscription: makeSubscription("", appsapi.Feed{APIVersion: "policy/v1", Kind: "PodDisruptionBudget", Name: "foo"}),
+			cluster:      makeCluster("v1.20.4"),
+		},
+		{
+			name: "kinds of Clusternet and CustomResourceDefinitions in feeds are skipped",
+			subscription: makeSubscription("",
+				appsapi.Feed{APIVersion: "apps.clusternet.io/v1alpha1", Kind: "HelmChart", Name: "foo"},
+				appsapi.Feed{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "foos.example.com"},
+				appsapi.Feed{APIVersion: "example.com/v1", Kind: "Foo", Name: "foo"},
+			),
+			cluster: makeCluster("v1.20.4", append(servedAPIs,
+				clusterapi.ServedAPIGroupVersion{GroupVersion: "apiextensions.k8s.io/v1", Kinds: []string{"CustomResourceDefinition"}})...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := New(nil, nil)
+			state := framework.NewCycleState()
+			if status := p.(framework.PreFilterPlugin).PreFilter(context.Background(), state, tt.subscription); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			gotStatus := p.(framework.FilterPlugin).Filter(context.Background(), state, tt.subscription, tt.cluster)
+			if !reflect.DeepEqual(gotStatus, tt.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAPICompatibilityPreFilterInvalidConstraint(t *testing.T) {
+	p, _ := New(nil, nil)
+	status := p.(framework.PreFilterPlugin).PreFilter(context.Background(), framework.NewCycleState(), makeSubscription("not a version"))
+	if status.Code() != framework.UnschedulableAndUnresolvable {
+		t.Errorf("expected status code %v, got %v", framework.UnschedulableAndUnresolvable, status.Code())
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteraffinity
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/helper"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+const (
+	// preFilterStateKey is the key in CycleState to ClusterAffinity pre-computed data for filtering.
+	preFilterStateKey = "PreFilter" + names.ClusterAffinity
+	// preScoreStateKey is the key in CycleState to ClusterAffinity pre-computed data for scoring.
+	preScoreStateKey = "PreScore" + names.ClusterAffinity
+
+	// ErrReasonClusterSelectorTermsNotMatch is used when a cluster doesn't match the cluster selector terms.
+	ErrReasonClusterSelectorTermsNotMatch = "cluster(s) didn't match Subscription's cluster affinity"
+)
+
+// ClusterAffinity is a plugin that checks if a cluster matches the cluster selector terms of subscribers,
+// and prefers the clusters matching the preferred cluster affinity of a subscription.
+type ClusterAffinity struct {
+	handle framework.Handle
+}
+
+var _ framework.PreFilterPlugin = &ClusterAffinity{}
+var _ framework.FilterPlugin = &ClusterAffinity{}
+var _ framework.PreScorePlugin = &ClusterAffinity{}
+var _ framework.ScorePlugin = &ClusterAffinity{}
+
+// New initializes a new plugin and returns it.
+func New(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &ClusterAffinity{handle: h}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *ClusterAffinity) Name() string {
+	return names.ClusterAffinity
+}
+
+// clusterSelectorTerm is the parsed form of an appsapi.ClusterSelectorTerm.
+type clusterSelectorTerm struct {
+	labelSelector labels.Selector
+	fieldSelector labels.Selector
+}
+
+// newClusterSelectorTerm parses a cluster selector term. It returns nil for an empty term.
+func newClusterSelectorTerm(term *appsapi.ClusterSelectorTerm) (*clusterSelectorTerm, error) {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return nil, nil
+	}
+	for _, req := range term.MatchFields {
+		if req.Key != appsapi.ClusterFieldName && req.Key != appsapi.ClusterFieldNamespace {
+			return nil, fmt.Errorf("unsupported field key %q in matchFields", req.Key)
+		}
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: term.MatchExpressions})
+	if err != nil {
+		return nil, err
+	}
+	fieldSelector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: term.MatchFields})
+	if err != nil {
+		return nil, err
+	}
+	return &clusterSelectorTerm{labelSelector: labelSelector, fieldSelector: fieldSelector}, nil
+}
+
+// match returns whether the cluster matches the term. An empty term matches no clusters.
+func (t *clusterSelectorTerm) match(cluster *clusterapi.ManagedCluster) bool {
+	if t == nil {
+		return false
+	}
+	fields := labels.Set{
+		appsapi.ClusterFieldName:      cluster.Name,
+		appsapi.ClusterFieldNamespace: cluster.Namespace,
+	}
+	return t.labelSelector.Matches(labels.Set(cluster.Labels)) && t.fieldSelector.Matches(fields)
+}
+
+// subscriberAffinity holds the parsed cluster affinity and cluster selector terms of a subscriber.
+type subscriberAffinity struct {
+	clusterAffinity labels.Selector
+	terms           []*clusterSelectorTerm
+}
+
+// preFilterState holds the parsed affinity of all the subscribers.
+type preFilterState struct {
+	subscribers []subscriberAffinity
+}
+
+// Clone the prefilter state.
+func (s *preFilterState) Clone() framework.StateData {
+	return s
+}
+
+// preferredTerm is a parsed weighted preference.
+type preferredTerm struct {
+	weight int64
+	term   *clusterSelectorTerm
+}
+
+// preScoreState holds the parsed preferred cluster affinity of the subscription.
+type preScoreState struct {
+	preferredTerms []preferredTerm
+}
+
+// Clone the prescore state.
+func (s *preScoreState) Clone() framework.StateData {
+	return s
+}
+
+// PreFilter invoked at the prefilter extension point. It parses the cluster selector terms of subscribers.
+func (pl *ClusterAffinity) PreFilter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription) *framework.Status {
+	s := &preFilterState{}
+	for _, subscriber := range sub.Spec.Subscribers {
+		selector, err := metav1.LabelSelectorAsSelector(subscriber.ClusterAffinity)
+		if err != nil {
+			return framework.AsStatus(fmt.Errorf("parsing cluster affinity: %w", err))
+		}
+		affinity := subscriberAffinity{clusterAffinity: selector}
+		for i := range subscriber.ClusterSelectorTerms {
+			term, err := newClusterSelectorTerm(&subscriber.ClusterSelectorTerms[i])
+			if err != nil {
+				return framework.AsStatus(fmt.Errorf("parsing cluster selector terms: %w", err))
+			}
+			affinity.terms = append(affinity.terms, term)
+		}
+		s.subscribers = append(s.subscribers, affinity)
+	}
+	state.Write(preFilterStateKey, s)
+	return nil
+}
+
+// Filter invoked at the filter extension point.
+// A cluster is feasible if it is selected by the cluster affinity of a subscriber, which either has no
+// cluster selector terms or has a term matching the cluster.
+func (pl *ClusterAffinity) Filter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) *framework.Status {
+	if cluster == nil {
+		return framework.AsStatus(fmt.Errorf("invalid cluster"))
+	}
+
+	c, err := state.Read(preFilterStateKey)
+	if err != nil {
+		return framework.AsStatus(fmt.Errorf("reading %q from cycleState: %w", preFilterStateKey, err))
+	}
+	s, ok := c.(*preFilterState)
+	if !ok {
+		return framework.AsStatus(fmt.Errorf("%+v cannot be converted to clusteraffinity.preFilterState", c))
+	}
+
+	for _, subscriber := range s.subscribers {
+		if !subscriber.clusterAffinity.Matches(labels.Set(cluster.Labels)) {
+			continue
+		}
+		if len(subscriber.terms) == 0 {
+			return nil
+		}
+		for _, term := range subscriber.terms {
+			if term.match(cluster) {
+				return nil
+			}
+		}
+	}
+	return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonClusterSelectorTermsNotMatch)
+}
+
+// PreScore invoked at the prescore extension point. It parses the preferred cluster affinity of the subscription.
+func (pl *ClusterAffinity) PreScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) *framework.Status {
+	s := &preScoreState{}
+	for i := range sub.Spec.PreferredClusterAffinity {
+		preference := &sub.Spec.PreferredClusterAffinity[i]
+		if preference.Weight == 0 {
+			continue
+		}
+		term, err := newClusterSelectorTerm(&preference.Preference)
+		if err != nil {
+			return framework.AsStatus(fmt.Errorf("parsing preferred cluster affinity: %w", err))
+		}
+		s.preferredTerms = append(s.preferredTerms, preferredTerm{weight: int64(preference.Weight), term: term})
+	}
+	state.Write(preScoreStateKey, s)
+	return nil
+}
+
+// Score invoked at the Score extension point.
+// The returned score is the sum of weights of the preferences matching the cluster.
+func (pl *ClusterAffinity) Score(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	c, err := state.Read(preScoreStateKey)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("reading %q from cycleState: %w", preScoreStateKey, err))
+	}
+	s, ok := c.(*preScoreState)
+	if !ok {
+		return 0, framework.AsStatus(fmt.Errorf("%+v cannot be converted to clusteraffinity.preScoreState", c))
+	}
+	if len(s.preferredTerms) == 0 {
+		return 0, nil
+	}
+
+	ns, name, err := cache.SplitMetaNamespaceKey(namespacedCluster)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("invalid resource key: %s", namespacedCluster))
+	}
+	cluster, err := pl.handle.SharedInformerFactory().Clusters().V1beta1().ManagedClusters().Lister().ManagedClusters(ns).Get(name)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("getting cluster %s: %v", namespacedCluster, err))
+	}
+
+	var score int64
+	for _, preference := range s.preferredTerms {
+		if preference.term.match(cluster) {
+			score += preference.weight
+		}
+	}
+	return score, nil
+}
+
+// NormalizeScore invoked after scoring all clusters.
+func (pl *ClusterAffinity) NormalizeScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, scores framework.ClusterScoreList) *framework.Status {
+	return helper.DefaultNormalizeScore(framework.MaxClusterScore, false, scores)
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *ClusterAffinity) ScoreExtensions() framework.ScoreExtensions {
+	return pl
+}
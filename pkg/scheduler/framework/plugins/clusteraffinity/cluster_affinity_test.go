@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusteraffinity
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func makeCluster(name string, labels map[string]string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns-" + name,
+			Labels:    labels,
+		},
+	}
+}
+
+func requirement(key string, operator metav1.LabelSelectorOperator, values ...string) metav1.LabelSelectorRequirement {
+	return metav1.LabelSelectorRequirement{Key: key, Operator: operator, Values: values}
+}
+
+func TestClusterAffinityFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		subscribers []appsapi.Subscriber
+		cluster     *clusterapi.ManagedCluster
+		wantStatus  *framework.Status
+	}{
+		{
+			name: "subscriber without cluster selector terms",
+			subscribers: []appsapi.Subscriber{
+				{ClusterAffinity: &metav1.LabelSelector{}},
+			},
+			cluster: makeCluster("c1", nil),
+		},
+		{
+			name: "cluster matches a term by labels",
+			subscribers: []appsapi.Subscriber{
+				{
+					ClusterAffinity: &metav1.LabelSelector{},
+					ClusterSelectorTerms: []appsapi.ClusterSelectorTerm{
+						{MatchExpressions: []metav1.LabelSelectorRequirement{requirement("region", metav1.LabelSelectorOpIn, "r2")}},
+						{MatchExpressions: []metav1.LabelSelectorRequirement{requirement("region", metav1.LabelSelectorOpIn, "r1")}},
+					},
+				},
+			},
+			cluster: makeCluster("c1", map[string]string{"region": "r1"}),
+		},
+		{
+			name: "cluster matches a term by fields",
+			subscribers: []appsapi.Subscriber{
+				{
+					ClusterAffinity: &metav1.LabelSelector{},
+					ClusterSelectorTerms: []appsapi.ClusterSelectorTerm{
+						{MatchFields: []metav1.LabelSelectorRequirement{requirement(appsapi.ClusterFieldName, metav1.LabelSelectorOpIn, "c1", "c2")}},
+					},
+				},
+			},
+			cluster: makeCluster("c1", nil),
+		},
+		{
+			name: "cluster doesn't match all the requirements of a term",
+			subscribers: []appsapi.Subscriber{
+				{
+					ClusterAffinity: &metav1.LabelSelector{},
+					ClusterSelectorTerms: []appsapi.ClusterSelectorTerm{
+						{
+							MatchExpressions: []metav1.LabelSelectorRequirement{requirement("region", metav1.LabelSelectorOpIn, "r1")},
+							MatchFields:      []metav1.LabelSelectorRequirement{requirement(appsapi.ClusterFieldNamespace, metav1.LabelSelectorOpNotIn, "ns-c1")},
+						},
+					},
+				},
+			},
+			cluster:    makeCluster("c1", map[string]string{"region": "r1"}),
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonClusterSelectorTermsNotMatch),
+		},
+		{
+			name: "empty term matches no clusters",
+			subscribers: []appsapi.Subscriber{
+				{
+					ClusterAffinity:      &metav1.LabelSelector{},
+					ClusterSelectorTerms: []appsapi.ClusterSelectorTerm{{}},
+				},
+			},
+			cluster:    makeCluster("c1", nil),
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonClusterSelectorTermsNotMatch),
+		},
+		{
+			name: "terms of a subscriber not selecting the cluster are ignored",
+			subscribers: []appsapi.Subscriber{
+				{
+					ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+					ClusterSelectorTerms: []appsapi.ClusterSelectorTerm{
+						{MatchFields: []metav1.LabelSelectorRequirement{requirement(appsapi.ClusterFieldName, metav1.LabelSelectorOpIn, "c1")}},
+					},
+				},
+				{
+					ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}},
+					ClusterSelectorTerms: []appsapi.ClusterSelectorTerm{
+						{MatchFields: []metav1.LabelSelectorRequirement{requirement(appsapi.ClusterFieldName, metav1.LabelSelectorOpIn, "c2")}},
+					},
+				},
+			},
+			cluster:    makeCluster("c1", map[string]string{"env": "test"}),
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonClusterSelectorTermsNotMatch),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &appsapi.Subscription{Spec: appsapi.SubscriptionSpec{Subscribers: tt.subscribers}}
+			p, _ := New(nil, nil)
+			state := framework.NewCycleState()
+			if status := p.(framework.PreFilterPlugin).PreFilter(context.Background(), state, sub); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			gotStatus := p.(framework.FilterPlugin).Filter(context.Background(), state, sub, tt.cluster)
+			if !reflect.DeepEqual(gotStatus, tt.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClusterAffinityPreFilterUnsupportedField(t *testing.T) {
+	sub := &appsapi.Subscription{
+		Spec: appsapi.SubscriptionSpec{
+			Subscribers: []appsapi.Subscriber{
+				{
+					ClusterAffinity: &metav1.LabelSelector{},
+					ClusterSelectorTerms: []appsapi.ClusterSelectorTerm{
+						{MatchFields: []metav1.LabelSelectorRequirement{requirement("spec.syncMode", metav1.LabelSelectorOpExists)}},
+					},
+				},
+			},
+		},
+	}
+	p, _ := New(nil, nil)
+	if status := p.(framework.PreFilterPlugin).PreFilter(context.Background(), framework.NewCycleState(), sub); status.IsSuccess() {
+		t.Errorf("expected an error on unsupported field key")
+	}
+}
+
+func TestClusterAffinityScore(t *testing.T) {
+	clusters := []*clusterapi.ManagedCluster{
+		makeCluster("c1", map[string]string{"region": "r1", "provider": "aws"}),
+		makeCluster("c2", map[string]string{"region": "r1", "provider": "gcp"}),
+		makeCluster("c3", map[string]string{"region": "r2", "provider": "aws"}),
+		makeCluster("c4", nil),
+	}
+
+	tests := []struct {
+		name         string
+		preferences  []appsapi.PreferredClusterSelectorTerm
+		expectedList framework.ClusterScoreList
+	}{
+		{
+			name: "no preferences",
+			expectedList: framework.ClusterScoreList{
+				{NamespacedName: "ns-c1/c1", Score: 0},
+				{NamespacedName: "ns-c2/c2", Score: 0},
+				{NamespacedName: "ns-c3/c3", Score: 0},
+				{NamespacedName: "ns-c4/c4", Score: 0},
+			},
+		},
+		{
+			name: "weights of matched preferences are added up",
+			preferences: []appsapi.PreferredClusterSelectorTerm{
+				{
+					Weight: 60,
+					Preference: appsapi.ClusterSelectorTerm{
+						MatchExpressions: []metav1.LabelSelectorRequirement{requirement("region", metav1.LabelSelectorOpIn, "r1")},
+					},
+				},
+				{
+					Weight: 20,
+					Preference: appsapi.ClusterSelectorTerm{
+						MatchExpressions: []metav1.LabelSelectorRequirement{requirement("provider", metav1.LabelSelectorOpIn, "aws")},
+					},
+				},
+				{
+					Weight: 20,
+					Preference: appsapi.ClusterSelectorTerm{
+						MatchFields: []metav1.LabelSelectorRequirement{requirement(appsapi.ClusterFieldName, metav1.LabelSelectorOpIn, "c4")},
+					},
+				},
+			},
+			expectedList: framework.ClusterScoreList{
+				{NamespacedName: "ns-c1/c1", Score: framework.MaxClusterScore},
+				{NamespacedName: "ns-c2/c2", Score: 75},
+				{NamespacedName: "ns-c3/c3", Score: 25},
+				{NamespacedName: "ns-c4/c4", Score: 25},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+			for _, cluster := range clusters {
+				if err := fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(cluster); err != nil {
+					t.Fatal(err)
+				}
+			}
+			fh, err := runtime.NewFramework(nil, nil, runtime.WithInformerFactory(fakeInformerFactory))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sub := &appsapi.Subscription{Spec: appsapi.SubscriptionSpec{PreferredClusterAffinity: tt.preferences}}
+			p, _ := New(nil, fh)
+			state := framework.NewCycleState()
+			if status := p.(framework.PreScorePlugin).PreScore(context.Background(), state, sub, clusters); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			var gotList framework.ClusterScoreList
+			for _, cluster := range clusters {
+				score, status := p.(framework.ScorePlugin).Score(context.Background(), state, sub, klog.KObj(cluster).String())
+				if !status.IsSuccess() {
+					t.Fatalf("unexpected error: %v", status)
+				}
+				gotList = append(gotList, framework.ClusterScore{NamespacedName: klog.KObj(cluster).String(), Score: score})
+			}
+
+			status := p.(framework.ScorePlugin).ScoreExtensions().NormalizeScore(context.Background(), state, sub, gotList)
+			if !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			if !reflect.DeepEqual(gotList, tt.expectedList) {
+				t.Errorf("expected:\n\t%+v,\ngot:\n\t%+v", tt.expectedList, gotList)
+			}
+		})
+	}
+}
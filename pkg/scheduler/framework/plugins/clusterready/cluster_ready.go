@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterready
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+const (
+	// ErrReasonNotReady is used for clusters whose Ready condition is False.
+	ErrReasonNotReady = "cluster(s) were not ready"
+	// ErrReasonUnknown is used for clusters whose Ready condition is Unknown.
+	ErrReasonUnknown = "cluster(s) had unknown ready status"
+	// ErrReasonNotReadyz is used for clusters that fail the readyz check.
+	ErrReasonNotReadyz = "cluster(s) failed the readyz check"
+	// ErrReasonNeverPosted is used for clusters that never posted status.
+	ErrReasonNeverPosted = "cluster(s) never posted status"
+	// ErrReasonHeartbeatLost is used for clusters that stopped posting status.
+	ErrReasonHeartbeatLost = "cluster(s) stopped posting status"
+
+	// defaultHeartbeatFrequency is used for clusters that do not report HeartbeatFrequencySeconds,
+	// which is consistent with the default status report frequency of clusternet-agent.
+	defaultHeartbeatFrequency = 3 * time.Minute
+)
+
+// ClusterReady is a plugin that checks if a cluster is ready and keeps posting its status.
+type ClusterReady struct {
+	handle framework.Handle
+	args   schedulerapis.ClusterReadyArgs
+	// now returns the current time, which could be replaced in tests.
+	now func() time.Time
+}
+
+var _ framework.FilterPlugin = &ClusterReady{}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *ClusterReady) Name() string {
+	return names.ClusterReady
+}
+
+// Filter invoked at the filter extension point.
+func (pl *ClusterReady) Filter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) *framework.Status {
+	if cluster == nil {
+		return framework.AsStatus(fmt.Errorf("invalid cluster"))
+	}
+
+	if cluster.Status.LastObservedTime.IsZero() {
+		return framework.NewStatus(framework.Unschedulable, ErrReasonNeverPosted)
+	}
+	heartbeatInTime := pl.heartbeatInTime(cluster.Status)
+
+	for _, condition := range cluster.Status.Conditions {
+		if condition.Type != clusterapi.ClusterReady {
+			continue
+		}
+		switch condition.Status {
+		case metav1.ConditionFalse:
+			return framework.NewStatus(framework.Unschedulable, ErrReasonNotReady)
+		case metav1.ConditionUnknown:
+			// the cluster has come back only if it posts status again after the condition turns into Unknown
+			if !heartbeatInTime || !cluster.Status.LastObservedTime.After(condition.LastTransitionTime.Time) {
+				return framework.NewStatus(framework.Unschedulable, ErrReasonUnknown)
+			}
+		}
+	}
+
+	if !cluster.Status.Readyz {
+		return framework.NewStatus(framework.Unschedulable, ErrReasonNotReadyz)
+	}
+	if !heartbeatInTime {
+		return framework.NewStatus(framework.Unschedulable, ErrReasonHeartbeatLost)
+	}
+	return nil
+}
+
+// heartbeatInTime checks whether the last heartbeat of a cluster is within the tolerated heartbeat gaps.
+// defaultHeartbeatFrequency is used for clusters that do not report HeartbeatFrequencySeconds.
+func (pl *ClusterReady) heartbeatInTime(status clusterapi.ManagedClusterStatus) bool {
+	frequency := defaultHeartbeatFrequency
+	if status.HeartbeatFrequencySeconds != nil && *status.HeartbeatFrequencySeconds > 0 {
+		frequency = time.Duration(*status.HeartbeatFrequencySeconds) * time.Second
+	}
+	gracePeriod := frequency * time.Duration(pl.args.ToleratedMissedHeartbeats+1)
+	return !pl.now().After(status.LastObservedTime.Add(gracePeriod))
+}
+
+// New initializes a new plugin and returns it.
+func New(obj runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	args := schedulerapis.ClusterReadyArgs{
+		ToleratedMissedHeartbeats: schedulerapis.DefaultToleratedMissedHeartbeats,
+	}
+	if obj != nil {
+		readyArgs, ok := obj.(*schedulerapis.ClusterReadyArgs)
+		if !ok {
+			return nil, fmt.Errorf("want args to be of type ClusterReadyArgs, got %T", obj)
+		}
+		if readyArgs.ToleratedMissedHeartbeats < 0 {
+			return nil, fmt.Errorf("toleratedMissedHeartbeats should not be negative, got %d", readyArgs.ToleratedMissedHeartbeats)
+		}
+		args = *readyArgs
+	}
+
+	return &ClusterReady{
+		handle: h,
+		args:   args,
+		now:    time.Now,
+	}, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterready
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilpointer "k8s.io/utils/pointer"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+func makeCluster(readyStatus metav1.ConditionStatus, readyz bool, lastObservedTime time.Time, heartbeatFrequencySeconds *int64) *clusterapi.ManagedCluster {
+	cluster := &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-test",
+			Namespace: "cluster-ns-01",
+		},
+		Status: clusterapi.ManagedClusterStatus{
+			Readyz:                    readyz,
+			LastObservedTime:          metav1.NewTime(lastObservedTime),
+			HeartbeatFrequencySeconds: heartbeatFrequencySeconds,
+		},
+	}
+	if len(readyStatus) > 0 {
+		cluster.Status.Conditions = []metav1.Condition{
+			{
+				Type:   clusterapi.ClusterReady,
+				Status: readyStatus,
+			},
+		}
+	}
+	return cluster
+}
+
+func withReadyTransitionTime(cluster *clusterapi.ManagedCluster, lastTransitionTime time.Time) *clusterapi.ManagedCluster {
+	for i := range cluster.Status.Conditions {
+		cluster.Status.Conditions[i].LastTransitionTime = metav1.NewTime(lastTransitionTime)
+	}
+	return cluster
+}
+
+func TestClusterReadyFilter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	frequency := utilpointer.Int64Ptr(60)
+
+	tests := []struct {
+		name       string
+		args       *schedulerapis.ClusterReadyArgs
+		cluster    *clusterapi.ManagedCluster
+		wantStatus *framework.Status
+	}{
+		{
+			name:    "ready cluster",
+			cluster: makeCluster(metav1.ConditionTrue, true, now.Add(-30*time.Second), frequency),
+		},
+		{
+			name:       "not ready cluster",
+			cluster:    makeCluster(metav1.ConditionFalse, false, now.Add(-30*time.Second), frequency),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonNotReady),
+		},
+		{
+			name:       "lost cluster",
+			cluster:    makeCluster(metav1.ConditionUnknown, false, now.Add(-10*time.Minute), frequency),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonUnknown),
+		},
+		{
+			name:    "lost cluster that posts status again",
+			cluster: withReadyTransitionTime(makeCluster(metav1.ConditionUnknown, true, now.Add(-30*time.Second), frequency), now.Add(-time.Minute)),
+		},
+		{
+			name:       "lost cluster without any heartbeat after being lost",
+			cluster:    withReadyTransitionTime(makeCluster(metav1.ConditionUnknown, true, now.Add(-30*time.Second), frequency), now.Add(-10*time.Second)),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonUnknown),
+		},
+		{
+			name:       "lost cluster without heartbeat frequency",
+			cluster:    withReadyTransitionTime(makeCluster(metav1.ConditionUnknown, true, now.Add(-time.Hour), nil), now.Add(-2*time.Hour)),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonUnknown),
+		},
+		{
+			name:       "cluster failing readyz check",
+			cluster:    makeCluster("", false, now.Add(-30*time.Second), frequency),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonNotReadyz),
+		},
+		{
+			name:       "cluster never posting status",
+			cluster:    makeCluster("", false, time.Time{}, nil),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonNeverPosted),
+		},
+		{
+			name:    "short heartbeat gap is tolerated by default",
+			cluster: makeCluster(metav1.ConditionTrue, true, now.Add(-3*time.Minute), frequency),
+		},
+		{
+			name:       "long heartbeat gap",
+			cluster:    makeCluster(metav1.ConditionTrue, true, now.Add(-5*time.Minute), frequency),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonHeartbeatLost),
+		},
+		{
+			name:       "no heartbeat gap is tolerated",
+			args:       &schedulerapis.ClusterReadyArgs{ToleratedMissedHeartbeats: 0},
+			cluster:    makeCluster(metav1.ConditionTrue, true, now.Add(-90*time.Second), frequency),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonHeartbeatLost),
+		},
+		{
+			name:    "cluster without heartbeat frequency",
+			cluster: makeCluster(metav1.ConditionTrue, true, now.Add(-5*time.Minute), nil),
+		},
+		{
+			name:       "cluster without heartbeat frequency stopped posting status",
+			cluster:    makeCluster(metav1.ConditionTrue, true, now.Add(-time.Hour), nil),
+			wantStatus: framework.NewStatus(framework.Unschedulable, ErrReasonHeartbeatLost),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args runtime.Object
+			if tt.args != nil {
+				args = tt.args
+			}
+			p, err := New(args, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.(*ClusterReady).now = func() time.Time { return now }
+
+			gotStatus := p.(framework.FilterPlugin).Filter(context.Background(), framework.NewCycleState(), &appsapi.Subscription{}, tt.cluster)
+			if !reflect.DeepEqual(gotStatus, tt.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+// Fit is a plugin that checks if a cluster has sufficient resources to run the workloads of a subscription.
+type Fit struct {
+	handle framework.Handle
+}
+
+var _ framework.PreFilterPlugin = &Fit{}
+var _ framework.FilterPlugin = &Fit{}
+
+// NewFit initializes a new plugin and returns it.
+func NewFit(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &Fit{handle: h}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *Fit) Name() string {
+	return names.ClusterResourcesFit
+}
+
+// PreFilter invoked at the prefilter extension point.
+// It computes the resource requests of a subscription once, which are used by Filter for all the clusters.
+func (pl *Fit) PreFilter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription) *framework.Status {
+	return computeSubscriptionRequests(pl.handle, state, sub)
+}
+
+// Filter invoked at the filter extension point.
+// Checks if a cluster has sufficient resources, such as cpu, memory, gpu etc to run the workloads of a subscription.
+// It returns a list of insufficient resources, if empty, then the cluster has all the resources requested by the subscription.
+func (pl *Fit) Filter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) *framework.Status {
+	if cluster == nil {
+		return framework.AsStatus(fmt.Errorf("invalid cluster"))
+	}
+
+	requests, err := getSubscriptionRequests(pl.handle, state, sub)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+
+	insufficientResources := fitsRequest(requests, cluster)
+	if len(insufficientResources) == 0 {
+		return nil
+	}
+
+	failureReasons := make([]string, 0, len(insufficientResources))
+	for _, r := range insufficientResources {
+		failureReasons = append(failureReasons, fmt.Sprintf("Insufficient %v", r))
+	}
+	return framework.NewStatus(framework.Unschedulable, failureReasons...)
+}
+
+// fitsRequest returns the names of resources that the cluster could not afford.
+func fitsRequest(requests corev1.ResourceList, cluster *clusterapi.ManagedCluster) []string {
+	var insufficientResources []string
+	for name, request := range requests {
+		if request.IsZero() {
+			continue
+		}
+
+		available := cluster.Status.Allocatable[name]
+		available = available.DeepCopy()
+		if requested, ok := cluster.Status.Requested[name]; ok {
+			available.Sub(requested)
+		}
+		if available.Cmp(request) < 0 {
+			insufficientResources = append(insufficientResources, string(name))
+		}
+	}
+	// use an ordered list
+	sort.Strings(insufficientResources)
+	return insufficientResources
+}
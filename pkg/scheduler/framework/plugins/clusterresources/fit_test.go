@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	"github.com/clusternet/clusternet/pkg/known"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func makeCluster(name string, allocatableCPU, allocatableMemory, requestedCPU, requestedMemory string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns-" + name,
+		},
+		Status: clusterapi.ManagedClusterStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(allocatableCPU),
+				corev1.ResourceMemory: resource.MustParse(allocatableMemory),
+			},
+			Requested: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(requestedCPU),
+				corev1.ResourceMemory: resource.MustParse(requestedMemory),
+			},
+		},
+	}
+}
+
+func makeDeploymentManifest(name string, replicas int32, cpu, memory string) *appsapi.Manifest {
+	return &appsapi.Manifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployments.v1.apps.default." + name,
+			Namespace: known.ClusternetReservedNamespace,
+			Labels: map[string]string{
+				known.ConfigGroupLabel:     "apps",
+				known.ConfigVersionLabel:   "v1",
+				known.ConfigKindLabel:      "Deployment",
+				known.ConfigNamespaceLabel: "default",
+				known.ConfigNameLabel:      name,
+			},
+		},
+		Template: runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":%q,"namespace":"default"},`+
+				`"spec":{"replicas":%d,"template":{"spec":{"containers":[{"name":"app","image":"app",`+
+				`"resources":{"requests":{"cpu":%q,"memory":%q}}}]}}}}`, name, replicas, cpu, memory)),
+		},
+	}
+}
+
+func makeSubscription(strategy appsapi.SchedulingStrategyType, feedNames ...string) *appsapi.Subscription {
+	sub := &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+		Spec: appsapi.SubscriptionSpec{
+			SchedulingStrategy: strategy,
+		},
+	}
+	for _, name := range feedNames {
+		sub.Spec.Feeds = append(sub.Spec.Feeds, appsapi.Feed{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+			Namespace:  "default",
+			Name:       name,
+		})
+	}
+	return sub
+}
+
+func newFakeFramework(t *testing.T, clusters []*clusterapi.ManagedCluster, manifests []*appsapi.Manifest) framework.Framework {
+	fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0*time.Second)
+	for _, cluster := range clusters {
+		if err := fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(cluster); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, manifest := range manifests {
+		if err := fakeInformerFactory.Apps().V1alpha1().Manifests().Informer().GetStore().Add(manifest); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fh, err := frameworkruntime.NewFramework(nil, nil, frameworkruntime.WithInformerFactory(fakeInformerFactory))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fh
+}
+
+func TestFit(t *testing.T) {
+	manifests := []*appsapi.Manifest{
+		makeDeploymentManifest("small", 2, "500m", "512Mi"),
+		makeDeploymentManifest("large", 4, "2", "4Gi"),
+		makeDeploymentManifest("huge", 10000, "1m", "1Pi"),
+	}
+
+	tests := []struct {
+		name         string
+		subscription *appsapi.Subscription
+		cluster      *clusterapi.ManagedCluster
+		wantStatus   *framework.Status
+	}{
+		{
+			name:         "no workload feeds",
+			subscription: makeSubscription(appsapi.ReplicaSchedulingStrategyType),
+			cluster:      makeCluster("c1", "1", "1Gi", "1", "1Gi"),
+		},
+		{
+			name:         "enough resources for replication",
+			subscription: makeSubscription(appsapi.ReplicaSchedulingStrategyType, "small"),
+			cluster:      makeCluster("c1", "4", "8Gi", "2", "4Gi"),
+		},
+		{
+			name:         "insufficient cpu for replication",
+			subscription: makeSubscription(appsapi.ReplicaSchedulingStrategyType, "small", "large"),
+			cluster:      makeCluster("c1", "8", "32Gi", "2", "4Gi"),
+			wantStatus:   framework.NewStatus(framework.Unschedulable, "Insufficient cpu"),
+		},
+		{
+			name:         "insufficient cpu and memory for replication",
+			subscription: makeSubscription(appsapi.ReplicaSchedulingStrategyType, "large"),
+			cluster:      makeCluster("c1", "4", "8Gi", "2", "4Gi"),
+			wantStatus:   framework.NewStatus(framework.Unschedulable, "Insufficient cpu", "Insufficient memory"),
+		},
+		{
+			name:         "large requests do not overflow",
+			subscription: makeSubscription(appsapi.ReplicaSchedulingStrategyType, "huge"),
+			cluster:      makeCluster("c1", "16", "8Ei", "2", "4Gi"),
+			wantStatus:   framework.NewStatus(framework.Unschedulable, "Insufficient memory"),
+		},
+		{
+			name:         "only one replica is counted for dividing",
+			subscription: makeSubscription(appsapi.DividingSchedulingStrategyType, "large"),
+			cluster:      makeCluster("c1", "4", "8Gi", "2", "4Gi"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fh := newFakeFramework(t, nil, manifests)
+			p, _ := NewFit(nil, fh)
+			state := framework.NewCycleState()
+			if status := p.(framework.PreFilterPlugin).PreFilter(context.Background(), state, tt.subscription); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			gotStatus := p.(framework.FilterPlugin).Filter(context.Background(), state, tt.subscription, tt.cluster)
+			if !reflect.DeepEqual(gotStatus, tt.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, tt.wantStatus)
+			}
+		})
+	}
+}
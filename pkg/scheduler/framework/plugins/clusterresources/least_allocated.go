@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/framework/plugins/noderesources/least_allocated.go and modified
+
+package clusterresources
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+// LeastAllocated is a score plugin that favors clusters with fewer allocation requested resources based on requested resources.
+type LeastAllocated struct {
+	handle framework.Handle
+	resourceAllocationScorer
+}
+
+var _ framework.PreScorePlugin = &LeastAllocated{}
+var _ framework.ScorePlugin = &LeastAllocated{}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (la *LeastAllocated) Name() string {
+	return names.ClusterResourcesLeastAllocated
+}
+
+// PreScore invoked at the prescore extension point.
+// It computes the resource requests of a subscription once, which are used by Score for all the clusters.
+func (la *LeastAllocated) PreScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, _ []*clusterapi.ManagedCluster) *framework.Status {
+	return computeSubscriptionRequests(la.handle, state, sub)
+}
+
+// Score invoked at the score extension point.
+func (la *LeastAllocated) Score(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	// la.score favors clusters with fewer requested resources.
+	// It calculates the percentage of memory and CPU requested by subscriptions scheduled on the cluster, and
+	// prioritizes based on the minimum of the average of the fraction of requested to capacity.
+	//
+	// Details:
+	// (cpu((capacity-sum(requested))*MaxClusterScore/capacity) + memory((capacity-sum(requested))*MaxClusterScore/capacity))/weightSum
+	return la.score(la.handle, state, sub, namespacedCluster)
+}
+
+// ScoreExtensions of the Score plugin.
+func (la *LeastAllocated) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// NewLeastAllocated initializes a new plugin and returns it.
+func NewLeastAllocated(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &LeastAllocated{
+		handle: h,
+		resourceAllocationScorer: resourceAllocationScorer{
+			Name:                names.ClusterResourcesLeastAllocated,
+			scorer:              leastResourceScorer(defaultRequestedRatioResources),
+			resourceToWeightMap: defaultRequestedRatioResources,
+		},
+	}, nil
+}
+
+func leastResourceScorer(resToWeightMap resourceToWeightMap) func(resourceToValueMap, resourceToValueMap) int64 {
+	return func(requested, allocable resourceToValueMap) int64 {
+		var clusterScore, weightSum int64
+		for resource, weight := range resToWeightMap {
+			resourceScore := leastRequestedScore(requested[resource], allocable[resource])
+			clusterScore += resourceScore * weight
+			weightSum += weight
+		}
+		if weightSum == 0 {
+			return 0
+		}
+		return clusterScore / weightSum
+	}
+}
+
+// The unused capacity is calculated on a scale of 0-MaxClusterScore
+// 0 being the lowest priority and `MaxClusterScore` being the highest.
+// The more unused resources the higher the score is.
+func leastRequestedScore(requested, capacity int64) int64 {
+	if capacity == 0 {
+		return 0
+	}
+	if requested > capacity {
+		return 0
+	}
+
+	return ((capacity - requested) * framework.MaxClusterScore) / capacity
+}
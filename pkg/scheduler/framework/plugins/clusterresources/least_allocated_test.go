@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+func TestLeastAllocated(t *testing.T) {
+	manifests := []*appsapi.Manifest{
+		makeDeploymentManifest("small", 2, "500m", "512Mi"),
+	}
+
+	tests := []struct {
+		name         string
+		subscription *appsapi.Subscription
+		clusters     []*clusterapi.ManagedCluster
+		expectedList framework.ClusterScoreList
+	}{
+		{
+			name:         "cluster with less requested resources gets a higher score",
+			subscription: makeSubscription(appsapi.DividingSchedulingStrategyType, "small"),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", "4", "8Gi", "2", "4Gi"),
+				makeCluster("c2", "8", "16Gi", "0", "0"),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 40},
+				{NamespacedName: "ns-c2/c2", Score: 94},
+			},
+		},
+		{
+			name:         "clusters without allocatable resources",
+			subscription: makeSubscription(appsapi.DividingSchedulingStrategyType, "small"),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", "0", "0", "0", "0"),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fh := newFakeFramework(t, test.clusters, manifests)
+			p, _ := NewLeastAllocated(nil, fh)
+
+			state := framework.NewCycleState()
+			if status := p.(framework.PreScorePlugin).PreScore(context.Background(), state, test.subscription, test.clusters); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+
+			var gotList framework.ClusterScoreList
+			for _, cluster := range test.clusters {
+				score, status := p.(framework.ScorePlugin).Score(context.Background(), state, test.subscription, klog.KObj(cluster).String())
+				if !status.IsSuccess() {
+					t.Errorf("unexpected error: %v", status)
+				}
+				gotList = append(gotList, framework.ClusterScore{NamespacedName: klog.KObj(cluster).String(), Score: score})
+			}
+
+			if !reflect.DeepEqual(test.expectedList, gotList) {
+				t.Errorf("expected:\n\t%+v,\ngot:\n\t%+v", test.expectedList, gotList)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/framework/plugins/noderesources/most_allocated.go and modified
+
+package clusterresources
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+// MostAllocated is a score plugin that favors clusters with high allocation based on requested resources.
+type MostAllocated struct {
+	handle framework.Handle
+	resourceAllocationScorer
+}
+
+var _ framework.PreScorePlugin = &MostAllocated{}
+var _ framework.ScorePlugin = &MostAllocated{}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (ma *MostAllocated) Name() string {
+	return names.ClusterResourcesMostAllocated
+}
+
+// PreScore invoked at the prescore extension point.
+// It computes the resource requests of a subscription once, which are used by Score for all the clusters.
+func (ma *MostAllocated) PreScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, _ []*clusterapi.ManagedCluster) *framework.Status {
+	return computeSubscriptionRequests(ma.handle, state, sub)
+}
+
+// Score invoked at the Score extension point.
+func (ma *MostAllocated) Score(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	// ma.score favors clusters with most requested resources.
+	// It calculates the percentage of memory and CPU requested by subscriptions scheduled on the cluster, and prioritizes
+	// based on the maximum of the average of the fraction of requested to capacity.
+	// Details: (cpu(MaxClusterScore * sum(requested) / capacity) + memory(MaxClusterScore * sum(requested) / capacity)) / weightSum
+	return ma.score(ma.handle, state, sub, namespacedCluster)
+}
+
+// ScoreExtensions of the Score plugin.
+func (ma *MostAllocated) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// NewMostAllocated initializes a new plugin and returns it.
+func NewMostAllocated(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &MostAllocated{
+		handle: h,
+		resourceAllocationScorer: resourceAllocationScorer{
+			Name:                names.ClusterResourcesMostAllocated,
+			scorer:              mostResourceScorer(defaultRequestedRatioResources),
+			resourceToWeightMap: defaultRequestedRatioResources,
+		},
+	}, nil
+}
+
+func mostResourceScorer(resToWeightMap resourceToWeightMap) func(requested, allocable resourceToValueMap) int64 {
+	return func(requested, allocable resourceToValueMap) int64 {
+		var clusterScore, weightSum int64
+		for resource, weight := range resToWeightMap {
+			resourceScore := mostRequestedScore(requested[resource], allocable[resource])
+			clusterScore += resourceScore * weight
+			weightSum += weight
+		}
+		if weightSum == 0 {
+			return 0
+		}
+		return clusterScore / weightSum
+	}
+}
+
+// The used capacity is calculated on a scale of 0-MaxClusterScore (MaxClusterScore is
+// constant with value set to 100).
+// 0 being the lowest priority and 100 being the highest.
+// The more resources are used the higher the score is. This function
+// is almost a reversed version of leastRequestedScore.
+func mostRequestedScore(requested, capacity int64) int64 {
+	if capacity == 0 {
+		return 0
+	}
+	if requested > capacity {
+		// `requested` might be greater than `capacity` because the resources requested
+		// by the subscription are beyond the available ones.
+		requested = capacity
+	}
+
+	return (requested * framework.MaxClusterScore) / capacity
+}
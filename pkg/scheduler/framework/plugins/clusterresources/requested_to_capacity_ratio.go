@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/framework/plugins/noderesources/requested_to_capacity_ratio.go and modified
+
+package clusterresources
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+const (
+	// maxUtilization is the maximum utilization of a resource in percentage.
+	maxUtilization = 100
+)
+
+// defaultFunctionShape favors the clusters with lower utilization, which spreads the workloads.
+var defaultFunctionShape = []schedulerapis.UtilizationShapePoint{
+	{Utilization: 0, Score: int32(schedulerapis.MaxCustomPriorityScore)},
+	{Utilization: maxUtilization, Score: 0},
+}
+
+// RequestedToCapacityRatio is a score plugin that allows users to apply bin packing
+// on core resources like CPU, Memory as well as extended resources like accelerators.
+type RequestedToCapacityRatio struct {
+	handle framework.Handle
+	resourceAllocationScorer
+}
+
+var _ framework.PreScorePlugin = &RequestedToCapacityRatio{}
+var _ framework.ScorePlugin = &RequestedToCapacityRatio{}
+
+// NewRequestedToCapacityRatio initializes a new plugin and returns it.
+func NewRequestedToCapacityRatio(obj runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	shape := defaultFunctionShape
+	weightMap := defaultRequestedRatioResources
+	if obj != nil {
+		args, ok := obj.(*schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs)
+		if !ok {
+			return nil, fmt.Errorf("want args to be of type ClusterResourcesRequestedToCapacityRatioArgs, got %T", obj)
+		}
+		if len(args.Shape) > 0 {
+			shape = args.Shape
+		}
+		if len(args.Resources) > 0 {
+			weightMap = make(resourceToWeightMap, len(args.Resources))
+			for _, resource := range args.Resources {
+				weightMap[corev1.ResourceName(resource.Name)] = resource.Weight
+			}
+		}
+	}
+
+	return &RequestedToCapacityRatio{
+		handle: h,
+		resourceAllocationScorer: resourceAllocationScorer{
+			Name:                names.ClusterResourcesRequestedToCapacityRatio,
+			scorer:              buildRequestedToCapacityRatioScorerFunction(shape, weightMap),
+			resourceToWeightMap: weightMap,
+		},
+	}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *RequestedToCapacityRatio) Name() string {
+	return names.ClusterResourcesRequestedToCapacityRatio
+}
+
+// PreScore invoked at the prescore extension point.
+// It computes the resource requests of a subscription once, which are used by Score for all the clusters.
+func (pl *RequestedToCapacityRatio) PreScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, _ []*clusterapi.ManagedCluster) *framework.Status {
+	return computeSubscriptionRequests(pl.handle, state, sub)
+}
+
+// Score invoked at the score extension point.
+func (pl *RequestedToCapacityRatio) Score(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	return pl.score(pl.handle, state, sub, namespacedCluster)
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *RequestedToCapacityRatio) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+func buildRequestedToCapacityRatioScorerFunction(shape []schedulerapis.UtilizationShapePoint, resourceToWeightMap resourceToWeightMap) func(resourceToValueMap, resourceToValueMap) int64 {
+	// Scale the utilization shape to [0, MaxClusterScore].
+	scaledShape := make([]schedulerapis.UtilizationShapePoint, 0, len(shape))
+	for _, point := range shape {
+		scaledShape = append(scaledShape, schedulerapis.UtilizationShapePoint{
+			Utilization: point.Utilization,
+			Score:       point.Score * int32(framework.MaxClusterScore/schedulerapis.MaxCustomPriorityScore),
+		})
+	}
+
+	rawScoringFunction := buildBrokenLinearFunction(scaledShape)
+	resourceScoringFunction := func(requested, capacity int64) int64 {
+		if capacity == 0 || requested > capacity {
+			return rawScoringFunction(maxUtilization)
+		}
+
+		return rawScoringFunction(requested * maxUtilization / capacity)
+	}
+	return func(requested, allocable resourceToValueMap) int64 {
+		var clusterScore, weightSum int64
+		for resource, weight := range resourceToWeightMap {
+			resourceScore := resourceScoringFunction(requested[resource], allocable[resource])
+			if resourceScore > 0 {
+				clusterScore += resourceScore * weight
+				weightSum += weight
+			}
+		}
+		if weightSum == 0 {
+			return 0
+		}
+		return int64(math.Round(float64(clusterScore) / float64(weightSum)))
+	}
+}
+
+// buildBrokenLinearFunction creates a function which is built using linear segments. Segments are defined via shape array.
+// Shape[i].Utilization slice represents points on "Utilization" axis where different segments meet.
+// Shape[i].Score represents function values at meeting points.
+//
+// function f(p) is defined as:
+//
+//	shape[0].Score for p < shape[0].Utilization
+//	shape[n-1].Score for p > shape[n-1].Utilization
+//
+// and linear between points (p < shape[i].Utilization)
+func buildBrokenLinearFunction(shape []schedulerapis.UtilizationShapePoint) func(int64) int64 {
+	return func(p int64) int64 {
+		for i := 0; i < len(shape); i++ {
+			if p <= int64(shape[i].Utilization) {
+				if i == 0 {
+					return int64(shape[0].Score)
+				}
+				return int64(shape[i-1].Score) + int64(shape[i].Score-shape[i-1].Score)*(p-int64(shape[i-1].Utilization))/int64(shape[i].Utilization-shape[i-1].Utilization)
+			}
+		}
+		return int64(shape[len(shape)-1].Score)
+	}
+}
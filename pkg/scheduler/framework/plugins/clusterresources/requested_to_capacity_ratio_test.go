@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresources
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+func TestRequestedToCapacityRatio(t *testing.T) {
+	manifests := []*appsapi.Manifest{
+		makeDeploymentManifest("small", 2, "500m", "512Mi"),
+	}
+
+	binPackingShape := []schedulerapis.UtilizationShapePoint{
+		{Utilization: 0, Score: 0},
+		{Utilization: 100, Score: 10},
+	}
+
+	tests := []struct {
+		name         string
+		args         *schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs
+		subscription *appsapi.Subscription
+		clusters     []*clusterapi.ManagedCluster
+		expectedList framework.ClusterScoreList
+	}{
+		{
+			name:         "cluster with lower utilization gets a higher score with the default shape",
+			subscription: makeSubscription(appsapi.DividingSchedulingStrategyType, "small"),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", "4", "8Gi", "2", "4Gi"),
+				makeCluster("c2", "8", "16Gi", "0", "0"),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 41},
+				{NamespacedName: "ns-c2/c2", Score: 96},
+			},
+		},
+		{
+			name: "cluster with higher utilization gets a higher score with a bin packing shape",
+			args: &schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs{
+				Shape: binPackingShape,
+			},
+			subscription: makeSubscription(appsapi.DividingSchedulingStrategyType, "small"),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", "4", "8Gi", "2", "4Gi"),
+				makeCluster("c2", "8", "16Gi", "0", "0"),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 59},
+				{NamespacedName: "ns-c2/c2", Score: 5},
+			},
+		},
+		{
+			name: "only the configured resources are scored",
+			args: &schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs{
+				Shape:     binPackingShape,
+				Resources: []schedulerapis.ResourceSpec{{Name: "cpu", Weight: 1}},
+			},
+			subscription: makeSubscription(appsapi.DividingSchedulingStrategyType, "small"),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", "4", "8Gi", "2", "4Gi"),
+				makeCluster("c2", "8", "16Gi", "0", "0"),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 62},
+				{NamespacedName: "ns-c2/c2", Score: 6},
+			},
+		},
+		{
+			name:         "clusters without allocatable resources",
+			subscription: makeSubscription(appsapi.DividingSchedulingStrategyType, "small"),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", "0", "0", "0", "0"),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 0},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fh := newFakeFramework(t, test.clusters, manifests)
+			var args runtime.Object
+			if test.args != nil {
+				args = test.args
+			}
+			p, err := NewRequestedToCapacityRatio(args, fh)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			state := framework.NewCycleState()
+			if status := p.(framework.PreScorePlugin).PreScore(context.Background(), state, test.subscription, test.clusters); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+
+			var gotList framework.ClusterScoreList
+			for _, cluster := range test.clusters {
+				score, status := p.(framework.ScorePlugin).Score(context.Background(), state, test.subscription, klog.KObj(cluster).String())
+				if !status.IsSuccess() {
+					t.Errorf("unexpected error: %v", status)
+				}
+				gotList = append(gotList, framework.ClusterScore{NamespacedName: klog.KObj(cluster).String(), Score: score})
+			}
+
+			if !reflect.DeepEqual(test.expectedList, gotList) {
+				t.Errorf("expected:\n\t%+v,\ngot:\n\t%+v", test.expectedList, gotList)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresources
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/helper"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+	"github.com/clusternet/clusternet/pkg/utils"
+)
+
+// Reservation is a plugin that assumes the resources requested by a subscription on the target clusters
+// in the scheduler cache, so that the subscriptions scheduled back-to-back won't overcommit a cluster
+// before its agent reports the real usage.
+type Reservation struct {
+	handle framework.Handle
+}
+
+var _ framework.ReservePlugin = &Reservation{}
+
+// NewReservation initializes a new plugin and returns it.
+func NewReservation(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &Reservation{handle: h}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *Reservation) Name() string {
+	return names.ClusterResourcesReservation
+}
+
+// Reserve invoked at the reserve extension point.
+func (pl *Reservation) Reserve(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) *framework.Status {
+	clusterCache := pl.handle.ClusterCache()
+	if clusterCache == nil {
+		return nil
+	}
+
+	clusterRequests, err := getAssumedRequests(pl.handle, sub, targetClusters)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	clusterCache.AssumeSubscription(klog.KObj(sub).String(), clusterRequests)
+	return nil
+}
+
+// Unreserve invoked at the unreserve extension point.
+func (pl *Reservation) Unreserve(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) {
+	clusterCache := pl.handle.ClusterCache()
+	if clusterCache == nil {
+		return
+	}
+	clusterCache.ForgetSubscription(klog.KObj(sub).String())
+}
+
+// getAssumedRequests returns the resources to be consumed by a subscription on each of the target clusters,
+// which is indexed by the namespaced name of clusters.
+// Only the increments are counted, since the workloads that are already running in the clusters
+// have been reported by the agents.
+func getAssumedRequests(handle framework.Handle, sub *appsapi.Subscription, targetClusters framework.TargetClusters) (map[string]corev1.ResourceList, error) {
+	manifestLister := handle.SharedInformerFactory().Apps().V1alpha1().Manifests().Lister()
+
+	boundIndices := make(map[string]int, len(sub.Status.BindingClusters))
+	for idx, cluster := range sub.Status.BindingClusters {
+		boundIndices[cluster] = idx
+	}
+
+	clusterRequests := make(map[string]corev1.ResourceList)
+	if sub.Spec.SchedulingStrategy != appsapi.DividingSchedulingStrategyType {
+		requests, err := helper.GetSubscriptionRequests(manifestLister, sub)
+		if err != nil {
+			return nil, err
+		}
+		if len(requests) == 0 {
+			return nil, nil
+		}
+		for _, cluster := range targetClusters.BindingClusters {
+			if _, ok := boundIndices[cluster]; ok {
+				continue
+			}
+			clusterRequests[cluster] = requests.DeepCopy()
+		}
+		return clusterRequests, nil
+	}
+
+	podRequests, err := helper.GetPodRequests(manifestLister, sub)
+	if err != nil {
+		return nil, err
+	}
+	for idx, cluster := range targetClusters.BindingClusters {
+		requests := make(corev1.ResourceList)
+		for feedKey, replicas := range targetClusters.Replicas {
+			if idx >= len(replicas) {
+				continue
+			}
+			increment := replicas[idx]
+			if boundIdx, ok := boundIndices[cluster]; ok && boundIdx < len(sub.Status.Replicas[feedKey]) {
+				increment -= sub.Status.Replicas[feedKey][boundIdx]
+			}
+			if increment <= 0 {
+				continue
+			}
+			for name, quantity := range utils.MultiplyResourceList(podRequests[feedKey], int64(increment)) {
+				value := requests[name]
+				value.Add(quantity)
+				requests[name] = value
+			}
+		}
+		if len(requests) > 0 {
+			clusterRequests[cluster] = requests
+		}
+	}
+	return clusterRequests, nil
+}
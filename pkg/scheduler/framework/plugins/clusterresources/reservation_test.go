@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterresources
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	schedulercache "github.com/clusternet/clusternet/pkg/scheduler/cache"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func TestReservation(t *testing.T) {
+	feedKey := "apps/v1/Deployment/default/app"
+	manifests := []*appsapi.Manifest{makeDeploymentManifest("app", 4, "500m", "512Mi")}
+
+	tests := []struct {
+		name           string
+		subscription   *appsapi.Subscription
+		targetClusters framework.TargetClusters
+		// expected requested cpu of each cluster after reserving
+		expectedCPU map[string]string
+	}{
+		{
+			name:         "replicated to newly bound clusters",
+			subscription: makeSubscription(appsapi.ReplicaSchedulingStrategyType, "app"),
+			targetClusters: framework.TargetClusters{
+				BindingClusters: []string{"ns-c1/c1", "ns-c2/c2"},
+			},
+			expectedCPU: map[string]string{"ns-c1/c1": "3", "ns-c2/c2": "3"},
+		},
+		{
+			name: "clusters already bound are not assumed again",
+			subscription: func() *appsapi.Subscription {
+				sub := makeSubscription(appsapi.ReplicaSchedulingStrategyType, "app")
+				sub.Status.BindingClusters = []string{"ns-c1/c1"}
+				return sub
+			}(),
+			targetClusters: framework.TargetClusters{
+				BindingClusters: []string{"ns-c1/c1", "ns-c2/c2"},
+			},
+			expectedCPU: map[string]string{"ns-c1/c1": "1", "ns-c2/c2": "3"},
+		},
+		{
+			name: "increments of divided replicas",
+			subscription: func() *appsapi.Subscription {
+				sub := makeSubscription(appsapi.DividingSchedulingStrategyType, "app")
+				sub.Status.BindingClusters = []string{"ns-c1/c1", "ns-c2/c2"}
+				sub.Status.Replicas = map[string]appsapi.FeedReplicas{feedKey: {2, 2}}
+				return sub
+			}(),
+			targetClusters: framework.TargetClusters{
+				BindingClusters: []string{"ns-c1/c1", "ns-c2/c2"},
+				Replicas:        map[string][]int32{feedKey: {3, 1}},
+			},
+			expectedCPU: map[string]string{"ns-c1/c1": "1500m", "ns-c2/c2": "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0*time.Second)
+			for _, cluster := range []*clusterapi.ManagedCluster{
+				makeCluster("c1", "8", "16Gi", "1", "1Gi"),
+				makeCluster("c2", "8", "16Gi", "1", "1Gi"),
+			} {
+				if err := fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(cluster); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, manifest := range manifests {
+				if err := fakeInformerFactory.Apps().V1alpha1().Manifests().Informer().GetStore().Add(manifest); err != nil {
+					t.Fatal(err)
+				}
+			}
+			clusterCache := schedulercache.New(fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Lister())
+			fh, err := frameworkruntime.NewFramework(nil, nil,
+				frameworkruntime.WithInformerFactory(fakeInformerFactory),
+				frameworkruntime.WithClusterCache(clusterCache),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, _ := NewReservation(nil, fh)
+			state := framework.NewCycleState()
+			if status := p.(framework.ReservePlugin).Reserve(context.Background(), state, tt.subscription, tt.targetClusters); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			for namespacedName, expected := range tt.expectedCPU {
+				cluster, err := clusterCache.Get(namespacedName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				requested := cluster.Status.Requested[corev1.ResourceCPU]
+				if requested.Cmp(resource.MustParse(expected)) != 0 {
+					t.Errorf("expected requested cpu of %s to be %s, got %s", namespacedName, expected, requested.String())
+				}
+			}
+
+			p.(framework.ReservePlugin).Unreserve(context.Background(), state, tt.subscription, tt.targetClusters)
+			for namespacedName := range tt.expectedCPU {
+				cluster, err := clusterCache.Get(namespacedName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				requested := cluster.Status.Requested[corev1.ResourceCPU]
+				if requested.Cmp(resource.MustParse("1")) != 0 {
+					t.Errorf("expected requested cpu of %s to be 1 after unreserving, got %s", namespacedName, requested.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/framework/plugins/noderesources/resource_allocation.go and modified
+
+package clusterresources
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/helper"
+)
+
+// subscriptionRequestsStateKey is the key in CycleState to the total resource requests of a subscription,
+// which are shared by all the plugins in this package.
+const subscriptionRequestsStateKey framework.StateKey = "ClusterResourcesSubscriptionRequests"
+
+// subscriptionRequestsState computed at PreFilter or PreScore and used at Filter or Score.
+type subscriptionRequestsState struct {
+	requests corev1.ResourceList
+}
+
+// Clone the subscription requests state.
+func (s *subscriptionRequestsState) Clone() framework.StateData {
+	return s
+}
+
+// computeSubscriptionRequests computes the total resource requests of a subscription and writes them into
+// the CycleState, unless they have already been computed in the same scheduling cycle.
+func computeSubscriptionRequests(handle framework.Handle, state *framework.CycleState, sub *appsapi.Subscription) *framework.Status {
+	if _, err := state.Read(subscriptionRequestsStateKey); err == nil {
+		return nil
+	}
+
+	requests, err := helper.GetSubscriptionRequests(handle.SharedInformerFactory().Apps().V1alpha1().Manifests().Lister(), sub)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	state.Write(subscriptionRequestsStateKey, &subscriptionRequestsState{requests: requests})
+	return nil
+}
+
+// getSubscriptionRequests reads the total resource requests of a subscription from the CycleState.
+// They are computed here if PreFilter or PreScore of the plugin is not enabled.
+func getSubscriptionRequests(handle framework.Handle, state *framework.CycleState, sub *appsapi.Subscription) (corev1.ResourceList, error) {
+	c, err := state.Read(subscriptionRequestsStateKey)
+	if err != nil {
+		if status := computeSubscriptionRequests(handle, state, sub); !status.IsSuccess() {
+			return nil, status.AsError()
+		}
+		if c, err = state.Read(subscriptionRequestsStateKey); err != nil {
+			return nil, fmt.Errorf("error reading %q from cycleState: %w", subscriptionRequestsStateKey, err)
+		}
+	}
+
+	s, ok := c.(*subscriptionRequestsState)
+	if !ok {
+		return nil, fmt.Errorf("%+v  convert to clusterresources.subscriptionRequestsState error", c)
+	}
+	return s.requests, nil
+}
+
+// resourceToWeightMap contains resource name and weight.
+type resourceToWeightMap map[corev1.ResourceName]int64
+
+// defaultRequestedRatioResources is used to set default requestToWeight map for CPU and memory
+var defaultRequestedRatioResources = resourceToWeightMap{corev1.ResourceMemory: 1, corev1.ResourceCPU: 1}
+
+// resourceToValueMap contains resource name and score.
+type resourceToValueMap map[corev1.ResourceName]int64
+
+// resourceAllocationScorer contains information to calculate resource allocation score.
+type resourceAllocationScorer struct {
+	Name                string
+	scorer              func(requested, allocable resourceToValueMap) int64
+	resourceToWeightMap resourceToWeightMap
+}
+
+// score will use `scorer` function to calculate the score.
+func (r *resourceAllocationScorer) score(handle framework.Handle, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	cluster, err := getCluster(handle, namespacedCluster)
+	if err != nil {
+		return 0, framework.AsStatus(err)
+	}
+
+	if r.resourceToWeightMap == nil {
+		return 0, framework.NewStatus(framework.Error, "resources not found")
+	}
+
+	subRequests, err := getSubscriptionRequests(handle, state, sub)
+	if err != nil {
+		return 0, framework.AsStatus(err)
+	}
+
+	requested := make(resourceToValueMap, len(r.resourceToWeightMap))
+	allocatable := make(resourceToValueMap, len(r.resourceToWeightMap))
+	for resource := range r.resourceToWeightMap {
+		allocatable[resource], requested[resource] = calculateResourceAllocatableRequest(cluster, subRequests, resource)
+	}
+
+	score := r.scorer(requested, allocatable)
+	if klog.V(10).Enabled() {
+		klog.InfoS("Listing internal info for allocatable resources, requested resources and score", "subscription",
+			klog.KObj(sub), "cluster", klog.KObj(cluster), "resourceAllocationScorer", r.Name,
+			"allocatableResource", allocatable, "requestedResource", requested, "resourceScore", score,
+		)
+	}
+
+	return score, nil
+}
+
+// getCluster returns the cluster with the resources assumed by the subscriptions being bound,
+// which are tracked in the scheduler cache.
+func getCluster(handle framework.Handle, namespacedCluster string) (*clusterapi.ManagedCluster, error) {
+	if clusterCache := handle.ClusterCache(); clusterCache != nil {
+		cluster, err := clusterCache.Get(namespacedCluster)
+		if err != nil {
+			return nil, fmt.Errorf("getting cluster %s: %v", namespacedCluster, err)
+		}
+		return cluster, nil
+	}
+
+	// Convert the namespace/name string into a distinct namespace and name
+	ns, name, err := cache.SplitMetaNamespaceKey(namespacedCluster)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource key: %s", namespacedCluster)
+	}
+	cluster, err := handle.SharedInformerFactory().Clusters().V1beta1().ManagedClusters().Lister().ManagedClusters(ns).Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster %s: %v", namespacedCluster, err)
+	}
+	return cluster, nil
+}
+
+// calculateResourceAllocatableRequest returns resources Allocatable and Requested values.
+// The requested value contains the resources requested by the subscription.
+func calculateResourceAllocatableRequest(cluster *clusterapi.ManagedCluster, subRequests corev1.ResourceList, resource corev1.ResourceName) (int64, int64) {
+	allocatable := cluster.Status.Allocatable[resource]
+	requested := cluster.Status.Requested[resource]
+	subRequest := subRequests[resource]
+
+	switch resource {
+	case corev1.ResourceCPU:
+		return allocatable.MilliValue(), requested.MilliValue() + subRequest.MilliValue()
+	default:
+		return allocatable.Value(), requested.Value() + subRequest.Value()
+	}
+}
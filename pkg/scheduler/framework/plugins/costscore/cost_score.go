@@ -0,0 +1,159 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costscore
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/helper"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+const (
+	// preScoreStateKey is the key in CycleState to CostScore pre-computed data for scoring.
+	preScoreStateKey = "PreScore" + names.CostScore
+
+	// costScale scales the estimated cost per hour to an integer score, which keeps the precision
+	// of one millionth of the currency unit.
+	costScale = 1000000
+
+	// unpricedScore is the raw score of the clusters without pricing, which is replaced
+	// with the highest cost of all the clusters in NormalizeScore.
+	unpricedScore int64 = -1
+
+	// bytesPerGiB is the number of bytes in one GiB memory.
+	bytesPerGiB = 1 << 30
+)
+
+// CostScore is a score plugin that favors clusters where running the workloads of a subscription costs less,
+// which is estimated with the pricing of clusters.
+type CostScore struct {
+	handle framework.Handle
+}
+
+var _ framework.PreScorePlugin = &CostScore{}
+var _ framework.ScorePlugin = &CostScore{}
+
+// New initializes a new plugin and returns it.
+func New(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &CostScore{handle: h}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *CostScore) Name() string {
+	return names.CostScore
+}
+
+// preScoreState holds the resource requests of the subscription.
+type preScoreState struct {
+	requests corev1.ResourceList
+}
+
+// Clone the prescore state.
+func (s *preScoreState) Clone() framework.StateData {
+	return s
+}
+
+// PreScore invoked at the prescore extension point.
+// It computes the resource requests of a subscription once, which are used by Score for all the clusters.
+func (pl *CostScore) PreScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, _ []*clusterapi.ManagedCluster) *framework.Status {
+	requests, err := helper.GetSubscriptionRequests(pl.handle.SharedInformerFactory().Apps().V1alpha1().Manifests().Lister(), sub)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	state.Write(preScoreStateKey, &preScoreState{requests: requests})
+	return nil
+}
+
+// Score invoked at the Score extension point.
+// The raw score is the estimated cost per hour of running the workloads in the cluster,
+// which is reversed in NormalizeScore.
+func (pl *CostScore) Score(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	c, err := state.Read(preScoreStateKey)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("reading %q from cycleState: %w", preScoreStateKey, err))
+	}
+	s, ok := c.(*preScoreState)
+	if !ok {
+		return 0, framework.AsStatus(fmt.Errorf("%+v cannot be converted to costscore.preScoreState", c))
+	}
+
+	ns, name, err := cache.SplitMetaNamespaceKey(namespacedCluster)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("invalid resource key: %s", namespacedCluster))
+	}
+	cluster, err := pl.handle.SharedInformerFactory().Clusters().V1beta1().ManagedClusters().Lister().ManagedClusters(ns).Get(name)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("getting cluster %s: %v", namespacedCluster, err))
+	}
+
+	if cluster.Spec.Pricing == nil {
+		return unpricedScore, nil
+	}
+
+	cost := estimateCost(cluster.Spec.Pricing, s.requests)
+	klog.V(5).InfoS("Estimated cost of running subscription", "subscription", klog.KObj(sub),
+		"cluster", klog.KObj(cluster), "costPerHour", cost)
+	return int64(math.Round(cost * costScale)), nil
+}
+
+// NormalizeScore invoked after scoring all clusters.
+// The clusters without pricing are regarded as the most expensive ones, and the cheaper a cluster is,
+// the higher its score will be.
+func (pl *CostScore) NormalizeScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, scores framework.ClusterScoreList) *framework.Status {
+	var maxCost int64
+	for i := range scores {
+		if scores[i].Score > maxCost {
+			maxCost = scores[i].Score
+		}
+	}
+	for i := range scores {
+		if scores[i].Score == unpricedScore {
+			scores[i].Score = maxCost
+		}
+	}
+	return helper.DefaultNormalizeScore(framework.MaxClusterScore, true, scores)
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *CostScore) ScoreExtensions() framework.ScoreExtensions {
+	return pl
+}
+
+// estimateCost returns the cost per hour of the requested CPU and memory with the given pricing.
+func estimateCost(pricing *clusterapi.ClusterPricing, requests corev1.ResourceList) float64 {
+	var cost float64
+	if pricing.CPUHour != nil {
+		cpu := requests[corev1.ResourceCPU]
+		cost += float64(cpu.MilliValue()) / 1000 * pricing.CPUHour.AsApproximateFloat64()
+	}
+	if pricing.MemoryGBHour != nil {
+		memory := requests[corev1.ResourceMemory]
+		cost += float64(memory.Value()) / bytesPerGiB * pricing.MemoryGBHour.AsApproximateFloat64()
+	}
+	return cost
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costscore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	"github.com/clusternet/clusternet/pkg/known"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func makeCluster(name string, pricing *clusterapi.ClusterPricing) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns-" + name,
+		},
+		Spec: clusterapi.ManagedClusterSpec{
+			Pricing: pricing,
+		},
+	}
+}
+
+func makePricing(cpuHour, memoryGBHour string) *clusterapi.ClusterPricing {
+	cpu := resource.MustParse(cpuHour)
+	memory := resource.MustParse(memoryGBHour)
+	return &clusterapi.ClusterPricing{
+		CPUHour:      &cpu,
+		MemoryGBHour: &memory,
+	}
+}
+
+func TestCostScore(t *testing.T) {
+	manifest := &appsapi.Manifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployments.v1.apps.default.app",
+			Namespace: known.ClusternetReservedNamespace,
+			Labels: map[string]string{
+				known.ConfigGroupLabel:     "apps",
+				known.ConfigVersionLabel:   "v1",
+				known.ConfigKindLabel:      "Deployment",
+				known.ConfigNamespaceLabel: "default",
+				known.ConfigNameLabel:      "app",
+			},
+		},
+		Template: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"app","namespace":"default"},` +
+				`"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"app","image":"app",` +
+				`"resources":{"requests":{"cpu":"500m","memory":"512Mi"}}}]}}}}`),
+		},
+	}
+	sub := &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+		Spec: appsapi.SubscriptionSpec{
+			SchedulingStrategy: appsapi.ReplicaSchedulingStrategyType,
+			Feeds: []appsapi.Feed{
+				{
+					Kind:       "Deployment",
+					APIVersion: "apps/v1",
+					Namespace:  "default",
+					Name:       "app",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		clusters     []*clusterapi.ManagedCluster
+		expectedList framework.ClusterScoreList
+	}{
+		{
+			name: "cheaper clusters get higher scores",
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", makePricing("0.04", "0.005")),
+				makeCluster("c2", makePricing("0.02", "0.0025")),
+				makeCluster("c3", makePricing("0", "0")),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 0},
+				{NamespacedName: "ns-c2/c2", Score: 50},
+				{NamespacedName: "ns-c3/c3", Score: framework.MaxClusterScore},
+			},
+		},
+		{
+			name: "clusters without pricing are regarded as the most expensive ones",
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", nil),
+				makeCluster("c2", makePricing("0.02", "0.0025")),
+				makeCluster("c3", makePricing("0.01", "0.00125")),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: 0},
+				{NamespacedName: "ns-c2/c2", Score: 0},
+				{NamespacedName: "ns-c3/c3", Score: 50},
+			},
+		},
+		{
+			name: "no clusters with pricing",
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", nil),
+				makeCluster("c2", nil),
+			},
+			expectedList: []framework.ClusterScore{
+				{NamespacedName: "ns-c1/c1", Score: framework.MaxClusterScore},
+				{NamespacedName: "ns-c2/c2", Score: framework.MaxClusterScore},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0*time.Second)
+			for _, cluster := range test.clusters {
+				if err := fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(cluster); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := fakeInformerFactory.Apps().V1alpha1().Manifests().Informer().GetStore().Add(manifest); err != nil {
+				t.Fatal(err)
+			}
+			fh, err := frameworkruntime.NewFramework(nil, nil, frameworkruntime.WithInformerFactory(fakeInformerFactory))
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, _ := New(nil, fh)
+
+			state := framework.NewCycleState()
+			if status := p.(framework.PreScorePlugin).PreScore(context.Background(), state, sub, test.clusters); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+
+			var gotList framework.ClusterScoreList
+			for _, cluster := range test.clusters {
+				score, status := p.(framework.ScorePlugin).Score(context.Background(), state, sub, klog.KObj(cluster).String())
+				if !status.IsSuccess() {
+					t.Errorf("unexpected error: %v", status)
+				}
+				gotList = append(gotList, framework.ClusterScore{NamespacedName: klog.KObj(cluster).String(), Score: score})
+			}
+
+			status := p.(framework.ScorePlugin).ScoreExtensions().NormalizeScore(context.Background(), state, sub, gotList)
+			if !status.IsSuccess() {
+				t.Errorf("unexpected error: %v", status)
+			}
+
+			if !reflect.DeepEqual(test.expectedList, gotList) {
+				t.Errorf("expected:\n\t%+v,\ngot:\n\t%+v", test.expectedList, gotList)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package defaultbinder
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,19 +49,44 @@ func (pl *DefaultBinder) Name() string {
 }
 
 // Bind binds subscriptions to clusters using the clusternet client.
-func (pl *DefaultBinder) Bind(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) *framework.Status {
+func (pl *DefaultBinder) Bind(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) *framework.Status {
 	klog.V(3).InfoS("Attempting to bind subscription to clusters",
-		"subscription", klog.KObj(sub), "clusters", namespacedClusters)
+		"subscription", klog.KObj(sub), "clusters", targetClusters.BindingClusters)
 
-	// use an ordered list
-	sort.SliceStable(namespacedClusters, func(i, j int) bool {
-		return namespacedClusters[i] < namespacedClusters[j]
+	// use an ordered list, and keep the divided replicas in the same order
+	indices := make([]int, len(targetClusters.BindingClusters))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return targetClusters.BindingClusters[indices[i]] < targetClusters.BindingClusters[indices[j]]
 	})
 
+	bindingClusters := make([]string, len(indices))
+	for i, idx := range indices {
+		bindingClusters[i] = targetClusters.BindingClusters[idx]
+	}
+
+	var replicas map[string]appsapi.FeedReplicas
+	if len(targetClusters.Replicas) > 0 {
+		replicas = make(map[string]appsapi.FeedReplicas, len(targetClusters.Replicas))
+		for feedKey, feedReplicas := range targetClusters.Replicas {
+			if len(feedReplicas) != len(indices) {
+				return framework.AsStatus(fmt.Errorf("mismatched replicas of %s: %d replicas for %d clusters",
+					feedKey, len(feedReplicas), len(indices)))
+			}
+			replicas[feedKey] = make(appsapi.FeedReplicas, len(indices))
+			for i, idx := range indices {
+				replicas[feedKey][i] = feedReplicas[idx]
+			}
+		}
+	}
+
 	subCopy := sub.DeepCopy()
-	subCopy.Status.BindingClusters = namespacedClusters
+	subCopy.Status.BindingClusters = bindingClusters
+	subCopy.Status.Replicas = replicas
 	subCopy.Status.SpecHash = utils.HashSubscriptionSpec(&subCopy.Spec)
-	subCopy.Status.DesiredReleases = len(namespacedClusters)
+	subCopy.Status.DesiredReleases = len(bindingClusters)
 
 	_, err := pl.handle.ClientSet().AppsV1alpha1().Subscriptions(sub.Namespace).UpdateStatus(ctx, subCopy, metav1.UpdateOptions{})
 	if err != nil {
@@ -28,6 +28,7 @@ import (
 
 	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
 	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
 	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
 )
 
@@ -35,14 +36,20 @@ func TestDefaultBinder(t *testing.T) {
 	testSubscription := &appsapi.Subscription{
 		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "ns"},
 	}
-	testClusters := []string{
-		"cluster-ns-03/xyz",
-		"cluster-ns-01/def",
+	testClusters := framework.TargetClusters{
+		BindingClusters: []string{
+			"cluster-ns-03/xyz",
+			"cluster-ns-01/def",
+		},
+		Replicas: map[string][]int32{
+			"apps/v1/Deployment/default/nginx": {3, 1},
+		},
 	}
 	tests := []struct {
 		name           string
 		injectErr      error
 		wantedBindings []string
+		wantedReplicas map[string]appsapi.FeedReplicas
 	}{
 		{
 			name: "successful",
@@ -50,6 +57,9 @@ func TestDefaultBinder(t *testing.T) {
 				"cluster-ns-01/def",
 				"cluster-ns-03/xyz",
 			},
+			wantedReplicas: map[string]appsapi.FeedReplicas{
+				"apps/v1/Deployment/default/nginx": {1, 3},
+			},
 		}, {
 			name:      "binding error",
 			injectErr: errors.New("binding error"),
@@ -58,6 +68,7 @@ func TestDefaultBinder(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var bindedClusters []string
+			var bindedReplicas map[string]appsapi.FeedReplicas
 			client := fake.NewSimpleClientset(testSubscription)
 			client.PrependReactor("update", "subscriptions", func(action clienttesting.Action) (bool, runtime.Object, error) {
 				if action.GetSubresource() != "status" {
@@ -70,6 +81,7 @@ func TestDefaultBinder(t *testing.T) {
 				bindedSubscription := action.(clienttesting.UpdateAction).GetObject().(*appsapi.Subscription)
 				if bindedSubscription != nil {
 					bindedClusters = bindedSubscription.Status.BindingClusters
+					bindedReplicas = bindedSubscription.Status.Replicas
 				}
 				return true, bindedSubscription, nil
 			})
@@ -79,13 +91,16 @@ func TestDefaultBinder(t *testing.T) {
 				t.Fatal(err)
 			}
 			binder := &DefaultBinder{handle: fh}
-			status := binder.Bind(context.Background(), testSubscription, testClusters)
+			status := binder.Bind(context.Background(), framework.NewCycleState(), testSubscription, testClusters)
 			if got := status.AsError(); (tt.injectErr != nil) != (got != nil) {
 				t.Errorf("got error %q, want %q", got, tt.injectErr)
 			}
 			if diff := cmp.Diff(tt.wantedBindings, bindedClusters); diff != "" {
 				t.Errorf("got different binding (-want, +got): %s", diff)
 			}
+			if diff := cmp.Diff(tt.wantedReplicas, bindedReplicas); diff != "" {
+				t.Errorf("got different replicas (-want, +got): %s", diff)
+			}
 		})
 	}
 }
@@ -0,0 +1,256 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicassigner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/helper"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+	"github.com/clusternet/clusternet/pkg/utils"
+)
+
+// DynamicAssigner is a plugin that divides replicas into clusters in proportion to
+// how many replicas could be run in each cluster with its available resources.
+type DynamicAssigner struct {
+	handle framework.Handle
+}
+
+var _ framework.AssignPlugin = &DynamicAssigner{}
+
+// New creates a DynamicAssigner.
+func New(_ runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	return &DynamicAssigner{handle: handle}, nil
+}
+
+// Name returns the name of the plugin.
+func (pl *DynamicAssigner) Name() string {
+	return names.DynamicAssigner
+}
+
+// Assign divides the replicas of dividable feeds into clusters by their available resources.
+// Clusters that could run none of the replicas will be skipped.
+// With MinimizeMovement, the replicas already divided are adjusted in place.
+func (pl *DynamicAssigner) Assign(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (framework.TargetClusters, *framework.Status) {
+	if sub.Spec.SchedulingStrategy != appsapi.DividingSchedulingStrategyType ||
+		sub.Spec.DividingScheduling == nil || sub.Spec.DividingScheduling.Type != appsapi.DynamicReplicaDividingType {
+		return framework.TargetClusters{}, framework.NewStatus(framework.Skip, "")
+	}
+
+	mfstLister := pl.handle.SharedInformerFactory().Apps().V1alpha1().Manifests().Lister()
+	desiredReplicas, err := helper.GetDesiredReplicas(mfstLister, sub)
+	if err != nil {
+		return framework.TargetClusters{}, framework.AsStatus(err)
+	}
+	podRequests, err := helper.GetPodRequests(mfstLister, sub)
+	if err != nil {
+		return framework.TargetClusters{}, framework.AsStatus(err)
+	}
+
+	// use a sorted list of feed keys to get stable events
+	feedKeys := make([]string, 0, len(desiredReplicas))
+	for feedKey := range desiredReplicas {
+		feedKeys = append(feedKeys, feedKey)
+	}
+	sort.Strings(feedKeys)
+
+	clusterKeys := make([]string, len(clusters))
+	for idx, cluster := range clusters {
+		clusterKeys[idx] = klog.KObj(cluster).String()
+	}
+
+	// resources taken by the replicas this subscription is currently running in each cluster,
+	// which are reclaimable on re-dividing
+	reclaimable := make([]corev1.ResourceList, len(clusters))
+	for idx := range clusters {
+		reclaimable[idx] = getReclaimableResources(sub, clusterKeys[idx], podRequests)
+	}
+
+	minimizeMovement := sub.Spec.DividingScheduling.MinimizeMovement
+
+	// whether a cluster could run any replica of the dividable feeds
+	fitted := make([]bool, len(clusters))
+	replicas := make(map[string][]int32, len(feedKeys))
+	for _, feedKey := range feedKeys {
+		var sum int64
+		weights := make([]int64, len(clusters))
+		for idx, cluster := range clusters {
+			weights[idx] = computeAvailableReplicas(cluster, podRequests[feedKey], reclaimable[idx])
+			if weights[idx] > 0 {
+				fitted[idx] = true
+			}
+			sum += weights[idx]
+		}
+		if sum == 0 {
+			return framework.TargetClusters{}, framework.NewStatus(framework.Unschedulable,
+				fmt.Sprintf("0/%d clusters have enough resources to run a replica of %s", len(clusters), feedKey))
+		}
+		if minimizeMovement {
+			replicas[feedKey], err = helper.AdjustReplicasInPlace(desiredReplicas[feedKey],
+				helper.GetBoundReplicas(sub, feedKey, clusterKeys), weights)
+		} else {
+			replicas[feedKey], err = helper.DivideReplicasByWeights(desiredReplicas[feedKey], weights)
+		}
+		if err != nil {
+			return framework.TargetClusters{}, framework.AsStatus(err)
+		}
+		// replicas kept in place still need the cluster to be bound
+		for idx, feedReplicas := range replicas[feedKey] {
+			if feedReplicas > 0 {
+				fitted[idx] = true
+			}
+		}
+		pl.recordDividedReplicas(sub, feedKey, clusters, weights, replicas[feedKey])
+	}
+
+	result := framework.TargetClusters{
+		Replicas: make(map[string][]int32, len(replicas)),
+	}
+	var skippedClusters []string
+	for idx := range clusters {
+		if len(feedKeys) > 0 && !fitted[idx] {
+			skippedClusters = append(skippedClusters, clusterKeys[idx])
+			continue
+		}
+		result.BindingClusters = append(result.BindingClusters, clusterKeys[idx])
+		for feedKey, feedReplicas := range replicas {
+			result.Replicas[feedKey] = append(result.Replicas[feedKey], feedReplicas[idx])
+		}
+	}
+
+	if len(skippedClusters) > 0 && pl.handle.EventRecorder() != nil {
+		pl.handle.EventRecorder().Eventf(sub, corev1.EventTypeNormal, "ClustersSkipped",
+			"Skipped clusters %s, which have no enough resources to run any replica", strings.Join(skippedClusters, ","))
+	}
+	return result, nil
+}
+
+// recordDividedReplicas records an event on the subscription to explain how the replicas of a feed are divided.
+func (pl *DynamicAssigner) recordDividedReplicas(sub *appsapi.Subscription, feedKey string,
+	clusters []*clusterapi.ManagedCluster, availableReplicas []int64, replicas []int32) {
+	if pl.handle.EventRecorder() == nil {
+		return
+	}
+
+	details := make([]string, 0, len(clusters))
+	for idx, cluster := range clusters {
+		details = append(details, fmt.Sprintf("%s(available: %d, assigned: %d)",
+			klog.KObj(cluster), availableReplicas[idx], replicas[idx]))
+	}
+	pl.handle.EventRecorder().Eventf(sub, corev1.EventTypeNormal, "ReplicasDivided",
+		"Divided replicas of %s into %s", feedKey, strings.Join(details, ","))
+}
+
+// computeAvailableReplicas returns how many replicas with given resource requests could be run in the cluster,
+// which is calculated by the allocatable resources minus the requested ones, plus the reclaimable ones.
+// Each replica takes a pod as well, if the cluster reports its allocatable pods.
+// If no resources are requested, every cluster with room for a pod is regarded as able to run one replica,
+// which divides replicas evenly.
+func computeAvailableReplicas(cluster *clusterapi.ManagedCluster, requests, reclaimable corev1.ResourceList) int64 {
+	var result int64 = -1
+	for name, request := range requests {
+		if request.IsZero() || name == corev1.ResourcePods {
+			continue
+		}
+
+		available, ok := getAvailableResource(cluster, reclaimable, name)
+		if !ok || available.Sign() <= 0 {
+			return 0
+		}
+
+		var count int64
+		if name == corev1.ResourceCPU {
+			count = available.MilliValue() / request.MilliValue()
+		} else {
+			count = available.Value() / request.Value()
+		}
+		if result < 0 || count < result {
+			result = count
+		}
+	}
+	if result < 0 {
+		result = 1
+	}
+
+	if availablePods, ok := getAvailableResource(cluster, reclaimable, corev1.ResourcePods); ok && availablePods.Value() < result {
+		result = availablePods.Value()
+	}
+	if result < 0 {
+		return 0
+	}
+	return result
+}
+
+// getAvailableResource returns the allocatable resource of a cluster minus the requested one, plus the reclaimable one.
+// It returns false if the cluster does not report the resource.
+func getAvailableResource(cluster *clusterapi.ManagedCluster, reclaimable corev1.ResourceList, name corev1.ResourceName) (resource.Quantity, bool) {
+	allocatable, ok := cluster.Status.Allocatable[name]
+	if !ok {
+		return resource.Quantity{}, false
+	}
+	available := allocatable.DeepCopy()
+	if requested, ok := cluster.Status.Requested[name]; ok {
+		available.Sub(requested)
+	}
+	if quantity, ok := reclaimable[name]; ok {
+		available.Add(quantity)
+	}
+	return available, true
+}
+
+// getReclaimableResources returns the resources requested by the replicas that the subscription
+// has been divided into the given cluster, including the pods they take.
+func getReclaimableResources(sub *appsapi.Subscription, namespacedCluster string, podRequests map[string]corev1.ResourceList) corev1.ResourceList {
+	index := -1
+	for idx, bindingCluster := range sub.Status.BindingClusters {
+		if bindingCluster == namespacedCluster {
+			index = idx
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+
+	result := make(corev1.ResourceList)
+	for feedKey, feedReplicas := range sub.Status.Replicas {
+		if index >= len(feedReplicas) || feedReplicas[index] <= 0 {
+			continue
+		}
+		replicas := int64(feedReplicas[index])
+		for name, quantity := range utils.MultiplyResourceList(podRequests[feedKey], replicas) {
+			value := result[name]
+			value.Add(quantity)
+			result[name] = value
+		}
+		pods := result[corev1.ResourcePods]
+		pods.Add(*resource.NewQuantity(replicas, resource.DecimalSI))
+		result[corev1.ResourcePods] = pods
+	}
+	return result
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamicassigner
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	"github.com/clusternet/clusternet/pkg/known"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func newCluster(namespace, name string, allocatableCPU, requestedCPU string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Status: clusterapi.ManagedClusterStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse(allocatableCPU),
+			},
+			Requested: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse(requestedCPU),
+			},
+		},
+	}
+}
+
+func newDeploymentManifest() *appsapi.Manifest {
+	return &appsapi.Manifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployments.v1.apps.default.nginx",
+			Namespace: known.ClusternetReservedNamespace,
+			Labels: map[string]string{
+				known.ConfigGroupLabel:     "apps",
+				known.ConfigVersionLabel:   "v1",
+				known.ConfigKindLabel:      "Deployment",
+				known.ConfigNamespaceLabel: "default",
+				known.ConfigNameLabel:      "nginx",
+			},
+		},
+		Template: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},` +
+				`"spec":{"replicas":6,"template":{"spec":{"containers":[{"name":"nginx","image":"nginx",` +
+				`"resources":{"requests":{"cpu":"500m"}}}]}}}}`),
+		},
+	}
+}
+
+func TestDynamicAssigner(t *testing.T) {
+	subscription := &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+		Spec: appsapi.SubscriptionSpec{
+			SchedulingStrategy: appsapi.DividingSchedulingStrategyType,
+			DividingScheduling: &appsapi.DividingSchedulingStrategy{
+				Type: appsapi.DynamicReplicaDividingType,
+			},
+			Feeds: []appsapi.Feed{
+				{
+					Kind:       "Deployment",
+					APIVersion: "apps/v1",
+					Namespace:  "default",
+					Name:       "nginx",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		status   appsapi.SubscriptionStatus
+		clusters []*clusterapi.ManagedCluster
+		want     framework.TargetClusters
+		wantCode framework.Code
+	}{
+		{
+			name: "divide by available resources and skip clusters without enough resources",
+			clusters: []*clusterapi.ManagedCluster{
+				// 4 replicas could be run
+				newCluster("ns-01", "cluster-01", "4", "2"),
+				// 2 replicas could be run
+				newCluster("ns-02", "cluster-02", "2", "1"),
+				// no replicas could be run
+				newCluster("ns-03", "cluster-03", "2", "1800m"),
+			},
+			want: framework.TargetClusters{
+				BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+				Replicas: map[string][]int32{
+					"apps/v1/Deployment/default/nginx": {4, 2},
+				},
+			},
+			wantCode: framework.Success,
+		},
+		{
+			name: "resources taken by the subscription itself are reclaimable",
+			status: appsapi.SubscriptionStatus{
+				BindingClusters: []string{"ns-01/cluster-01"},
+				Replicas: map[string]appsapi.FeedReplicas{
+					"apps/v1/Deployment/default/nginx": {4},
+				},
+			},
+			clusters: []*clusterapi.ManagedCluster{
+				// 4 replicas of this subscription are running here, so 8 replicas could be run on re-dividing
+				newCluster("ns-01", "cluster-01", "4", "2"),
+				// 4 replicas could be run
+				newCluster("ns-02", "cluster-02", "4", "2"),
+			},
+			want: framework.TargetClusters{
+				BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02"},
+				Replicas: map[string][]int32{
+					"apps/v1/Deployment/default/nginx": {4, 2},
+				},
+			},
+			wantCode: framework.Success,
+		},
+		{
+			name: "no cluster has enough resources",
+			clusters: []*clusterapi.ManagedCluster{
+				newCluster("ns-01", "cluster-01", "4", "4"),
+			},
+			want:     framework.TargetClusters{},
+			wantCode: framework.Unschedulable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0*time.Second)
+			if err := fakeInformerFactory.Apps().V1alpha1().Manifests().Informer().GetStore().Add(newDeploymentManifest()); err != nil {
+				t.Fatal(err)
+			}
+
+			fh, err := frameworkruntime.NewFramework(nil, nil, frameworkruntime.WithInformerFactory(fakeInformerFactory))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sub := subscription.DeepCopy()
+			sub.Status = tt.status
+			p, _ := New(nil, fh)
+			got, status := p.(framework.AssignPlugin).Assign(context.Background(), framework.NewCycleState(), sub, tt.clusters)
+			if status.Code() != tt.wantCode {
+				t.Fatalf("unexpected status code: %v, want %v", status.Code(), tt.wantCode)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("expected:\n\t%+v,\ngot:\n\t%+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestComputeAvailableReplicas(t *testing.T) {
+	withPods := func(cluster *clusterapi.ManagedCluster, allocatable, requested int64) *clusterapi.ManagedCluster {
+		cluster.Status.Allocatable[corev1.ResourcePods] = *resource.NewQuantity(allocatable, resource.DecimalSI)
+		cluster.Status.Requested[corev1.ResourcePods] = *resource.NewQuantity(requested, resource.DecimalSI)
+		return cluster
+	}
+
+	tests := []struct {
+		name        string
+		cluster     *clusterapi.ManagedCluster
+		requests    corev1.ResourceList
+		reclaimable corev1.ResourceList
+		want        int64
+	}{
+		{
+			name:     "limited by cpu",
+			cluster:  newCluster("ns-01", "cluster-01", "4", "1"),
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			want:     3,
+		},
+		{
+			name:    "missing resource",
+			cluster: newCluster("ns-01", "cluster-01", "4", "1"),
+			requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			want: 0,
+		},
+		{
+			name:     "no requests",
+			cluster:  newCluster("ns-01", "cluster-01", "4", "4"),
+			requests: corev1.ResourceList{},
+			want:     1,
+		},
+		{
+			name:     "limited by pods",
+			cluster:  withPods(newCluster("ns-01", "cluster-01", "4", "1"), 10, 8),
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			want:     2,
+		},
+		{
+			name:     "no room for pods",
+			cluster:  withPods(newCluster("ns-01", "cluster-01", "4", "1"), 10, 10),
+			requests: corev1.ResourceList{},
+			want:     0,
+		},
+		{
+			name:     "with reclaimable resources",
+			cluster:  withPods(newCluster("ns-01", "cluster-01", "4", "3"), 10, 5),
+			requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			reclaimable: corev1.ResourceList{
+				corev1.ResourceCPU:  resource.MustParse("2"),
+				corev1.ResourcePods: resource.MustParse("2"),
+			},
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeAvailableReplicas(tt.cluster, tt.requests, tt.reclaimable); got != tt.want {
+				t.Errorf("computeAvailableReplicas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
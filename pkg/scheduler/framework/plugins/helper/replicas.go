@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	applisters "github.com/clusternet/clusternet/pkg/generated/listers/apps/v1alpha1"
+	"github.com/clusternet/clusternet/pkg/known"
+	"github.com/clusternet/clusternet/pkg/utils"
+)
+
+// GetDesiredReplicas returns the desired replicas of all the dividable feeds in a Subscription,
+// which is indexed by the feed key.
+func GetDesiredReplicas(manifestLister applisters.ManifestLister, sub *appsapi.Subscription) (map[string]int32, error) {
+	manifests, err := getDividableManifests(manifestLister, sub, false)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredReplicas := make(map[string]int32, len(manifests))
+	for feedKey, manifest := range manifests {
+		replicas, err := utils.GetWorkloadReplicas(manifest.Template.Raw)
+		if err != nil {
+			return nil, err
+		}
+		desiredReplicas[feedKey] = replicas
+	}
+	return desiredReplicas, nil
+}
+
+// GetPodRequests returns the resource requests of a single replica for all the dividable feeds in a Subscription,
+// which is indexed by the feed key.
+func GetPodRequests(manifestLister applisters.ManifestLister, sub *appsapi.Subscription) (map[string]corev1.ResourceList, error) {
+	manifests, err := getDividableManifests(manifestLister, sub, false)
+	if err != nil {
+		return nil, err
+	}
+
+	podRequests := make(map[string]corev1.ResourceList, len(manifests))
+	for feedKey, manifest := range manifests {
+		podTemplate, err := utils.GetWorkloadPodTemplate(manifest.Template.Raw)
+		if err != nil {
+			return nil, err
+		}
+		podRequests[feedKey] = utils.GetPodResourceRequests(&podTemplate.Spec)
+	}
+	return podRequests, nil
+}
+
+// GetSubscriptionRequests returns the total resource requests of the workload feeds in a Subscription
+// to be run in a single cluster. For Dividing scheduling, only one replica of each feed is counted,
+// since the replicas will be divided into multiple clusters.
+func GetSubscriptionRequests(manifestLister applisters.ManifestLister, sub *appsapi.Subscription) (corev1.ResourceList, error) {
+	manifests, err := getDividableManifests(manifestLister, sub, true)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make(corev1.ResourceList)
+	for _, manifest := range manifests {
+		podTemplate, err := utils.GetWorkloadPodTemplate(manifest.Template.Raw)
+		if err != nil {
+			return nil, err
+		}
+
+		replicas := int64(1)
+		if sub.Spec.SchedulingStrategy != appsapi.DividingSchedulingStrategyType {
+			desired, err := utils.GetWorkloadReplicas(manifest.Template.Raw)
+			if err != nil {
+				return nil, err
+			}
+			replicas = int64(desired)
+		}
+
+		for name, quantity := range utils.MultiplyResourceList(utils.GetPodResourceRequests(&podTemplate.Spec), replicas) {
+			value := requests[name]
+			value.Add(quantity)
+			requests[name] = value
+		}
+	}
+	return requests, nil
+}
+
+// GetBoundReplicas returns the replicas of a feed that have been divided into each of the given clusters
+// in the last scheduling, which are zeros for the clusters not bound.
+func GetBoundReplicas(sub *appsapi.Subscription, feedKey string, clusters []string) []int32 {
+	boundIndices := make(map[string]int, len(sub.Status.BindingClusters))
+	for idx, cluster := range sub.Status.BindingClusters {
+		boundIndices[cluster] = idx
+	}
+
+	feedReplicas := sub.Status.Replicas[feedKey]
+	result := make([]int32, len(clusters))
+	for idx, cluster := range clusters {
+		if boundIdx, ok := boundIndices[cluster]; ok && boundIdx < len(feedReplicas) {
+			result[idx] = feedReplicas[boundIdx]
+		}
+	}
+	return result
+}
+
+// AdjustReplicasInPlace adjusts the replicas divided into clusters to the desired replicas,
+// so that as few replicas as possible are moved among clusters.
+// Scaling up divides the increment into the clusters already running replicas by the weights,
+// or into all the clusters if none of them has a positive weight. Scaling down takes replicas
+// away from the clusters in proportion to their current replicas.
+func AdjustReplicasInPlace(desired int32, current []int32, weights []int64) ([]int32, error) {
+	var sum int32
+	for _, replicas := range current {
+		sum += replicas
+	}
+
+	result := make([]int32, len(current))
+	copy(result, current)
+	switch {
+	case sum > desired:
+		currentWeights := make([]int64, len(current))
+		for idx, replicas := range current {
+			currentWeights[idx] = int64(replicas)
+		}
+		decrements, err := DivideReplicasByWeights(sum-desired, currentWeights)
+		if err != nil {
+			return nil, err
+		}
+		for idx := range result {
+			result[idx] -= decrements[idx]
+		}
+	case sum < desired:
+		runningWeights := make([]int64, len(weights))
+		var hasPositiveWeight bool
+		for idx, weight := range weights {
+			if current[idx] > 0 && weight > 0 {
+				runningWeights[idx] = weight
+				hasPositiveWeight = true
+			}
+		}
+		if !hasPositiveWeight {
+			runningWeights = weights
+		}
+		increments, err := DivideReplicasByWeights(desired-sum, runningWeights)
+		if err != nil {
+			return nil, err
+		}
+		for idx := range result {
+			result[idx] += increments[idx]
+		}
+	}
+	return result, nil
+}
+
+// getDividableManifests returns the Manifests of all the dividable feeds in a Subscription,
+// which is indexed by the feed key. Nonexistent Manifests will be ignored if ignoreNotFound is true.
+func getDividableManifests(manifestLister applisters.ManifestLister, sub *appsapi.Subscription, ignoreNotFound bool) (map[string]*appsapi.Manifest, error) {
+	result := make(map[string]*appsapi.Manifest)
+	for _, feed := range sub.Spec.Feeds {
+		if !utils.IsDividableFeed(feed) {
+			continue
+		}
+
+		manifests, err := utils.ListManifestsBySelector(known.ClusternetReservedNamespace, manifestLister, feed)
+		if err != nil {
+			return nil, err
+		}
+		if len(manifests) == 0 {
+			if ignoreNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("%s is not found", utils.FormatFeed(feed))
+		}
+		result[utils.GetFeedKey(feed)] = manifests[0]
+	}
+	return result, nil
+}
+
+// DivideReplicasByWeights divides replicas in proportion to the weights.
+// The remainders are distributed one by one to the ones with the largest fractional parts,
+// ties are broken by the larger weight and then the lower index, so the result is deterministic.
+// An error is returned if there are replicas to divide but none of the weights is positive.
+func DivideReplicasByWeights(replicas int32, weights []int64) ([]int32, error) {
+	result := make([]int32, len(weights))
+	if replicas <= 0 {
+		return result, nil
+	}
+
+	var sum int64
+	for _, weight := range weights {
+		if weight > 0 {
+			sum += weight
+		}
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("cannot divide %d replicas into %d clusters with no positive weights", replicas, len(weights))
+	}
+
+	type remainder struct {
+		index    int
+		weight   int64
+		fraction int64
+	}
+	remainders := make([]remainder, 0, len(weights))
+
+	var assigned int32
+	for idx, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		product := int64(replicas) * weight
+		result[idx] = int32(product / sum)
+		assigned += result[idx]
+		remainders = append(remainders, remainder{index: idx, weight: weight, fraction: product % sum})
+	}
+
+	sort.SliceStable(remainders, func(i, j int) bool {
+		if remainders[i].fraction != remainders[j].fraction {
+			return remainders[i].fraction > remainders[j].fraction
+		}
+		return remainders[i].weight > remainders[j].weight
+	})
+	for i := 0; assigned < replicas; i++ {
+		result[remainders[i%len(remainders)].index]++
+		assigned++
+	}
+	return result, nil
+}
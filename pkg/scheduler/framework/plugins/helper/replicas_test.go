@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helper
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDivideReplicasByWeights(t *testing.T) {
+	tests := []struct {
+		name     string
+		replicas int32
+		weights  []int64
+		expected []int32
+		wantErr  bool
+	}{
+		{
+			name:     "divided evenly",
+			replicas: 6,
+			weights:  []int64{1, 2, 3},
+			expected: []int32{1, 2, 3},
+		},
+		{
+			name:     "remainders go to the largest fractional parts",
+			replicas: 10,
+			weights:  []int64{1, 1, 1},
+			expected: []int32{4, 3, 3},
+		},
+		{
+			name:     "ties are broken by larger weights",
+			replicas: 3,
+			weights:  []int64{1, 3, 2},
+			expected: []int32{0, 2, 1},
+		},
+		{
+			name:     "zero weights get nothing",
+			replicas: 5,
+			weights:  []int64{0, 2, 3},
+			expected: []int32{0, 2, 3},
+		},
+		{
+			name:     "all zero weights",
+			replicas: 5,
+			weights:  []int64{0, 0},
+			wantErr:  true,
+		},
+		{
+			name:     "all zero weights with zero replicas",
+			replicas: 0,
+			weights:  []int64{0, 0},
+			expected: []int32{0, 0},
+		},
+		{
+			name:     "zero replicas",
+			replicas: 0,
+			weights:  []int64{1, 2},
+			expected: []int32{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DivideReplicasByWeights(tt.replicas, tt.weights)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DivideReplicasByWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("unexpected replicas (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestAdjustReplicasInPlace(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  int32
+		current  []int32
+		weights  []int64
+		expected []int32
+	}{
+		{
+			name:     "unchanged",
+			desired:  6,
+			current:  []int32{2, 4, 0},
+			weights:  []int64{1, 1, 1},
+			expected: []int32{2, 4, 0},
+		},
+		{
+			name:     "scaling up the clusters already running replicas",
+			desired:  9,
+			current:  []int32{2, 4, 0},
+			weights:  []int64{1, 1, 1},
+			expected: []int32{4, 5, 0},
+		},
+		{
+			name:     "scaling up all the clusters if none is running replicas",
+			desired:  3,
+			current:  []int32{0, 0},
+			weights:  []int64{1, 2},
+			expected: []int32{1, 2},
+		},
+		{
+			name:     "scaling up all the clusters if the running ones have no weights",
+			desired:  4,
+			current:  []int32{2, 0},
+			weights:  []int64{0, 1},
+			expected: []int32{2, 2},
+		},
+		{
+			name:     "scaling down in proportion to current replicas",
+			desired:  3,
+			current:  []int32{2, 4, 0},
+			weights:  []int64{1, 1, 1},
+			expected: []int32{1, 2, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AdjustReplicasInPlace(tt.desired, tt.current, tt.weights)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("unexpected replicas (-want, +got): %s", diff)
+			}
+		})
+	}
+}
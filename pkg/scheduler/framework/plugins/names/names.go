@@ -17,7 +17,33 @@ limitations under the License.
 package names
 
 const (
+	APICompatibility = "APICompatibility"
+
+	ClusterAffinity = "ClusterAffinity"
+
+	ClusterReady = "ClusterReady"
+
+	ClusterResourcesFit = "ClusterResourcesFit"
+
+	ClusterResourcesLeastAllocated = "ClusterResourcesLeastAllocated"
+
+	ClusterResourcesMostAllocated = "ClusterResourcesMostAllocated"
+
+	ClusterResourcesRequestedToCapacityRatio = "ClusterResourcesRequestedToCapacityRatio"
+
+	ClusterResourcesReservation = "ClusterResourcesReservation"
+
+	ClusterTopologySpread = "ClusterTopologySpread"
+
+	CostScore = "CostScore"
+
 	DefaultBinder = "DefaultBinder"
 
+	DynamicAssigner = "DynamicAssigner"
+
+	StaticAssigner = "StaticAssigner"
+
+	StickyBinding = "StickyBinding"
+
 	TaintToleration = "TaintToleration"
 )
@@ -17,16 +17,38 @@ limitations under the License.
 package plugins
 
 import (
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/apicompatibility"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/clusteraffinity"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/clusterready"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/clusterresources"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/costscore"
 	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/defaultbinder"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/dynamicassigner"
 	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/staticassigner"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/stickybinding"
 	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/tainttoleration"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/topologyspread"
 	"github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
 )
 
 // NewInTreeRegistry builds the registry with all the in-tree plugins.
 func NewInTreeRegistry() runtime.Registry {
 	return runtime.Registry{
-		names.DefaultBinder:   defaultbinder.New,
-		names.TaintToleration: tainttoleration.New,
+		names.APICompatibility:                         apicompatibility.New,
+		names.ClusterAffinity:                          clusteraffinity.New,
+		names.ClusterReady:                             clusterready.New,
+		names.ClusterResourcesFit:                      clusterresources.NewFit,
+		names.ClusterResourcesLeastAllocated:           clusterresources.NewLeastAllocated,
+		names.ClusterResourcesMostAllocated:            clusterresources.NewMostAllocated,
+		names.ClusterResourcesRequestedToCapacityRatio: clusterresources.NewRequestedToCapacityRatio,
+		names.ClusterResourcesReservation:              clusterresources.NewReservation,
+		names.ClusterTopologySpread:                    topologyspread.New,
+		names.CostScore:                                costscore.New,
+		names.DefaultBinder:                            defaultbinder.New,
+		names.DynamicAssigner:                          dynamicassigner.New,
+		names.StaticAssigner:                           staticassigner.New,
+		names.StickyBinding:                            stickybinding.New,
+		names.TaintToleration:                          tainttoleration.New,
 	}
 }
@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package staticassigner
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/helper"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+// StaticAssigner is a plugin that divides replicas into clusters by the static weights of subscribers.
+type StaticAssigner struct {
+	handle framework.Handle
+}
+
+var _ framework.AssignPlugin = &StaticAssigner{}
+
+// New creates a StaticAssigner.
+func New(_ runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	return &StaticAssigner{handle: handle}, nil
+}
+
+// Name returns the name of the plugin.
+func (pl *StaticAssigner) Name() string {
+	return names.StaticAssigner
+}
+
+// Assign divides the replicas of dividable feeds into clusters by the static weights of subscribers.
+// A cluster takes the weight of the first subscriber it matches, which defaults to 1.
+// With MinimizeMovement, the replicas already divided are adjusted in place.
+func (pl *StaticAssigner) Assign(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (framework.TargetClusters, *framework.Status) {
+	if sub.Spec.SchedulingStrategy != appsapi.DividingSchedulingStrategyType {
+		return framework.TargetClusters{}, framework.NewStatus(framework.Skip, "")
+	}
+	if sub.Spec.DividingScheduling != nil && sub.Spec.DividingScheduling.Type != appsapi.StaticReplicaDividingType {
+		return framework.TargetClusters{}, framework.NewStatus(framework.Skip, "")
+	}
+
+	desiredReplicas, err := helper.GetDesiredReplicas(pl.handle.SharedInformerFactory().Apps().V1alpha1().Manifests().Lister(), sub)
+	if err != nil {
+		return framework.TargetClusters{}, framework.AsStatus(err)
+	}
+
+	weights := make([]int64, len(clusters))
+	bindingClusters := make([]string, len(clusters))
+	for idx, cluster := range clusters {
+		weight, err := getClusterWeight(sub.Spec.Subscribers, cluster)
+		if err != nil {
+			return framework.TargetClusters{}, framework.AsStatus(err)
+		}
+		weights[idx] = weight
+
+		key, err := cache.MetaNamespaceKeyFunc(cluster)
+		if err != nil {
+			return framework.TargetClusters{}, framework.AsStatus(fmt.Errorf("invalid cluster: %v", err))
+		}
+		bindingClusters[idx] = key
+	}
+
+	minimizeMovement := sub.Spec.DividingScheduling != nil && sub.Spec.DividingScheduling.MinimizeMovement
+	replicas := make(map[string][]int32, len(desiredReplicas))
+	for feedKey, desired := range desiredReplicas {
+		if minimizeMovement {
+			replicas[feedKey], err = helper.AdjustReplicasInPlace(desired,
+				helper.GetBoundReplicas(sub, feedKey, bindingClusters), weights)
+		} else {
+			replicas[feedKey], err = helper.DivideReplicasByWeights(desired, weights)
+		}
+		if err != nil {
+			return framework.TargetClusters{}, framework.NewStatus(framework.Unschedulable,
+				fmt.Sprintf("failed to divide replicas of %s: %v", feedKey, err))
+		}
+	}
+
+	return framework.TargetClusters{
+		BindingClusters: bindingClusters,
+		Replicas:        replicas,
+	}, nil
+}
+
+// getClusterWeight returns the weight of the first subscriber that matches the cluster.
+func getClusterWeight(subscribers []appsapi.Subscriber, cluster *clusterapi.ManagedCluster) (int64, error) {
+	for _, subscriber := range subscribers {
+		selector, err := metav1.LabelSelectorAsSelector(subscriber.ClusterAffinity)
+		if err != nil {
+			return 0, err
+		}
+		if !selector.Matches(labels.Set(cluster.Labels)) {
+			continue
+		}
+
+		if subscriber.Weight == nil {
+			return 1, nil
+		}
+		return int64(*subscriber.Weight), nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package staticassigner
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	"github.com/clusternet/clusternet/pkg/known"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func newCluster(namespace, name string, labels map[string]string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+}
+
+func newDeploymentManifest(name string, replicas int32) *appsapi.Manifest {
+	return &appsapi.Manifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployments.v1.apps.default." + name,
+			Namespace: known.ClusternetReservedNamespace,
+			Labels: map[string]string{
+				known.ConfigGroupLabel:     "apps",
+				known.ConfigVersionLabel:   "v1",
+				known.ConfigKindLabel:      "Deployment",
+				known.ConfigNamespaceLabel: "default",
+				known.ConfigNameLabel:      name,
+			},
+		},
+		Template: runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":%q,"namespace":"default"},"spec":{"replicas":%d}}`,
+				name, replicas)),
+		},
+	}
+}
+
+func TestStaticAssigner(t *testing.T) {
+	deployFeed := appsapi.Feed{
+		Kind:       "Deployment",
+		APIVersion: "apps/v1",
+		Namespace:  "default",
+		Name:       "nginx",
+	}
+	serviceFeed := appsapi.Feed{
+		Kind:       "Service",
+		APIVersion: "v1",
+		Namespace:  "default",
+		Name:       "nginx",
+	}
+
+	tests := []struct {
+		name         string
+		subscription *appsapi.Subscription
+		clusters     []*clusterapi.ManagedCluster
+		want         framework.TargetClusters
+		wantCode     framework.Code
+	}{
+		{
+			name: "skip replication scheduling",
+			subscription: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					SchedulingStrategy: appsapi.ReplicaSchedulingStrategyType,
+					Feeds:              []appsapi.Feed{deployFeed},
+				},
+			},
+			clusters: []*clusterapi.ManagedCluster{newCluster("ns-01", "cluster-01", nil)},
+			wantCode: framework.Skip,
+		},
+		{
+			name: "divide replicas by weights",
+			subscription: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					SchedulingStrategy: appsapi.DividingSchedulingStrategyType,
+					DividingScheduling: &appsapi.DividingSchedulingStrategy{
+						Type: appsapi.StaticReplicaDividingType,
+					},
+					Subscribers: []appsapi.Subscriber{
+						{
+							ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+							Weight:          pointer.Int32Ptr(2),
+						},
+						{
+							ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "west"}},
+						},
+					},
+					Feeds: []appsapi.Feed{deployFeed, serviceFeed},
+				},
+			},
+			clusters: []*clusterapi.ManagedCluster{
+				newCluster("ns-01", "cluster-01", map[string]string{"region": "east"}),
+				newCluster("ns-02", "cluster-02", map[string]string{"region": "west"}),
+				newCluster("ns-03", "cluster-03", map[string]string{"region": "west"}),
+			},
+			want: framework.TargetClusters{
+				BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03"},
+				Replicas: map[string][]int32{
+					"apps/v1/Deployment/default/nginx": {3, 1, 1},
+				},
+			},
+			wantCode: framework.Success,
+		},
+		{
+			name: "adjust replicas in place with minimize movement",
+			subscription: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					SchedulingStrategy: appsapi.DividingSchedulingStrategyType,
+					DividingScheduling: &appsapi.DividingSchedulingStrategy{
+						Type:             appsapi.StaticReplicaDividingType,
+						MinimizeMovement: true,
+					},
+					Subscribers: []appsapi.Subscriber{
+						{
+							ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+							Weight:          pointer.Int32Ptr(2),
+						},
+						{
+							ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "west"}},
+						},
+					},
+					Feeds: []appsapi.Feed{deployFeed},
+				},
+				Status: appsapi.SubscriptionStatus{
+					BindingClusters: []string{"ns-02/cluster-02", "ns-01/cluster-01"},
+					Replicas: map[string]appsapi.FeedReplicas{
+						"apps/v1/Deployment/default/nginx": {2, 1},
+					},
+				},
+			},
+			clusters: []*clusterapi.ManagedCluster{
+				newCluster("ns-01", "cluster-01", map[string]string{"region": "east"}),
+				newCluster("ns-02", "cluster-02", map[string]string{"region": "west"}),
+				newCluster("ns-03", "cluster-03", map[string]string{"region": "west"}),
+			},
+			want: framework.TargetClusters{
+				BindingClusters: []string{"ns-01/cluster-01", "ns-02/cluster-02", "ns-03/cluster-03"},
+				Replicas: map[string][]int32{
+					"apps/v1/Deployment/default/nginx": {2, 3, 0},
+				},
+			},
+			wantCode: framework.Success,
+		},
+		{
+			name: "no clusters with positive weights",
+			subscription: &appsapi.Subscription{
+				Spec: appsapi.SubscriptionSpec{
+					SchedulingStrategy: appsapi.DividingSchedulingStrategyType,
+					DividingScheduling: &appsapi.DividingSchedulingStrategy{
+						Type: appsapi.StaticReplicaDividingType,
+					},
+					Subscribers: []appsapi.Subscriber{
+						{
+							ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "east"}},
+						},
+					},
+					Feeds: []appsapi.Feed{deployFeed},
+				},
+			},
+			clusters: []*clusterapi.ManagedCluster{
+				newCluster("ns-01", "cluster-01", map[string]string{"region": "west"}),
+			},
+			wantCode: framework.Unschedulable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0*time.Second)
+			if err := fakeInformerFactory.Apps().V1alpha1().Manifests().Informer().GetStore().Add(newDeploymentManifest("nginx", 5)); err != nil {
+				t.Fatal(err)
+			}
+
+			fh, err := frameworkruntime.NewFramework(nil, nil, frameworkruntime.WithInformerFactory(fakeInformerFactory))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, _ := New(nil, fh)
+			got, status := p.(framework.AssignPlugin).Assign(context.Background(), framework.NewCycleState(), tt.subscription, tt.clusters)
+			if status.Code() != tt.wantCode {
+				t.Fatalf("unexpected status code: %v, want %v", status.Code(), tt.wantCode)
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("expected:\n\t%+v,\ngot:\n\t%+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stickybinding
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+// StickyBinding is a plugin that prefers the clusters a subscription is already bound to,
+// so that rescheduling won't move the workloads to other clusters unnecessarily.
+type StickyBinding struct {
+	handle framework.Handle
+}
+
+var _ framework.ScorePlugin = &StickyBinding{}
+
+// New initializes a new plugin and returns it.
+func New(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &StickyBinding{handle: h}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *StickyBinding) Name() string {
+	return names.StickyBinding
+}
+
+// Score invoked at the Score extension point.
+// The clusters in the BindingClusters of the subscription get the max score, while the others get zero.
+func (pl *StickyBinding) Score(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	for _, cluster := range sub.Status.BindingClusters {
+		if cluster == namespacedCluster {
+			return framework.MaxClusterScore, nil
+		}
+	}
+	return 0, nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *StickyBinding) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stickybinding
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+func TestStickyBindingScore(t *testing.T) {
+	sub := &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+		Status: appsapi.SubscriptionStatus{
+			BindingClusters: []string{"ns-c1/c1", "ns-c3/c3"},
+		},
+	}
+
+	expected := map[string]int64{
+		"ns-c1/c1": framework.MaxClusterScore,
+		"ns-c2/c2": 0,
+		"ns-c3/c3": framework.MaxClusterScore,
+	}
+
+	p, _ := New(nil, nil)
+	for cluster, want := range expected {
+		score, status := p.(framework.ScorePlugin).Score(context.Background(), framework.NewCycleState(), sub, cluster)
+		if !status.IsSuccess() {
+			t.Fatalf("unexpected error: %v", status)
+		}
+		if score != want {
+			t.Errorf("expected score %d for cluster %s, got %d", want, cluster, score)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologyspread
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/helper"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+)
+
+const (
+	// preFilterStateKey is the key in CycleState to ClusterTopologySpread pre-computed data for filtering.
+	preFilterStateKey = "PreFilter" + names.ClusterTopologySpread
+	// preScoreStateKey is the key in CycleState to ClusterTopologySpread pre-computed data for scoring.
+	preScoreStateKey = "PreScore" + names.ClusterTopologySpread
+
+	// ErrReasonClusterLabelNotMatch is used when a cluster doesn't have the required topology label.
+	ErrReasonClusterLabelNotMatch = "cluster(s) didn't match topology spread constraints (missing required label)"
+)
+
+// ClusterTopologySpread is a plugin that spreads a subscription across the topology domains of clusters,
+// such as regions and zones.
+// Clusters without the topology label of a DoNotSchedule constraint are filtered out, and clusters in
+// smaller topology domains are preferred, so that every domain gets represented when only a subset of
+// clusters is selected. The skew of the selected clusters and replicas is enforced by the scheduling algorithm.
+type ClusterTopologySpread struct {
+	handle framework.Handle
+}
+
+var _ framework.PreFilterPlugin = &ClusterTopologySpread{}
+var _ framework.FilterPlugin = &ClusterTopologySpread{}
+var _ framework.PreScorePlugin = &ClusterTopologySpread{}
+var _ framework.ScorePlugin = &ClusterTopologySpread{}
+
+// New initializes a new plugin and returns it.
+func New(_ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+	return &ClusterTopologySpread{handle: h}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *ClusterTopologySpread) Name() string {
+	return names.ClusterTopologySpread
+}
+
+// preFilterState holds the DoNotSchedule constraints of the subscription.
+type preFilterState struct {
+	constraints []appsapi.ClusterTopologySpreadConstraint
+}
+
+// Clone the prefilter state.
+func (s *preFilterState) Clone() framework.StateData {
+	return s
+}
+
+// preScoreState holds the constraints of the subscription, and how many feasible clusters
+// are in each topology domain of every constraint.
+type preScoreState struct {
+	constraints []appsapi.ClusterTopologySpreadConstraint
+	// clustersPerDomain is indexed by constraint, mapping a topology value to the number of clusters.
+	clustersPerDomain []map[string]int64
+}
+
+// Clone the prescore state.
+func (s *preScoreState) Clone() framework.StateData {
+	return s
+}
+
+// isHardConstraint returns whether the constraint is honored as DoNotSchedule.
+func isHardConstraint(constraint appsapi.ClusterTopologySpreadConstraint) bool {
+	return constraint.WhenUnsatisfiable != corev1.ScheduleAnyway
+}
+
+// getHardConstraints returns the DoNotSchedule constraints of the subscription.
+func getHardConstraints(sub *appsapi.Subscription) []appsapi.ClusterTopologySpreadConstraint {
+	var constraints []appsapi.ClusterTopologySpreadConstraint
+	for _, constraint := range sub.Spec.TopologySpreadConstraints {
+		if isHardConstraint(constraint) {
+			constraints = append(constraints, constraint)
+		}
+	}
+	return constraints
+}
+
+// PreFilter invoked at the prefilter extension point.
+func (pl *ClusterTopologySpread) PreFilter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription) *framework.Status {
+	state.Write(preFilterStateKey, &preFilterState{constraints: getHardConstraints(sub)})
+	return nil
+}
+
+// Filter invoked at the filter extension point.
+func (pl *ClusterTopologySpread) Filter(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) *framework.Status {
+	if cluster == nil {
+		return framework.AsStatus(fmt.Errorf("invalid cluster"))
+	}
+
+	var constraints []appsapi.ClusterTopologySpreadConstraint
+	if c, err := state.Read(preFilterStateKey); err == nil {
+		s, ok := c.(*preFilterState)
+		if !ok {
+			return framework.AsStatus(fmt.Errorf("%+v cannot be converted to topologyspread.preFilterState", c))
+		}
+		constraints = s.constraints
+	} else {
+		constraints = getHardConstraints(sub)
+	}
+
+	for _, constraint := range constraints {
+		if _, ok := cluster.Labels[constraint.TopologyKey]; !ok {
+			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonClusterLabelNotMatch)
+		}
+	}
+	return nil
+}
+
+// PreScore invoked at the prescore extension point. It counts the feasible clusters in each topology domain.
+func (pl *ClusterTopologySpread) PreScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) *framework.Status {
+	s := &preScoreState{
+		constraints:       sub.Spec.TopologySpreadConstraints,
+		clustersPerDomain: make([]map[string]int64, len(sub.Spec.TopologySpreadConstraints)),
+	}
+	for i, constraint := range s.constraints {
+		s.clustersPerDomain[i] = make(map[string]int64)
+		for _, cluster := range clusters {
+			if value, ok := cluster.Labels[constraint.TopologyKey]; ok {
+				s.clustersPerDomain[i][value]++
+			}
+		}
+	}
+	state.Write(preScoreStateKey, s)
+	return nil
+}
+
+// Score invoked at the Score extension point.
+// The returned score is the number of feasible clusters sharing the topology domains with the cluster,
+// which is reversed on normalizing, so that clusters in smaller domains are preferred.
+// A cluster without the topology label gets the largest count of all domains.
+func (pl *ClusterTopologySpread) Score(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, namespacedCluster string) (int64, *framework.Status) {
+	c, err := state.Read(preScoreStateKey)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("reading %q from cycleState: %w", preScoreStateKey, err))
+	}
+	s, ok := c.(*preScoreState)
+	if !ok {
+		return 0, framework.AsStatus(fmt.Errorf("%+v cannot be converted to topologyspread.preScoreState", c))
+	}
+	if len(s.constraints) == 0 {
+		return 0, nil
+	}
+
+	ns, name, err := cache.SplitMetaNamespaceKey(namespacedCluster)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("invalid resource key: %s", namespacedCluster))
+	}
+	cluster, err := pl.handle.SharedInformerFactory().Clusters().V1beta1().ManagedClusters().Lister().ManagedClusters(ns).Get(name)
+	if err != nil {
+		return 0, framework.AsStatus(fmt.Errorf("getting cluster %s: %v", namespacedCluster, err))
+	}
+
+	var score int64
+	for i, constraint := range s.constraints {
+		if value, ok := cluster.Labels[constraint.TopologyKey]; ok {
+			score += s.clustersPerDomain[i][value]
+			continue
+		}
+		var largest int64
+		for _, count := range s.clustersPerDomain[i] {
+			if count > largest {
+				largest = count
+			}
+		}
+		score += largest
+	}
+	return score, nil
+}
+
+// NormalizeScore invoked after scoring all clusters.
+func (pl *ClusterTopologySpread) NormalizeScore(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, scores framework.ClusterScoreList) *framework.Status {
+	return helper.DefaultNormalizeScore(framework.MaxClusterScore, true, scores)
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *ClusterTopologySpread) ScoreExtensions() framework.ScoreExtensions {
+	return pl
+}
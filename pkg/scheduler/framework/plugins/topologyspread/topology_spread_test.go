@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topologyspread
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+func makeCluster(name string, labels map[string]string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns-" + name,
+			Labels:    labels,
+		},
+	}
+}
+
+func makeSubscription(constraints ...appsapi.ClusterTopologySpreadConstraint) *appsapi.Subscription {
+	return &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+		Spec: appsapi.SubscriptionSpec{
+			TopologySpreadConstraints: constraints,
+		},
+	}
+}
+
+func TestClusterTopologySpreadFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *appsapi.Subscription
+		cluster      *clusterapi.ManagedCluster
+		wantStatus   *framework.Status
+	}{
+		{
+			name:         "no constraints",
+			subscription: makeSubscription(),
+			cluster:      makeCluster("c1", nil),
+		},
+		{
+			name:         "cluster with the topology label",
+			subscription: makeSubscription(appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region"}),
+			cluster:      makeCluster("c1", map[string]string{"region": "r1"}),
+		},
+		{
+			name:         "cluster without the topology label of a DoNotSchedule constraint",
+			subscription: makeSubscription(appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.DoNotSchedule}),
+			cluster:      makeCluster("c1", map[string]string{"zone": "z1"}),
+			wantStatus:   framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonClusterLabelNotMatch),
+		},
+		{
+			name:         "cluster without the topology label of a ScheduleAnyway constraint",
+			subscription: makeSubscription(appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.ScheduleAnyway}),
+			cluster:      makeCluster("c1", map[string]string{"zone": "z1"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := New(nil, nil)
+			state := framework.NewCycleState()
+			if status := p.(framework.PreFilterPlugin).PreFilter(context.Background(), state, tt.subscription); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			gotStatus := p.(framework.FilterPlugin).Filter(context.Background(), state, tt.subscription, tt.cluster)
+			if !reflect.DeepEqual(gotStatus, tt.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClusterTopologySpreadScore(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *appsapi.Subscription
+		clusters     []*clusterapi.ManagedCluster
+		expectedList framework.ClusterScoreList
+	}{
+		{
+			name:         "no constraints",
+			subscription: makeSubscription(),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", map[string]string{"region": "r1"}),
+				makeCluster("c2", map[string]string{"region": "r2"}),
+			},
+			expectedList: framework.ClusterScoreList{
+				{NamespacedName: "ns-c1/c1", Score: framework.MaxClusterScore},
+				{NamespacedName: "ns-c2/c2", Score: framework.MaxClusterScore},
+			},
+		},
+		{
+			name:         "clusters in smaller regions are preferred",
+			subscription: makeSubscription(appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.ScheduleAnyway}),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", map[string]string{"region": "r1"}),
+				makeCluster("c2", map[string]string{"region": "r1"}),
+				makeCluster("c3", map[string]string{"region": "r1"}),
+				makeCluster("c4", map[string]string{"region": "r2"}),
+				makeCluster("c5", nil),
+			},
+			expectedList: framework.ClusterScoreList{
+				{NamespacedName: "ns-c1/c1", Score: 0},
+				{NamespacedName: "ns-c2/c2", Score: 0},
+				{NamespacedName: "ns-c3/c3", Score: 0},
+				{NamespacedName: "ns-c4/c4", Score: 67},
+				{NamespacedName: "ns-c5/c5", Score: 0},
+			},
+		},
+		{
+			name: "scores of multiple constraints are added up",
+			subscription: makeSubscription(
+				appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "region"},
+				appsapi.ClusterTopologySpreadConstraint{MaxSkew: 1, TopologyKey: "zone"},
+			),
+			clusters: []*clusterapi.ManagedCluster{
+				makeCluster("c1", map[string]string{"region": "r1", "zone": "z1"}),
+				makeCluster("c2", map[string]string{"region": "r1", "zone": "z2"}),
+				makeCluster("c3", map[string]string{"region": "r2", "zone": "z3"}),
+				makeCluster("c4", map[string]string{"region": "r2", "zone": "z3"}),
+			},
+			expectedList: framework.ClusterScoreList{
+				{NamespacedName: "ns-c1/c1", Score: 25},
+				{NamespacedName: "ns-c2/c2", Score: 25},
+				{NamespacedName: "ns-c3/c3", Score: 0},
+				{NamespacedName: "ns-c4/c4", Score: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeInformerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+			for _, cluster := range tt.clusters {
+				if err := fakeInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(cluster); err != nil {
+					t.Fatal(err)
+				}
+			}
+			fh, err := runtime.NewFramework(nil, nil, runtime.WithInformerFactory(fakeInformerFactory))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, _ := New(nil, fh)
+			state := framework.NewCycleState()
+			if status := p.(framework.PreScorePlugin).PreScore(context.Background(), state, tt.subscription, tt.clusters); !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			var gotList framework.ClusterScoreList
+			for _, cluster := range tt.clusters {
+				score, status := p.(framework.ScorePlugin).Score(context.Background(), state, tt.subscription, klog.KObj(cluster).String())
+				if !status.IsSuccess() {
+					t.Fatalf("unexpected error: %v", status)
+				}
+				gotList = append(gotList, framework.ClusterScore{NamespacedName: klog.KObj(cluster).String(), Score: score})
+			}
+
+			status := p.(framework.ScorePlugin).ScoreExtensions().NormalizeScore(context.Background(), state, tt.subscription, gotList)
+			if !status.IsSuccess() {
+				t.Fatalf("unexpected error: %v", status)
+			}
+			if !reflect.DeepEqual(gotList, tt.expectedList) {
+				t.Errorf("expected:\n\t%+v,\ngot:\n\t%+v", tt.expectedList, gotList)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"time"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	restclient "k8s.io/client-go/rest"
@@ -35,6 +36,7 @@ import (
 	clusternet "github.com/clusternet/clusternet/pkg/generated/clientset/versioned"
 	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
 	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	schedulercache "github.com/clusternet/clusternet/pkg/scheduler/cache"
 	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
 	"github.com/clusternet/clusternet/pkg/scheduler/metrics"
 	"github.com/clusternet/clusternet/pkg/scheduler/parallelize"
@@ -50,6 +52,7 @@ const (
 	preScore                = "PreScore"
 	score                   = "Score"
 	scoreExtensionNormalize = "ScoreExtensionNormalize"
+	assign                  = "Assign"
 	preBind                 = "PreBind"
 	bind                    = "Bind"
 	postBind                = "PostBind"
@@ -68,6 +71,7 @@ type frameworkImpl struct {
 	postFilterPlugins    []framework.PostFilterPlugin
 	preScorePlugins      []framework.PreScorePlugin
 	scorePlugins         []framework.ScorePlugin
+	assignPlugins        []framework.AssignPlugin
 	reservePlugins       []framework.ReservePlugin
 	preBindPlugins       []framework.PreBindPlugin
 	bindPlugins          []framework.BindPlugin
@@ -78,9 +82,11 @@ type frameworkImpl struct {
 	kubeConfig      *restclient.Config
 	eventRecorder   record.EventRecorder
 	informerFactory informers.SharedInformerFactory
+	clusterCache    schedulercache.Cache
 
-	metricsRecorder *metricsRecorder
-	profileName     string
+	metricsRecorder             *metricsRecorder
+	profileName                 string
+	percentageOfClustersToScore int32
 
 	parallelizer parallelize.Parallelizer
 
@@ -108,6 +114,7 @@ func (f *frameworkImpl) getExtensionPoints(plugins *schedulerapis.Plugins) []ext
 		{&plugins.Reserve, &f.reservePlugins},
 		{&plugins.PreScore, &f.preScorePlugins},
 		{&plugins.Score, &f.scorePlugins},
+		{&plugins.Assign, &f.assignPlugins},
 		{&plugins.PreBind, &f.preBindPlugins},
 		{&plugins.Bind, &f.bindPlugins},
 		{&plugins.PostBind, &f.postBindPlugins},
@@ -120,6 +127,7 @@ type frameworkOptions struct {
 	kubeConfig      *restclient.Config
 	eventRecorder   record.EventRecorder
 	informerFactory informers.SharedInformerFactory
+	clusterCache    schedulercache.Cache
 	runAllFilters   bool
 	parallelizer    parallelize.Parallelizer
 	metricsRecorder *metricsRecorder
@@ -156,6 +164,13 @@ func WithInformerFactory(informerFactory informers.SharedInformerFactory) Option
 	}
 }
 
+// WithClusterCache sets the scheduler cache of clusters for the scheduling frameworkImpl.
+func WithClusterCache(clusterCache schedulercache.Cache) Option {
+	return func(o *frameworkOptions) {
+		o.clusterCache = clusterCache
+	}
+}
+
 // WithRunAllFilters sets the runAllFilters flag, which means RunFilterPlugins accumulates
 // all failure Statuses.
 func WithRunAllFilters(runAllFilters bool) Option {
@@ -181,7 +196,7 @@ func defaultFrameworkOptions() frameworkOptions {
 var _ framework.Framework = &frameworkImpl{}
 
 // NewFramework initializes plugins given the configuration and the registry.
-func NewFramework(r Registry, plugins *schedulerapis.Plugins, opts ...Option) (framework.Framework, error) {
+func NewFramework(r Registry, profile *schedulerapis.ClusternetSchedulerProfile, opts ...Option) (framework.Framework, error) {
 	options := defaultFrameworkOptions()
 	for _, opt := range opts {
 		opt(&options)
@@ -194,20 +209,50 @@ func NewFramework(r Registry, plugins *schedulerapis.Plugins, opts ...Option) (f
 		kubeConfig:           options.kubeConfig,
 		eventRecorder:        options.eventRecorder,
 		informerFactory:      options.informerFactory,
+		clusterCache:         options.clusterCache,
 		metricsRecorder:      options.metricsRecorder,
 		runAllFilters:        options.runAllFilters,
 		parallelizer:         options.parallelizer,
-		profileName:          "default",
+		profileName:          schedulerapis.DefaultSchedulerName,
 	}
 
-	if r == nil {
+	if profile == nil {
 		return f, nil
 	}
 
+	if len(profile.SchedulerName) > 0 {
+		f.profileName = profile.SchedulerName
+	}
+	f.percentageOfClustersToScore = profile.PercentageOfClustersToScore
+	if r == nil || profile.Plugins == nil {
+		return f, nil
+	}
+	plugins := profile.Plugins
+
+	// get needed plugins from config
+	pg := sets.NewString(plugins.Names()...)
+
+	pluginConfig := make(map[string]runtime.Object, len(profile.PluginConfig))
+	for i := range profile.PluginConfig {
+		name := profile.PluginConfig[i].Name
+		if _, ok := pluginConfig[name]; ok {
+			return nil, fmt.Errorf("repeated config for plugin %s", name)
+		}
+		if !pg.Has(name) {
+			return nil, fmt.Errorf("config for plugin %s is set, but the plugin is not enabled", name)
+		}
+		pluginConfig[name] = profile.PluginConfig[i].Args
+	}
+
 	// initialize plugins per individual extension points
 	pluginsMap := make(map[string]framework.Plugin)
 	for name, factory := range r {
-		p, err := factory(nil, f)
+		// initialize only needed plugins.
+		if !pg.Has(name) {
+			continue
+		}
+
+		p, err := factory(pluginConfig[name], f)
 		if err != nil {
 			return nil, fmt.Errorf("initializing plugin %q: %w", name, err)
 		}
@@ -267,13 +312,13 @@ func updatePluginList(pluginList interface{}, pluginSet schedulerapis.PluginSet,
 // *Status and its code is set to non-success if any of the plugins returns
 // anything but Success. If a non-success status is returned, then the scheduling
 // cycle is aborted.
-func (f *frameworkImpl) RunPreFilterPlugins(ctx context.Context, sub *appsapi.Subscription) (status *framework.Status) {
+func (f *frameworkImpl) RunPreFilterPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription) (status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(preFilter, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
 	}()
 	for _, pl := range f.preFilterPlugins {
-		status = f.runPreFilterPlugin(ctx, pl, sub)
+		status = f.runPreFilterPlugin(ctx, state, pl, sub)
 		if !status.IsSuccess() {
 			status.SetFailedPlugin(pl.Name())
 			if status.IsUnschedulable() {
@@ -286,9 +331,9 @@ func (f *frameworkImpl) RunPreFilterPlugins(ctx context.Context, sub *appsapi.Su
 	return nil
 }
 
-func (f *frameworkImpl) runPreFilterPlugin(ctx context.Context, pl framework.PreFilterPlugin, sub *appsapi.Subscription) *framework.Status {
+func (f *frameworkImpl) runPreFilterPlugin(ctx context.Context, state *framework.CycleState, pl framework.PreFilterPlugin, sub *appsapi.Subscription) *framework.Status {
 	startTime := time.Now()
-	status := pl.PreFilter(ctx, sub)
+	status := pl.PreFilter(ctx, state, sub)
 	f.metricsRecorder.observePluginDurationAsync(preFilter, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return status
 }
@@ -297,10 +342,10 @@ func (f *frameworkImpl) runPreFilterPlugin(ctx context.Context, pl framework.Pre
 // the given cluster. If any of these plugins doesn't return "Success", the
 // given cluster is not suitable for running subscription.
 // Meanwhile, the failure message and status are set for the given cluster.
-func (f *frameworkImpl) RunFilterPlugins(ctx context.Context, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) framework.PluginToStatus {
+func (f *frameworkImpl) RunFilterPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) framework.PluginToStatus {
 	statuses := make(framework.PluginToStatus)
 	for _, pl := range f.filterPlugins {
-		pluginStatus := f.runFilterPlugin(ctx, pl, sub, cluster)
+		pluginStatus := f.runFilterPlugin(ctx, state, pl, sub, cluster)
 		if !pluginStatus.IsSuccess() {
 			if !pluginStatus.IsUnschedulable() {
 				// Filter plugins are not supposed to return any status other than
@@ -320,16 +365,16 @@ func (f *frameworkImpl) RunFilterPlugins(ctx context.Context, sub *appsapi.Subsc
 	return statuses
 }
 
-func (f *frameworkImpl) runFilterPlugin(ctx context.Context, pl framework.FilterPlugin, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) *framework.Status {
+func (f *frameworkImpl) runFilterPlugin(ctx context.Context, state *framework.CycleState, pl framework.FilterPlugin, sub *appsapi.Subscription, cluster *clusterapi.ManagedCluster) *framework.Status {
 	startTime := time.Now()
-	status := pl.Filter(ctx, sub, cluster)
+	status := pl.Filter(ctx, state, sub, cluster)
 	f.metricsRecorder.observePluginDurationAsync(Filter, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return status
 }
 
 // RunPostFilterPlugins runs the set of configured PostFilter plugins until the first
 // Success or Error is met, otherwise continues to execute all plugins.
-func (f *frameworkImpl) RunPostFilterPlugins(ctx context.Context, sub *appsapi.Subscription, filteredClusterStatusMap framework.ClusterToStatusMap) (_ *framework.PostFilterResult, status *framework.Status) {
+func (f *frameworkImpl) RunPostFilterPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, filteredClusterStatusMap framework.ClusterToStatusMap) (_ *framework.PostFilterResult, status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(postFilter, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
@@ -337,7 +382,7 @@ func (f *frameworkImpl) RunPostFilterPlugins(ctx context.Context, sub *appsapi.S
 
 	statuses := make(framework.PluginToStatus)
 	for _, pl := range f.postFilterPlugins {
-		r, s := f.runPostFilterPlugin(ctx, pl, sub, filteredClusterStatusMap)
+		r, s := f.runPostFilterPlugin(ctx, state, pl, sub, filteredClusterStatusMap)
 		if s.IsSuccess() {
 			return r, s
 		} else if !s.IsUnschedulable() {
@@ -350,22 +395,22 @@ func (f *frameworkImpl) RunPostFilterPlugins(ctx context.Context, sub *appsapi.S
 	return nil, statuses.Merge()
 }
 
-func (f *frameworkImpl) runPostFilterPlugin(ctx context.Context, pl framework.PostFilterPlugin, sub *appsapi.Subscription, filteredClusterStatusMap framework.ClusterToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+func (f *frameworkImpl) runPostFilterPlugin(ctx context.Context, state *framework.CycleState, pl framework.PostFilterPlugin, sub *appsapi.Subscription, filteredClusterStatusMap framework.ClusterToStatusMap) (*framework.PostFilterResult, *framework.Status) {
 	startTime := time.Now()
-	r, s := pl.PostFilter(ctx, sub, filteredClusterStatusMap)
+	r, s := pl.PostFilter(ctx, state, sub, filteredClusterStatusMap)
 	f.metricsRecorder.observePluginDurationAsync(postFilter, pl.Name(), s, metrics.SinceInSeconds(startTime))
 	return r, s
 }
 
 // RunPreScorePlugins runs the set of configured pre-score plugins. If any
 // of these plugins returns any status other than "Success", the given subscription is rejected.
-func (f *frameworkImpl) RunPreScorePlugins(ctx context.Context, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (status *framework.Status) {
+func (f *frameworkImpl) RunPreScorePlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(preScore, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
 	}()
 	for _, pl := range f.preScorePlugins {
-		status = f.runPreScorePlugin(ctx, pl, sub, clusters)
+		status = f.runPreScorePlugin(ctx, state, pl, sub, clusters)
 		if !status.IsSuccess() {
 			return framework.AsStatus(fmt.Errorf("running PreScore plugin %q: %w", pl.Name(), status.AsError()))
 		}
@@ -374,9 +419,9 @@ func (f *frameworkImpl) RunPreScorePlugins(ctx context.Context, sub *appsapi.Sub
 	return nil
 }
 
-func (f *frameworkImpl) runPreScorePlugin(ctx context.Context, pl framework.PreScorePlugin, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) *framework.Status {
+func (f *frameworkImpl) runPreScorePlugin(ctx context.Context, state *framework.CycleState, pl framework.PreScorePlugin, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) *framework.Status {
 	startTime := time.Now()
-	status := pl.PreScore(ctx, sub, clusters)
+	status := pl.PreScore(ctx, state, sub, clusters)
 	f.metricsRecorder.observePluginDurationAsync(preScore, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return status
 }
@@ -385,7 +430,7 @@ func (f *frameworkImpl) runPreScorePlugin(ctx context.Context, pl framework.PreS
 // stores for each scoring plugin name the corresponding  ClusterScoreList(s).
 // It also returns *Status, which is set to non-success if any of the plugins returns
 // a non-success status.
-func (f *frameworkImpl) RunScorePlugins(ctx context.Context, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (ps framework.PluginToClusterScores, status *framework.Status) {
+func (f *frameworkImpl) RunScorePlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (ps framework.PluginToClusterScores, status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(score, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
@@ -401,7 +446,7 @@ func (f *frameworkImpl) RunScorePlugins(ctx context.Context, sub *appsapi.Subscr
 	f.Parallelizer().Until(ctx, len(clusters), func(index int) {
 		for _, pl := range f.scorePlugins {
 			clusterNamespacedName := klog.KObj(clusters[index]).String()
-			s, status := f.runScorePlugin(ctx, pl, sub, clusterNamespacedName)
+			s, status := f.runScorePlugin(ctx, state, pl, sub, clusterNamespacedName)
 			if !status.IsSuccess() {
 				err := fmt.Errorf("plugin %q failed with: %w", pl.Name(), status.AsError())
 				errCh.SendErrorWithCancel(err, cancel)
@@ -424,7 +469,7 @@ func (f *frameworkImpl) RunScorePlugins(ctx context.Context, sub *appsapi.Subscr
 		if pl.ScoreExtensions() == nil {
 			return
 		}
-		status := f.runScoreExtension(ctx, pl, ClusterScoreList)
+		status := f.runScoreExtension(ctx, state, pl, sub, ClusterScoreList)
 		if !status.IsSuccess() {
 			err := fmt.Errorf("plugin %q failed with: %w", pl.Name(), status.AsError())
 			errCh.SendErrorWithCancel(err, cancel)
@@ -459,30 +504,65 @@ func (f *frameworkImpl) RunScorePlugins(ctx context.Context, sub *appsapi.Subscr
 	return pluginToClusterScores, nil
 }
 
-func (f *frameworkImpl) runScorePlugin(ctx context.Context, pl framework.ScorePlugin, sub *appsapi.Subscription, clusterNamespace string) (int64, *framework.Status) {
+func (f *frameworkImpl) runScorePlugin(ctx context.Context, state *framework.CycleState, pl framework.ScorePlugin, sub *appsapi.Subscription, clusterNamespace string) (int64, *framework.Status) {
 	startTime := time.Now()
-	s, status := pl.Score(ctx, sub, clusterNamespace)
+	s, status := pl.Score(ctx, state, sub, clusterNamespace)
 	f.metricsRecorder.observePluginDurationAsync(score, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return s, status
 }
 
-func (f *frameworkImpl) runScoreExtension(ctx context.Context, pl framework.ScorePlugin, ClusterScoreList framework.ClusterScoreList) *framework.Status {
+func (f *frameworkImpl) runScoreExtension(ctx context.Context, state *framework.CycleState, pl framework.ScorePlugin, sub *appsapi.Subscription, ClusterScoreList framework.ClusterScoreList) *framework.Status {
 	startTime := time.Now()
-	status := pl.ScoreExtensions().NormalizeScore(ctx, ClusterScoreList)
+	status := pl.ScoreExtensions().NormalizeScore(ctx, state, sub, ClusterScoreList)
 	f.metricsRecorder.observePluginDurationAsync(scoreExtensionNormalize, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return status
 }
 
+// RunAssignPlugins runs the set of configured assign plugins until one returns a non `Skip` status.
+func (f *frameworkImpl) RunAssignPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (result framework.TargetClusters, status *framework.Status) {
+	startTime := time.Now()
+	defer func() {
+		metrics.FrameworkExtensionPointDuration.WithLabelValues(assign, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
+	}()
+	if len(f.assignPlugins) == 0 {
+		return result, framework.NewStatus(framework.Skip, "")
+	}
+	for _, pl := range f.assignPlugins {
+		result, status = f.runAssignPlugin(ctx, state, pl, sub, clusters)
+		if status != nil && status.Code() == framework.Skip {
+			continue
+		}
+		if !status.IsSuccess() {
+			if status.IsUnschedulable() {
+				status.SetFailedPlugin(pl.Name())
+				return result, status
+			}
+			err := status.AsError()
+			klog.ErrorS(err, "Failed running Assign plugin", "plugin", pl.Name(), "subscription", klog.KObj(sub))
+			return result, framework.AsStatus(fmt.Errorf("running Assign plugin %q: %w", pl.Name(), err)).WithFailedPlugin(pl.Name())
+		}
+		return result, status
+	}
+	return result, status
+}
+
+func (f *frameworkImpl) runAssignPlugin(ctx context.Context, state *framework.CycleState, pl framework.AssignPlugin, sub *appsapi.Subscription, clusters []*clusterapi.ManagedCluster) (framework.TargetClusters, *framework.Status) {
+	startTime := time.Now()
+	result, status := pl.Assign(ctx, state, sub, clusters)
+	f.metricsRecorder.observePluginDurationAsync(assign, pl.Name(), status, metrics.SinceInSeconds(startTime))
+	return result, status
+}
+
 // RunPreBindPlugins runs the set of configured prebind plugins. It returns a
 // failure (bool) if any of the plugins returns an error. It also returns an
 // error containing the rejection message or the error occurred in the plugin.
-func (f *frameworkImpl) RunPreBindPlugins(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) (status *framework.Status) {
+func (f *frameworkImpl) RunPreBindPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) (status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(preBind, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
 	}()
 	for _, pl := range f.preBindPlugins {
-		status = f.runPreBindPlugin(ctx, pl, sub, namespacedClusters)
+		status = f.runPreBindPlugin(ctx, state, pl, sub, targetClusters)
 		if !status.IsSuccess() {
 			err := status.AsError()
 			klog.ErrorS(err, "Failed running PreBind plugin", "plugin", pl.Name(), "sub", klog.KObj(sub))
@@ -492,15 +572,15 @@ func (f *frameworkImpl) RunPreBindPlugins(ctx context.Context, sub *appsapi.Subs
 	return nil
 }
 
-func (f *frameworkImpl) runPreBindPlugin(ctx context.Context, pl framework.PreBindPlugin, sub *appsapi.Subscription, namespacedClusters []string) *framework.Status {
+func (f *frameworkImpl) runPreBindPlugin(ctx context.Context, state *framework.CycleState, pl framework.PreBindPlugin, sub *appsapi.Subscription, targetClusters framework.TargetClusters) *framework.Status {
 	startTime := time.Now()
-	status := pl.PreBind(ctx, sub, namespacedClusters)
+	status := pl.PreBind(ctx, state, sub, targetClusters)
 	f.metricsRecorder.observePluginDurationAsync(preBind, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return status
 }
 
 // RunBindPlugins runs the set of configured bind plugins until one returns a non `Skip` status.
-func (f *frameworkImpl) RunBindPlugins(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) (status *framework.Status) {
+func (f *frameworkImpl) RunBindPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) (status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(bind, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
@@ -509,7 +589,7 @@ func (f *frameworkImpl) RunBindPlugins(ctx context.Context, sub *appsapi.Subscri
 		return framework.NewStatus(framework.Skip, "")
 	}
 	for _, bp := range f.bindPlugins {
-		status = f.runBindPlugin(ctx, bp, sub, namespacedClusters)
+		status = f.runBindPlugin(ctx, state, bp, sub, targetClusters)
 		if status != nil && status.Code() == framework.Skip {
 			continue
 		}
@@ -523,27 +603,27 @@ func (f *frameworkImpl) RunBindPlugins(ctx context.Context, sub *appsapi.Subscri
 	return status
 }
 
-func (f *frameworkImpl) runBindPlugin(ctx context.Context, bp framework.BindPlugin, sub *appsapi.Subscription, namespacedClusters []string) *framework.Status {
+func (f *frameworkImpl) runBindPlugin(ctx context.Context, state *framework.CycleState, bp framework.BindPlugin, sub *appsapi.Subscription, targetClusters framework.TargetClusters) *framework.Status {
 	startTime := time.Now()
-	status := bp.Bind(ctx, sub, namespacedClusters)
+	status := bp.Bind(ctx, state, sub, targetClusters)
 	f.metricsRecorder.observePluginDurationAsync(bind, bp.Name(), status, metrics.SinceInSeconds(startTime))
 	return status
 }
 
 // RunPostBindPlugins runs the set of configured postbind plugins.
-func (f *frameworkImpl) RunPostBindPlugins(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) {
+func (f *frameworkImpl) RunPostBindPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(postBind, framework.Success.String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
 	}()
 	for _, pl := range f.postBindPlugins {
-		f.runPostBindPlugin(ctx, pl, sub, namespacedClusters)
+		f.runPostBindPlugin(ctx, state, pl, sub, targetClusters)
 	}
 }
 
-func (f *frameworkImpl) runPostBindPlugin(ctx context.Context, pl framework.PostBindPlugin, sub *appsapi.Subscription, namespacedClusters []string) {
+func (f *frameworkImpl) runPostBindPlugin(ctx context.Context, state *framework.CycleState, pl framework.PostBindPlugin, sub *appsapi.Subscription, targetClusters framework.TargetClusters) {
 	startTime := time.Now()
-	pl.PostBind(ctx, sub, namespacedClusters)
+	pl.PostBind(ctx, state, sub, targetClusters)
 	f.metricsRecorder.observePluginDurationAsync(postBind, pl.Name(), nil, metrics.SinceInSeconds(startTime))
 }
 
@@ -552,13 +632,13 @@ func (f *frameworkImpl) runPostBindPlugin(ctx context.Context, pl framework.Post
 // continue running the remaining ones and returns the error. In such a case,
 // the subscription will not be scheduled and the caller will be expected to call
 // RunReservePluginsUnreserve.
-func (f *frameworkImpl) RunReservePluginsReserve(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) (status *framework.Status) {
+func (f *frameworkImpl) RunReservePluginsReserve(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) (status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(reserve, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
 	}()
 	for _, pl := range f.reservePlugins {
-		status = f.runReservePluginReserve(ctx, pl, sub, namespacedClusters)
+		status = f.runReservePluginReserve(ctx, state, pl, sub, targetClusters)
 		if !status.IsSuccess() {
 			err := status.AsError()
 			klog.ErrorS(err, "Failed running Reserve plugin", "plugin", pl.Name(), "subscription", klog.KObj(sub))
@@ -568,16 +648,16 @@ func (f *frameworkImpl) RunReservePluginsReserve(ctx context.Context, sub *appsa
 	return nil
 }
 
-func (f *frameworkImpl) runReservePluginReserve(ctx context.Context, pl framework.ReservePlugin, sub *appsapi.Subscription, namespacedClusters []string) *framework.Status {
+func (f *frameworkImpl) runReservePluginReserve(ctx context.Context, state *framework.CycleState, pl framework.ReservePlugin, sub *appsapi.Subscription, targetClusters framework.TargetClusters) *framework.Status {
 	startTime := time.Now()
-	status := pl.Reserve(ctx, sub, namespacedClusters)
+	status := pl.Reserve(ctx, state, sub, targetClusters)
 	f.metricsRecorder.observePluginDurationAsync(reserve, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return status
 }
 
 // RunReservePluginsUnreserve runs the Unreserve method in the set of
 // configured reserve plugins.
-func (f *frameworkImpl) RunReservePluginsUnreserve(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) {
+func (f *frameworkImpl) RunReservePluginsUnreserve(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(unreserve, framework.Success.String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
@@ -585,13 +665,13 @@ func (f *frameworkImpl) RunReservePluginsUnreserve(ctx context.Context, sub *app
 	// Execute the Unreserve operation of each reserve plugin in the
 	// *reverse* order in which the Reserve operation was executed.
 	for i := len(f.reservePlugins) - 1; i >= 0; i-- {
-		f.runReservePluginUnreserve(ctx, f.reservePlugins[i], sub, namespacedClusters)
+		f.runReservePluginUnreserve(ctx, state, f.reservePlugins[i], sub, targetClusters)
 	}
 }
 
-func (f *frameworkImpl) runReservePluginUnreserve(ctx context.Context, pl framework.ReservePlugin, sub *appsapi.Subscription, namespacedClusters []string) {
+func (f *frameworkImpl) runReservePluginUnreserve(ctx context.Context, state *framework.CycleState, pl framework.ReservePlugin, sub *appsapi.Subscription, targetClusters framework.TargetClusters) {
 	startTime := time.Now()
-	pl.Unreserve(ctx, sub, namespacedClusters)
+	pl.Unreserve(ctx, state, sub, targetClusters)
 	f.metricsRecorder.observePluginDurationAsync(unreserve, pl.Name(), nil, metrics.SinceInSeconds(startTime))
 }
 
@@ -601,7 +681,7 @@ func (f *frameworkImpl) runReservePluginUnreserve(ctx context.Context, pl framew
 // plugins returns "Wait", then this function will create and add waiting subscription
 // to a map of currently waiting subs and return status with "Wait" code.
 // Subscription will remain waiting subscription for the minimum duration returned by the permit plugins.
-func (f *frameworkImpl) RunPermitPlugins(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) (status *framework.Status) {
+func (f *frameworkImpl) RunPermitPlugins(ctx context.Context, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) (status *framework.Status) {
 	startTime := time.Now()
 	defer func() {
 		metrics.FrameworkExtensionPointDuration.WithLabelValues(permit, status.Code().String(), f.profileName).Observe(metrics.SinceInSeconds(startTime))
@@ -609,7 +689,7 @@ func (f *frameworkImpl) RunPermitPlugins(ctx context.Context, sub *appsapi.Subsc
 	pluginsWaitTime := make(map[string]time.Duration)
 	statusCode := framework.Success
 	for _, pl := range f.permitPlugins {
-		status, timeout := f.runPermitPlugin(ctx, pl, sub, namespacedClusters)
+		status, timeout := f.runPermitPlugin(ctx, state, pl, sub, targetClusters)
 		if !status.IsSuccess() {
 			if status.IsUnschedulable() {
 				msg := fmt.Sprintf("rejected subscription %q by permit plugin %q: %v", sub.Name, pl.Name(), status.Message())
@@ -641,9 +721,9 @@ func (f *frameworkImpl) RunPermitPlugins(ctx context.Context, sub *appsapi.Subsc
 	return nil
 }
 
-func (f *frameworkImpl) runPermitPlugin(ctx context.Context, pl framework.PermitPlugin, sub *appsapi.Subscription, namespacedClusters []string) (*framework.Status, time.Duration) {
+func (f *frameworkImpl) runPermitPlugin(ctx context.Context, state *framework.CycleState, pl framework.PermitPlugin, sub *appsapi.Subscription, targetClusters framework.TargetClusters) (*framework.Status, time.Duration) {
 	startTime := time.Now()
-	status, timeout := pl.Permit(ctx, sub, namespacedClusters)
+	status, timeout := pl.Permit(ctx, state, sub, targetClusters)
 	f.metricsRecorder.observePluginDurationAsync(permit, pl.Name(), status, metrics.SinceInSeconds(startTime))
 	return status, timeout
 }
@@ -756,6 +836,17 @@ func (f *frameworkImpl) SharedInformerFactory() informers.SharedInformerFactory
 	return f.informerFactory
 }
 
+// ClusterCache returns the scheduler cache of clusters.
+func (f *frameworkImpl) ClusterCache() schedulercache.Cache {
+	return f.clusterCache
+}
+
+// PercentageOfClustersToScore returns the percentage of feasible clusters to score,
+// which is configured in the profile associated to this framework.
+func (f *frameworkImpl) PercentageOfClustersToScore() int32 {
+	return f.percentageOfClustersToScore
+}
+
 // ProfileName returns the profile name associated to this framework.
 func (f *frameworkImpl) ProfileName() string {
 	return f.profileName
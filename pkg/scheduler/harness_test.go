@@ -0,0 +1,319 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	"github.com/clusternet/clusternet/pkg/known"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	"github.com/clusternet/clusternet/pkg/scheduler/options"
+	"github.com/clusternet/clusternet/pkg/utils"
+)
+
+// testHarness runs a real Scheduler in process, which talks to fake clientsets instead of an apiserver.
+type testHarness struct {
+	sched            *Scheduler
+	clusternetClient *fake.Clientset
+	cancel           context.CancelFunc
+}
+
+// newTestHarness creates a Scheduler with the default profile and the default plugins,
+// whose clusternet clientset is pre-populated with the given objects.
+func newTestHarness(tb testing.TB, objects ...runtime.Object) *testHarness {
+	clusternetClient := fake.NewSimpleClientset(objects...)
+	schedulerOptions := &options.SchedulerOptions{
+		ControllerOptions: &utils.ControllerOptions{},
+		Config: &schedulerapis.ClusternetSchedulerConfiguration{
+			Profiles: []schedulerapis.ClusternetSchedulerProfile{
+				{SchedulerName: schedulerapis.DefaultSchedulerName},
+			},
+		},
+	}
+
+	sched, err := newScheduler(schedulerOptions, &restclient.Config{}, kubefake.NewSimpleClientset(), clusternetClient,
+		informers.NewSharedInformerFactory(clusternetClient, 0), &record.FakeRecorder{})
+	if err != nil {
+		tb.Fatalf("failed to create scheduler: %v", err)
+	}
+	return &testHarness{
+		sched:            sched,
+		clusternetClient: clusternetClient,
+	}
+}
+
+// start starts the informers and the scheduling queue, and waits until the given number of subscriptions
+// have been added to the scheduling queue.
+func (h *testHarness) start(tb testing.TB, numSubscriptions int) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	h.sched.ClusternetInformerFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), h.sched.clustersSynced, h.sched.subsSynced, h.sched.manifestsSynced) {
+		tb.Fatal("failed to sync caches")
+	}
+	h.sched.SchedulingQueue.Run()
+
+	err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		return len(h.sched.SchedulingQueue.PendingSubscriptions()) >= numSubscriptions, nil
+	})
+	if err != nil {
+		tb.Fatalf("failed to wait for %d subscriptions to be queued: %v", numSubscriptions, err)
+	}
+	return ctx
+}
+
+// stop stops the informers and the scheduling queue.
+func (h *testHarness) stop() {
+	if h.cancel != nil {
+		h.cancel()
+		h.cancel = nil
+		h.sched.SchedulingQueue.Close()
+	}
+}
+
+// scheduleAll runs one scheduling cycle for each of the given number of subscriptions,
+// and returns the latency of every scheduling cycle.
+func (h *testHarness) scheduleAll(ctx context.Context, numSubscriptions int) []time.Duration {
+	latencies := make([]time.Duration, 0, numSubscriptions)
+	for i := 0; i < numSubscriptions; i++ {
+		start := time.Now()
+		h.sched.scheduleOne(ctx)
+		latencies = append(latencies, time.Since(start))
+	}
+	return latencies
+}
+
+// waitForScheduled waits until all the subscriptions get the Scheduled condition,
+// which is set after binding asynchronously, or on scheduling failures.
+func (h *testHarness) waitForScheduled(tb testing.TB, numSubscriptions int) map[string]*appsapi.Subscription {
+	var scheduled map[string]*appsapi.Subscription
+	err := wait.PollImmediate(10*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+		subs, err := h.clusternetClient.AppsV1alpha1().Subscriptions(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		scheduled = make(map[string]*appsapi.Subscription, len(subs.Items))
+		for i := range subs.Items {
+			if meta.FindStatusCondition(subs.Items[i].Status.Conditions, appsapi.SubscriptionScheduled) != nil {
+				scheduled[klog.KObj(&subs.Items[i]).String()] = &subs.Items[i]
+			}
+		}
+		return len(scheduled) >= numSubscriptions, nil
+	})
+	if err != nil {
+		tb.Fatalf("failed to wait for %d subscriptions to be scheduled, got %d: %v", numSubscriptions, len(scheduled), err)
+	}
+	return scheduled
+}
+
+// makeTestCluster returns a ready ManagedCluster with the given labels and allocatable resources.
+func makeTestCluster(name string, labels map[string]string, cpu, memory string) *clusterapi.ManagedCluster {
+	return &clusterapi.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns-" + name,
+			Labels:    labels,
+		},
+		Spec: clusterapi.ManagedClusterSpec{
+			SyncMode: clusterapi.Pull,
+		},
+		Status: clusterapi.ManagedClusterStatus{
+			LastObservedTime:  metav1.Now(),
+			KubernetesVersion: "v1.22.3",
+			Readyz:            true,
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+			Conditions: []metav1.Condition{
+				{
+					Type:               clusterapi.ClusterReady,
+					Status:             metav1.ConditionTrue,
+					Reason:             "ManagedClusterReady",
+					LastTransitionTime: metav1.Now(),
+				},
+			},
+		},
+	}
+}
+
+// makeTestManifest returns the Manifest of a Deployment with the given replicas and resource requests.
+func makeTestManifest(name string, replicas int32, cpu, memory string) *appsapi.Manifest {
+	return &appsapi.Manifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployments.v1.apps.default." + name,
+			Namespace: known.ClusternetReservedNamespace,
+			Labels: map[string]string{
+				known.ConfigGroupLabel:     "apps",
+				known.ConfigVersionLabel:   "v1",
+				known.ConfigKindLabel:      "Deployment",
+				known.ConfigNamespaceLabel: "default",
+				known.ConfigNameLabel:      name,
+			},
+		},
+		Template: runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":%q,"namespace":"default"},`+
+				`"spec":{"replicas":%d,"template":{"spec":{"containers":[{"name":"app","image":"app",`+
+				`"resources":{"requests":{"cpu":%q,"memory":%q}}}]}}}}`, name, replicas, cpu, memory)),
+		},
+	}
+}
+
+// makeTestSubscription returns a Replication Subscription of the given Deployment, which subscribes all the clusters.
+func makeTestSubscription(name, deployment string) *appsapi.Subscription {
+	return &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: appsapi.SubscriptionSpec{
+			SchedulerName:      schedulerapis.DefaultSchedulerName,
+			SchedulingStrategy: appsapi.ReplicaSchedulingStrategyType,
+			Subscribers: []appsapi.Subscriber{
+				{ClusterAffinity: &metav1.LabelSelector{}},
+			},
+			Feeds: []appsapi.Feed{
+				{
+					Kind:       "Deployment",
+					APIVersion: "apps/v1",
+					Namespace:  "default",
+					Name:       deployment,
+				},
+			},
+		},
+	}
+}
+
+// makeSyntheticClusters returns the given number of clusters with varying labels, taints and capacity.
+func makeSyntheticClusters(num int) []*clusterapi.ManagedCluster {
+	clusters := make([]*clusterapi.ManagedCluster, 0, num)
+	for i := 0; i < num; i++ {
+		cluster := makeTestCluster(fmt.Sprintf("cluster-%05d", i), map[string]string{
+			"region": fmt.Sprintf("region-%d", i%5),
+			"zone":   fmt.Sprintf("zone-%d", i%20),
+			"tier":   []string{"gold", "silver", "bronze"}[i%3],
+		}, fmt.Sprintf("%d", 8<<(i%4)), fmt.Sprintf("%dGi", 32<<(i%4)))
+		cluster.Status.Requested = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(fmt.Sprintf("%d", i%8)),
+			corev1.ResourceMemory: resource.MustParse(fmt.Sprintf("%dGi", i%16)),
+		}
+		switch {
+		case i%10 == 0:
+			cluster.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+		case i%7 == 0:
+			cluster.Spec.Taints = []corev1.Taint{{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule}}
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// makeSyntheticSubscriptions returns the given number of subscriptions, which are a mix of
+// Replication subscriptions selecting a few clusters and Dividing subscriptions, as well as the Manifests they feed.
+func makeSyntheticSubscriptions(num int) ([]*appsapi.Subscription, []*appsapi.Manifest) {
+	manifests := []*appsapi.Manifest{
+		makeTestManifest("small", 3, "100m", "128Mi"),
+		makeTestManifest("medium", 10, "250m", "512Mi"),
+	}
+
+	maxClusters := int32(3)
+	subs := make([]*appsapi.Subscription, 0, num)
+	for i := 0; i < num; i++ {
+		sub := makeTestSubscription(fmt.Sprintf("sub-%05d", i), manifests[i%len(manifests)].Labels[known.ConfigNameLabel])
+		switch i % 3 {
+		case 0:
+			sub.Spec.MaxClusters = &maxClusters
+			sub.Spec.Subscribers[0].ClusterAffinity = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"region": fmt.Sprintf("region-%d", i%5)},
+			}
+		case 1:
+			sub.Spec.MaxClusters = &maxClusters
+			sub.Spec.ClusterTolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+			sub.Spec.PreferredClusterAffinity = []appsapi.PreferredClusterSelectorTerm{
+				{
+					Weight: 10,
+					Preference: appsapi.ClusterSelectorTerm{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"gold"}},
+						},
+					},
+				},
+			}
+		case 2:
+			sub.Spec.SchedulingStrategy = appsapi.DividingSchedulingStrategyType
+			sub.Spec.DividingScheduling = &appsapi.DividingSchedulingStrategy{Type: appsapi.DynamicReplicaDividingType}
+			sub.Spec.Subscribers[0].ClusterAffinity = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"zone": fmt.Sprintf("zone-%d", i%20)},
+			}
+		}
+		subs = append(subs, sub)
+	}
+	return subs, manifests
+}
+
+// toObjects converts the given clusters, subscriptions and manifests to a list of objects.
+func toObjects(clusters []*clusterapi.ManagedCluster, subs []*appsapi.Subscription, manifests []*appsapi.Manifest) []runtime.Object {
+	objects := make([]runtime.Object, 0, len(clusters)+len(subs)+len(manifests))
+	for _, cluster := range clusters {
+		objects = append(objects, cluster)
+	}
+	for _, sub := range subs {
+		objects = append(objects, sub)
+	}
+	for _, manifest := range manifests {
+		objects = append(objects, manifest)
+	}
+	return objects
+}
+
+// percentile returns the given percentile of the durations.
+func percentile(durations []time.Duration, p int) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	idx := (len(sorted)*p+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilpointer "k8s.io/utils/pointer"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+)
+
+// expectedBinding is the expected scheduling result of a subscription.
+type expectedBinding struct {
+	clusters      []string
+	replicas      map[string]appsapi.FeedReplicas
+	unschedulable bool
+}
+
+func TestSchedulingWithInTreePlugins(t *testing.T) {
+	const feedKey = "apps/v1/Deployment/default/app"
+
+	tests := []struct {
+		name     string
+		clusters []*clusterapi.ManagedCluster
+		subs     []*appsapi.Subscription
+		expected map[string]expectedBinding
+	}{
+		{
+			name: "ClusterReady filters out clusters not ready",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", nil, "8", "16Gi"),
+				func() *clusterapi.ManagedCluster {
+					cluster := makeTestCluster("c2", nil, "8", "16Gi")
+					cluster.Status.Readyz = false
+					return cluster
+				}(),
+			},
+			subs: []*appsapi.Subscription{makeTestSubscription("sub", "app")},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c1/c1"}},
+			},
+		},
+		{
+			name: "ClusterAffinity filters clusters by cluster selector terms",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", nil, "8", "16Gi"),
+				makeTestCluster("c2", nil, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.Subscribers[0].ClusterSelectorTerms = []appsapi.ClusterSelectorTerm{
+						{
+							MatchFields: []metav1.LabelSelectorRequirement{
+								{Key: appsapi.ClusterFieldName, Operator: metav1.LabelSelectorOpIn, Values: []string{"c2"}},
+							},
+						},
+					}
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "ClusterAffinity prefers clusters matching preferred cluster affinity",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", map[string]string{"env": "test"}, "8", "16Gi"),
+				makeTestCluster("c2", map[string]string{"env": "prod"}, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.MaxClusters = utilpointer.Int32Ptr(1)
+					sub.Spec.PreferredClusterAffinity = []appsapi.PreferredClusterSelectorTerm{
+						{
+							Weight: 1,
+							Preference: appsapi.ClusterSelectorTerm{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod"}},
+								},
+							},
+						},
+					}
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "APICompatibility filters clusters by Kubernetes version constraint",
+			clusters: []*clusterapi.ManagedCluster{
+				func() *clusterapi.ManagedCluster {
+					cluster := makeTestCluster("c1", nil, "8", "16Gi")
+					cluster.Status.KubernetesVersion = "v1.20.1"
+					return cluster
+				}(),
+				makeTestCluster("c2", nil, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.KubernetesVersionConstraint = ">= 1.22"
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "TaintToleration filters out clusters with untolerated taints",
+			clusters: []*clusterapi.ManagedCluster{
+				func() *clusterapi.ManagedCluster {
+					cluster := makeTestCluster("c1", nil, "8", "16Gi")
+					cluster.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+					return cluster
+				}(),
+				makeTestCluster("c2", nil, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				makeTestSubscription("untolerated", "app"),
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("tolerated", "app")
+					sub.Spec.ClusterTolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"untolerated": {clusters: []string{"ns-c2/c2"}},
+				"tolerated":   {clusters: []string{"ns-c1/c1", "ns-c2/c2"}},
+			},
+		},
+		{
+			name: "TaintToleration prefers clusters without PreferNoSchedule taints",
+			clusters: []*clusterapi.ManagedCluster{
+				func() *clusterapi.ManagedCluster {
+					cluster := makeTestCluster("c1", nil, "8", "16Gi")
+					cluster.Spec.Taints = []corev1.Taint{{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule}}
+					return cluster
+				}(),
+				makeTestCluster("c2", nil, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.MaxClusters = utilpointer.Int32Ptr(1)
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "ClusterResourcesFit filters out clusters with insufficient resources",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", nil, "1", "16Gi"),
+				makeTestCluster("c2", nil, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{makeTestSubscription("sub", "app")},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "ClusterResourcesLeastAllocated prefers clusters with more available resources",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", nil, "4", "16Gi"),
+				makeTestCluster("c2", nil, "16", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.MaxClusters = utilpointer.Int32Ptr(1)
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "ClusterResourcesReservation keeps resources assumed by the subscriptions scheduled earlier",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", nil, "2", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("high", "app")
+					sub.Spec.Priority = utilpointer.Int32Ptr(10)
+					return sub
+				}(),
+				makeTestSubscription("low", "app"),
+			},
+			expected: map[string]expectedBinding{
+				"high": {clusters: []string{"ns-c1/c1"}},
+				"low":  {unschedulable: true},
+			},
+		},
+		{
+			name: "ClusterTopologySpread filters out clusters without the topology key",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("a1", map[string]string{"region": "a"}, "8", "16Gi"),
+				makeTestCluster("b1", map[string]string{"region": "b"}, "8", "16Gi"),
+				makeTestCluster("c1", nil, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.TopologySpreadConstraints = []appsapi.ClusterTopologySpreadConstraint{
+						{MaxSkew: 1, TopologyKey: "region", WhenUnsatisfiable: corev1.DoNotSchedule},
+					}
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-a1/a1", "ns-b1/b1"}},
+			},
+		},
+		{
+			name: "CostScore prefers cheaper clusters",
+			clusters: []*clusterapi.ManagedCluster{
+				func() *clusterapi.ManagedCluster {
+					cluster := makeTestCluster("c1", nil, "8", "16Gi")
+					cluster.Spec.Pricing = &clusterapi.ClusterPricing{CPUHour: resource.NewMilliQuantity(40, resource.DecimalSI)}
+					return cluster
+				}(),
+				func() *clusterapi.ManagedCluster {
+					cluster := makeTestCluster("c2", nil, "8", "16Gi")
+					cluster.Spec.Pricing = &clusterapi.ClusterPricing{CPUHour: resource.NewMilliQuantity(10, resource.DecimalSI)}
+					return cluster
+				}(),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.MaxClusters = utilpointer.Int32Ptr(1)
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "StickyBinding prefers clusters already bound",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", nil, "8", "16Gi"),
+				makeTestCluster("c2", nil, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.MaxClusters = utilpointer.Int32Ptr(1)
+					sub.Status.BindingClusters = []string{"ns-c2/c2"}
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {clusters: []string{"ns-c2/c2"}},
+			},
+		},
+		{
+			name: "StaticAssigner divides replicas by subscriber weights",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", map[string]string{"name": "c1"}, "8", "16Gi"),
+				makeTestCluster("c2", map[string]string{"name": "c2"}, "8", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.SchedulingStrategy = appsapi.DividingSchedulingStrategyType
+					sub.Spec.DividingScheduling = &appsapi.DividingSchedulingStrategy{Type: appsapi.StaticReplicaDividingType}
+					sub.Spec.Subscribers = []appsapi.Subscriber{
+						{
+							ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "c1"}},
+							Weight:          utilpointer.Int32Ptr(1),
+						},
+						{
+							ClusterAffinity: &metav1.LabelSelector{MatchLabels: map[string]string{"name": "c2"}},
+							Weight:          utilpointer.Int32Ptr(3),
+						},
+					}
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {
+					clusters: []string{"ns-c1/c1", "ns-c2/c2"},
+					replicas: map[string]appsapi.FeedReplicas{feedKey: {1, 3}},
+				},
+			},
+		},
+		{
+			name: "DynamicAssigner divides replicas by available resources",
+			clusters: []*clusterapi.ManagedCluster{
+				makeTestCluster("c1", nil, "1", "16Gi"),
+				makeTestCluster("c2", nil, "3", "16Gi"),
+			},
+			subs: []*appsapi.Subscription{
+				func() *appsapi.Subscription {
+					sub := makeTestSubscription("sub", "app")
+					sub.Spec.SchedulingStrategy = appsapi.DividingSchedulingStrategyType
+					sub.Spec.DividingScheduling = &appsapi.DividingSchedulingStrategy{Type: appsapi.DynamicReplicaDividingType}
+					return sub
+				}(),
+			},
+			expected: map[string]expectedBinding{
+				"sub": {
+					clusters: []string{"ns-c1/c1", "ns-c2/c2"},
+					replicas: map[string]appsapi.FeedReplicas{feedKey: {1, 3}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifests := []*appsapi.Manifest{makeTestManifest("app", 4, "500m", "512Mi")}
+			h := newTestHarness(t, toObjects(test.clusters, test.subs, manifests)...)
+			defer h.stop()
+
+			ctx := h.start(t, len(test.subs))
+			h.scheduleAll(ctx, len(test.subs))
+			scheduled := h.waitForScheduled(t, len(test.subs))
+
+			for name, expected := range test.expected {
+				sub, ok := scheduled["default/"+name]
+				if !ok {
+					t.Fatalf("subscription %s is not scheduled", name)
+				}
+				condition := meta.FindStatusCondition(sub.Status.Conditions, appsapi.SubscriptionScheduled)
+				if expected.unschedulable {
+					if condition.Status != metav1.ConditionFalse || len(sub.Status.BindingClusters) > 0 {
+						t.Errorf("expected subscription %s to be unschedulable, got condition %+v and binding clusters %v",
+							name, condition, sub.Status.BindingClusters)
+					}
+					continue
+				}
+
+				if condition.Status != metav1.ConditionTrue {
+					t.Errorf("expected subscription %s to be scheduled, got condition %+v", name, condition)
+				}
+				if !reflect.DeepEqual(expected.clusters, sub.Status.BindingClusters) {
+					t.Errorf("expected subscription %s to be bound to %v, got %v", name, expected.clusters, sub.Status.BindingClusters)
+				}
+				if len(expected.replicas) > 0 || len(sub.Status.Replicas) > 0 {
+					if !reflect.DeepEqual(expected.replicas, sub.Status.Replicas) {
+						t.Errorf("expected replicas of subscription %s to be %v, got %v", name, expected.replicas, sub.Status.Replicas)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/internal/heap/heap_test.go and modified.
+
+package heap
+
+import (
+	"testing"
+)
+
+func testHeapObjectKeyFunc(obj interface{}) (string, error) {
+	return obj.(testHeapObject).name, nil
+}
+
+type testHeapObject struct {
+	name string
+	val  int
+}
+
+func mkHeapObj(name string, val int) testHeapObject {
+	return testHeapObject{name: name, val: val}
+}
+
+func compareInts(val1 interface{}, val2 interface{}) bool {
+	first := val1.(testHeapObject).val
+	second := val2.(testHeapObject).val
+	return first < second
+}
+
+// TestHeapBasic tests Heap invariant
+func TestHeapBasic(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	const amount = 500
+	var i int
+
+	// empty queue
+	if item := h.Peek(); item != nil {
+		t.Errorf("expected nil object but got %v", item)
+	}
+
+	for i = amount; i > 0; i-- {
+		h.Add(mkHeapObj(string([]rune{'a', rune(i)}), i))
+		// Retrieve head without removing it
+		head := h.Peek()
+		if e, a := i, head.(testHeapObject).val; a != e {
+			t.Errorf("expected %d, got %d", e, a)
+		}
+	}
+
+	// Make sure that the numbers are popped in ascending order.
+	prevNum := 0
+	for i := 0; i < amount; i++ {
+		obj, err := h.Pop()
+		num := obj.(testHeapObject).val
+		// All the items must be sorted.
+		if err != nil || prevNum > num {
+			t.Errorf("got %v out of order, last was %v", obj, prevNum)
+		}
+		prevNum = num
+	}
+}
+
+// TestHeap_AddOrUpdate tests add capabilities of Heap.Add,
+// and ensures that heap invariant is preserved after adding items.
+func TestHeap_AddOrUpdate(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	h.Add(mkHeapObj("foo", 10))
+	h.Add(mkHeapObj("bar", 1))
+	h.Add(mkHeapObj("baz", 11))
+	h.Add(mkHeapObj("zab", 30))
+	h.Add(mkHeapObj("foo", 13)) // This updates "foo".
+
+	item, err := h.Pop()
+	if e, a := 1, item.(testHeapObject).val; err != nil || a != e {
+		t.Fatalf("expected %d, got %d", e, a)
+	}
+	item, err = h.Pop()
+	if e, a := 11, item.(testHeapObject).val; err != nil || a != e {
+		t.Fatalf("expected %d, got %d", e, a)
+	}
+	if err := h.Delete(mkHeapObj("baz", 11)); err == nil { // Nothing is deleted.
+		t.Fatalf("nothing should be deleted from the heap")
+	}
+	h.Add(mkHeapObj("foo", 14)) // foo is updated.
+	item, err = h.Pop()
+	if e, a := 14, item.(testHeapObject).val; err != nil || a != e {
+		t.Fatalf("expected %d, got %d", e, a)
+	}
+	item, err = h.Pop()
+	if e, a := 30, item.(testHeapObject).val; err != nil || a != e {
+		t.Fatalf("expected %d, got %d", e, a)
+	}
+	if h.Len() != 0 {
+		t.Fatalf("expected an empty heap, got %d items", h.Len())
+	}
+}
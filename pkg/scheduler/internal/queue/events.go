@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/internal/queue/events.go and modified.
+
+package queue
+
+const (
+	// SubscriptionAdd is the event when a new subscription is added to API server.
+	SubscriptionAdd = "SubscriptionAdd"
+	// SubscriptionUpdate is the event when the spec of a subscription is updated.
+	SubscriptionUpdate = "SubscriptionUpdate"
+	// ScheduleAttemptFailure is the event when a schedule attempt fails.
+	ScheduleAttemptFailure = "ScheduleAttemptFailure"
+	// BackoffComplete is the event when a subscription finishes backoff.
+	BackoffComplete = "BackoffComplete"
+	// UnschedulableTimeout is the event when a subscription stays in unschedulable for longer than timeout.
+	UnschedulableTimeout = "UnschedulableTimeout"
+
+	// ClusterAdd is the event when a new cluster joins.
+	ClusterAdd = "ClusterAdd"
+	// ClusterLabelChange is the event when the labels of a cluster are changed.
+	ClusterLabelChange = "ClusterLabelChange"
+	// ClusterTaintChange is the event when the taints of a cluster are changed.
+	ClusterTaintChange = "ClusterTaintChange"
+	// ClusterReadinessChange is the event when a cluster gets ready.
+	ClusterReadinessChange = "ClusterReadinessChange"
+	// ClusterAPIChange is the event when the Kubernetes version or served APIs of a cluster are changed.
+	ClusterAPIChange = "ClusterAPIChange"
+	// ClusterAllocatableChange is the event when the allocatable or requested resources of a cluster are changed.
+	ClusterAllocatableChange = "ClusterAllocatableChange"
+)
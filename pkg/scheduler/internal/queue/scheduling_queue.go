@@ -0,0 +1,651 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/internal/queue/scheduling_queue.go and modified.
+
+// This file contains structures that implement scheduling queue types.
+// Scheduling queues hold subscriptions waiting to be scheduled. This file implements a
+// priority queue which has two sub queues and an additional data structure,
+// namely: activeQ, backoffQ and unschedulableQ.
+// - activeQ holds subscriptions that are being considered for scheduling.
+// - backoffQ holds subscriptions that moved from unschedulableQ and will move to
+//   activeQ when their backoff periods complete.
+// - unschedulableQ holds subscriptions that were already attempted for scheduling and
+//   are currently determined to be unschedulable.
+
+package queue
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	"github.com/clusternet/clusternet/pkg/scheduler/internal/heap"
+	"github.com/clusternet/clusternet/pkg/scheduler/metrics"
+)
+
+const (
+	// If a subscription stays in unschedulableQ longer than unschedulableQTimeInterval,
+	// the subscription will be moved from unschedulableQ to backoffQ or activeQ.
+	// If a subscription is unschedulable forever, it keeps being retried in this interval.
+	unschedulableQTimeInterval = 60 * time.Second
+
+	queueClosed = "scheduling queue is closed"
+)
+
+const (
+	// DefaultSubscriptionInitialBackoffDuration is the default value for the initial backoff duration
+	// for unschedulable subscriptions.
+	DefaultSubscriptionInitialBackoffDuration = 1 * time.Second
+	// DefaultSubscriptionMaxBackoffDuration is the default value for the max backoff duration
+	// for unschedulable subscriptions.
+	DefaultSubscriptionMaxBackoffDuration = 10 * time.Second
+)
+
+// PreEnqueueCheck is a function type. It's used to build functions that
+// run against a Subscription and the caller can choose to enqueue or skip the Subscription
+// by the checking result.
+type PreEnqueueCheck func(sub *appsapi.Subscription) bool
+
+// SchedulingQueue is an interface for a queue to store subscriptions waiting to be scheduled.
+// The interface follows a pattern similar to cache.FIFO and cache.Heap and
+// makes it easy to use those data structures as a SchedulingQueue.
+type SchedulingQueue interface {
+	// Add adds a subscription to the active queue.
+	Add(sub *appsapi.Subscription) error
+	// AddUnschedulableIfNotPresent adds an unschedulable subscription back to scheduling queue.
+	// The schedulingCycle represents the current scheduling cycle number which can be
+	// returned by calling SchedulingCycle().
+	AddUnschedulableIfNotPresent(sInfo *framework.QueuedSubscriptionInfo, schedulingCycle int64) error
+	// SchedulingCycle returns the current number of scheduling cycle which is
+	// cached by scheduling queue. Normally, incrementing this number whenever
+	// a subscription is popped (e.g. called Pop()) is enough.
+	SchedulingCycle() int64
+	// Pop removes the head of the queue and returns it. It blocks if the
+	// queue is empty and waits until a new item is added to the queue.
+	Pop() (*framework.QueuedSubscriptionInfo, error)
+	// Update updates a subscription in the queue, which may move an unschedulable subscription
+	// back to the active queue or the backoff queue.
+	Update(oldSub, newSub *appsapi.Subscription) error
+	// Delete deletes a subscription from the queue.
+	Delete(sub *appsapi.Subscription) error
+	// MoveAllToActiveOrBackoffQueue moves all the unschedulable subscriptions passing the preCheck
+	// to the active queue or the backoff queue on a cluster event.
+	MoveAllToActiveOrBackoffQueue(event string, preCheck PreEnqueueCheck)
+	// PendingSubscriptions returns all the pending subscriptions in the queue.
+	PendingSubscriptions() []*appsapi.Subscription
+	// Close closes the SchedulingQueue so that the goroutine which is
+	// waiting to pop items can exit gracefully.
+	Close()
+	// Run starts the goroutines managing the queue.
+	Run()
+}
+
+// NewSchedulingQueue initializes a priority queue as a new scheduling queue.
+func NewSchedulingQueue(opts ...Option) SchedulingQueue {
+	return NewPriorityQueue(opts...)
+}
+
+// PriorityQueue implements a scheduling queue.
+// The head of PriorityQueue is the highest priority pending subscription. This structure
+// has two sub queues and an additional data structure, namely: activeQ,
+// backoffQ and unschedulableQ.
+//   - activeQ holds subscriptions that are being considered for scheduling.
+//   - backoffQ holds subscriptions that moved from unschedulableQ and will move to
+//     activeQ when their backoff periods complete.
+//   - unschedulableQ holds subscriptions that were already attempted for scheduling and
+//     are currently determined to be unschedulable.
+type PriorityQueue struct {
+	stop  chan struct{}
+	clock clock.Clock
+
+	// subscription initial backoff duration.
+	subscriptionInitialBackoffDuration time.Duration
+	// subscription maximum backoff duration.
+	subscriptionMaxBackoffDuration time.Duration
+
+	lock sync.RWMutex
+	cond sync.Cond
+
+	// activeQ is heap structure that scheduler actively looks at to find subscriptions to
+	// schedule. Head of heap is the highest priority subscription.
+	activeQ *heap.Heap
+	// backoffQ is a heap ordered by backoff expiry. Subscriptions which have completed backoff
+	// are popped from this heap before the scheduler looks at activeQ
+	backoffQ *heap.Heap
+	// unschedulableQ holds subscriptions that have been tried and determined unschedulable.
+	unschedulableQ *UnschedulableSubscriptions
+	// schedulingCycle represents sequence number of scheduling cycle and is incremented
+	// when a subscription is popped.
+	schedulingCycle int64
+	// moveRequestCycle caches the sequence number of scheduling cycle when we
+	// received a move request. Unschedulable subscriptions in and before this scheduling
+	// cycle will be put back to activeQ if we were trying to schedule them
+	// when we received move request.
+	moveRequestCycle int64
+
+	// closed indicates that the queue is closed.
+	// It is mainly used to let Pop() exit its control loop while waiting for an item.
+	closed bool
+}
+
+type priorityQueueOptions struct {
+	clock                              clock.Clock
+	subscriptionInitialBackoffDuration time.Duration
+	subscriptionMaxBackoffDuration     time.Duration
+}
+
+// Option configures a PriorityQueue
+type Option func(*priorityQueueOptions)
+
+// WithClock sets clock for PriorityQueue, the default clock is clock.RealClock.
+func WithClock(clock clock.Clock) Option {
+	return func(o *priorityQueueOptions) {
+		o.clock = clock
+	}
+}
+
+// WithSubscriptionInitialBackoffDuration sets subscription initial backoff duration for PriorityQueue.
+func WithSubscriptionInitialBackoffDuration(duration time.Duration) Option {
+	return func(o *priorityQueueOptions) {
+		o.subscriptionInitialBackoffDuration = duration
+	}
+}
+
+// WithSubscriptionMaxBackoffDuration sets subscription max backoff duration for PriorityQueue.
+func WithSubscriptionMaxBackoffDuration(duration time.Duration) Option {
+	return func(o *priorityQueueOptions) {
+		o.subscriptionMaxBackoffDuration = duration
+	}
+}
+
+var defaultPriorityQueueOptions = priorityQueueOptions{
+	clock:                              clock.RealClock{},
+	subscriptionInitialBackoffDuration: DefaultSubscriptionInitialBackoffDuration,
+	subscriptionMaxBackoffDuration:     DefaultSubscriptionMaxBackoffDuration,
+}
+
+// Making sure that PriorityQueue implements SchedulingQueue.
+var _ SchedulingQueue = &PriorityQueue{}
+
+// newQueuedSubscriptionInfoForLookup builds a QueuedSubscriptionInfo object for a lookup in the queue.
+func newQueuedSubscriptionInfoForLookup(sub *appsapi.Subscription) *framework.QueuedSubscriptionInfo {
+	// Since this is only used for a lookup in the queue, we only need to set the Subscription,
+	// and so we avoid creating a full QueuedSubscriptionInfo, which is an expensive operation.
+	return &framework.QueuedSubscriptionInfo{Subscription: sub}
+}
+
+// NewPriorityQueue creates a PriorityQueue object.
+func NewPriorityQueue(opts ...Option) *PriorityQueue {
+	options := defaultPriorityQueueOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	pq := &PriorityQueue{
+		clock:                              options.clock,
+		stop:                               make(chan struct{}),
+		subscriptionInitialBackoffDuration: options.subscriptionInitialBackoffDuration,
+		subscriptionMaxBackoffDuration:     options.subscriptionMaxBackoffDuration,
+		activeQ:                            heap.NewWithRecorder(subscriptionInfoKeyFunc, Less, metrics.NewActiveSubscriptionsRecorder()),
+		unschedulableQ:                     newUnschedulableSubscriptions(metrics.NewUnschedulableSubscriptionsRecorder()),
+		moveRequestCycle:                   -1,
+	}
+	pq.cond.L = &pq.lock
+	pq.backoffQ = heap.NewWithRecorder(subscriptionInfoKeyFunc, pq.subscriptionsCompareBackoffCompleted, metrics.NewBackoffSubscriptionsRecorder())
+
+	return pq
+}
+
+// Run starts the goroutine to pump from backoffQ to activeQ
+func (p *PriorityQueue) Run() {
+	go wait.Until(p.flushBackoffQCompleted, 1.0*time.Second, p.stop)
+	go wait.Until(p.flushUnschedulableQLeftover, 30*time.Second, p.stop)
+}
+
+// Add adds a subscription to the active queue. A subscription pending in the unschedulable queue or
+// the backoff queue is moved to the active queue, while the number of its attempts is kept.
+func (p *PriorityQueue) Add(sub *appsapi.Subscription) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	sInfo := p.newQueuedSubscriptionInfo(sub)
+	if oldSInfo := p.unschedulableQ.get(sub); oldSInfo != nil {
+		sInfo = p.refreshSubscription(oldSInfo, sub)
+		p.unschedulableQ.delete(sub)
+	} else if oldSInfo, exists, _ := p.backoffQ.Get(sInfo); exists {
+		sInfo = p.refreshSubscription(oldSInfo.(*framework.QueuedSubscriptionInfo), sub)
+		if err := p.backoffQ.Delete(sInfo); err != nil {
+			klog.ErrorS(err, "Error deleting subscription from the backoff queue", "subscription", klog.KObj(sub))
+		}
+	} else if oldSInfo, exists, _ := p.activeQ.Get(sInfo); exists {
+		// keep its position in the active queue
+		sInfo = oldSInfo.(*framework.QueuedSubscriptionInfo)
+		sInfo.Subscription = sub
+	}
+
+	if err := p.activeQ.Add(sInfo); err != nil {
+		klog.ErrorS(err, "Error adding subscription to the active queue", "subscription", klog.KObj(sub))
+		return err
+	}
+	metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("active", SubscriptionAdd).Inc()
+	p.cond.Broadcast()
+
+	return nil
+}
+
+// isSubscriptionBackingoff returns true if a subscription is still waiting for its backoff timer.
+// If this returns true, the subscription should not be re-tried.
+func (p *PriorityQueue) isSubscriptionBackingoff(sInfo *framework.QueuedSubscriptionInfo) bool {
+	boTime := p.getBackoffTime(sInfo)
+	return boTime.After(p.clock.Now())
+}
+
+// SchedulingCycle returns current scheduling cycle.
+func (p *PriorityQueue) SchedulingCycle() int64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.schedulingCycle
+}
+
+// AddUnschedulableIfNotPresent inserts a subscription that cannot be scheduled into
+// the queue, unless it is already in the queue. Normally, PriorityQueue puts
+// unschedulable subscriptions in `unschedulableQ`. But if there has been a recent move
+// request, then the subscription is put in `backoffQ`.
+func (p *PriorityQueue) AddUnschedulableIfNotPresent(sInfo *framework.QueuedSubscriptionInfo, schedulingCycle int64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	sub := sInfo.Subscription
+	if p.unschedulableQ.get(sub) != nil {
+		return fmt.Errorf("Subscription %v is already present in unschedulable queue", klog.KObj(sub))
+	}
+
+	if _, exists, _ := p.activeQ.Get(sInfo); exists {
+		return fmt.Errorf("Subscription %v is already present in the active queue", klog.KObj(sub))
+	}
+	if _, exists, _ := p.backoffQ.Get(sInfo); exists {
+		return fmt.Errorf("Subscription %v is already present in the backoff queue", klog.KObj(sub))
+	}
+
+	// Refresh the timestamp since the subscription is re-added.
+	sInfo.Timestamp = p.clock.Now()
+
+	// If a move request has been received, move it to the BackoffQ, otherwise move
+	// it to unschedulableQ.
+	if p.moveRequestCycle >= schedulingCycle {
+		if err := p.backoffQ.Add(sInfo); err != nil {
+			return fmt.Errorf("error adding subscription %v to the backoff queue: %v", klog.KObj(sub), err)
+		}
+		metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("backoff", ScheduleAttemptFailure).Inc()
+	} else {
+		p.unschedulableQ.addOrUpdate(sInfo)
+		metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("unschedulable", ScheduleAttemptFailure).Inc()
+	}
+
+	return nil
+}
+
+// flushBackoffQCompleted Moves all subscriptions from backoffQ which have completed backoff in to activeQ
+func (p *PriorityQueue) flushBackoffQCompleted() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	broadcast := false
+	for {
+		rawSInfo := p.backoffQ.Peek()
+		if rawSInfo == nil {
+			break
+		}
+		sInfo := rawSInfo.(*framework.QueuedSubscriptionInfo)
+		boTime := p.getBackoffTime(sInfo)
+		if boTime.After(p.clock.Now()) {
+			break
+		}
+		_, err := p.backoffQ.Pop()
+		if err != nil {
+			klog.ErrorS(err, "Unable to pop subscription from backoff queue despite backoff completion", "subscription", klog.KObj(sInfo.Subscription))
+			break
+		}
+		if err := p.activeQ.Add(sInfo); err != nil {
+			klog.ErrorS(err, "Error adding subscription to the active queue", "subscription", klog.KObj(sInfo.Subscription))
+			continue
+		}
+		metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("active", BackoffComplete).Inc()
+		broadcast = true
+	}
+
+	if broadcast {
+		p.cond.Broadcast()
+	}
+}
+
+// flushUnschedulableQLeftover moves subscriptions which stay in unschedulableQ longer than unschedulableQTimeInterval
+// to backoffQ or activeQ.
+func (p *PriorityQueue) flushUnschedulableQLeftover() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var subsToMove []*framework.QueuedSubscriptionInfo
+	currentTime := p.clock.Now()
+	for _, sInfo := range p.unschedulableQ.subscriptionInfoMap {
+		lastScheduleTime := sInfo.Timestamp
+		if currentTime.Sub(lastScheduleTime) > unschedulableQTimeInterval {
+			subsToMove = append(subsToMove, sInfo)
+		}
+	}
+
+	if len(subsToMove) > 0 {
+		p.moveSubscriptionsToActiveOrBackoffQueue(subsToMove, UnschedulableTimeout)
+	}
+}
+
+// Pop removes the head of the active queue and returns it. It blocks if the
+// activeQ is empty and waits until a new item is added to the queue. It
+// increments scheduling cycle when a subscription is popped.
+func (p *PriorityQueue) Pop() (*framework.QueuedSubscriptionInfo, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for p.activeQ.Len() == 0 {
+		// When the queue is empty, invocation of Pop() is blocked until new item is enqueued.
+		// When Close() is called, the p.closed is set and the condition is broadcast,
+		// which causes this loop to continue and return from the Pop().
+		if p.closed {
+			return nil, fmt.Errorf(queueClosed)
+		}
+		p.cond.Wait()
+	}
+	obj, err := p.activeQ.Pop()
+	if err != nil {
+		return nil, err
+	}
+	sInfo := obj.(*framework.QueuedSubscriptionInfo)
+	sInfo.Attempts++
+	p.schedulingCycle++
+	metrics.SchedulingQueueWaitDuration.Observe(p.clock.Since(sInfo.Timestamp).Seconds())
+	return sInfo, nil
+}
+
+// Update updates a subscription in the active or backoff queue if present. Otherwise, it removes
+// the item from the unschedulable queue if subscription is updated in a way that it may
+// become schedulable and adds the updated one to the active queue.
+// If subscription is not present in any of the queues, it is added to the active queue.
+func (p *PriorityQueue) Update(oldSub, newSub *appsapi.Subscription) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if oldSub != nil {
+		oldSInfo := newQueuedSubscriptionInfoForLookup(oldSub)
+		// If the subscription is already in the active queue, just update it there.
+		if oldSInfo, exists, _ := p.activeQ.Get(oldSInfo); exists {
+			sInfo := updateSubscription(oldSInfo, newSub)
+			return p.activeQ.Update(sInfo)
+		}
+
+		// If the subscription is in the backoff queue, update it there.
+		if oldSInfo, exists, _ := p.backoffQ.Get(oldSInfo); exists {
+			sInfo := updateSubscription(oldSInfo, newSub)
+			return p.backoffQ.Update(sInfo)
+		}
+	}
+
+	// If the subscription is in the unschedulable queue, updating it may make it schedulable.
+	if usSInfo := p.unschedulableQ.get(newSub); usSInfo != nil {
+		sInfo := updateSubscription(usSInfo, newSub)
+		if p.isSubscriptionBackingoff(usSInfo) {
+			if err := p.backoffQ.Add(sInfo); err != nil {
+				return err
+			}
+			p.unschedulableQ.delete(usSInfo.Subscription)
+			metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("backoff", SubscriptionUpdate).Inc()
+		} else {
+			if err := p.activeQ.Add(sInfo); err != nil {
+				return err
+			}
+			p.unschedulableQ.delete(usSInfo.Subscription)
+			metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("active", SubscriptionUpdate).Inc()
+			p.cond.Broadcast()
+		}
+		return nil
+	}
+
+	// If subscription is not in any of the queues, we put it in the active queue.
+	sInfo := p.newQueuedSubscriptionInfo(newSub)
+	if err := p.activeQ.Add(sInfo); err != nil {
+		return err
+	}
+	metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("active", SubscriptionUpdate).Inc()
+	p.cond.Broadcast()
+	return nil
+}
+
+// Delete deletes the item from either of the two queues. It assumes the subscription is
+// only in one queue.
+func (p *PriorityQueue) Delete(sub *appsapi.Subscription) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := p.activeQ.Delete(newQueuedSubscriptionInfoForLookup(sub)); err != nil {
+		// The item was probably not found in the activeQ.
+		p.backoffQ.Delete(newQueuedSubscriptionInfoForLookup(sub))
+		p.unschedulableQ.delete(sub)
+	}
+	return nil
+}
+
+// MoveAllToActiveOrBackoffQueue moves all subscriptions from unschedulableQ to activeQ or backoffQ.
+// This function adds all subscriptions and then signals the condition variable to ensure that
+// if Pop() is waiting for an item, it receives the signal after all the subscriptions are in the
+// queue and the head is the highest priority subscription.
+func (p *PriorityQueue) MoveAllToActiveOrBackoffQueue(event string, preCheck PreEnqueueCheck) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	unschedulableSubs := make([]*framework.QueuedSubscriptionInfo, 0, len(p.unschedulableQ.subscriptionInfoMap))
+	for _, sInfo := range p.unschedulableQ.subscriptionInfoMap {
+		if preCheck == nil || preCheck(sInfo.Subscription) {
+			unschedulableSubs = append(unschedulableSubs, sInfo)
+		}
+	}
+	p.moveSubscriptionsToActiveOrBackoffQueue(unschedulableSubs, event)
+}
+
+// NOTE: this function assumes lock has been acquired in caller
+func (p *PriorityQueue) moveSubscriptionsToActiveOrBackoffQueue(sInfoList []*framework.QueuedSubscriptionInfo, event string) {
+	moved := false
+	for _, sInfo := range sInfoList {
+		moved = true
+		sub := sInfo.Subscription
+		if p.isSubscriptionBackingoff(sInfo) {
+			if err := p.backoffQ.Add(sInfo); err != nil {
+				klog.ErrorS(err, "Error adding subscription to the backoff queue", "subscription", klog.KObj(sub))
+			} else {
+				metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("backoff", event).Inc()
+				p.unschedulableQ.delete(sub)
+			}
+		} else {
+			if err := p.activeQ.Add(sInfo); err != nil {
+				klog.ErrorS(err, "Error adding subscription to the scheduling queue", "subscription", klog.KObj(sub))
+			} else {
+				metrics.SchedulerQueueIncomingSubscriptions.WithLabelValues("active", event).Inc()
+				p.unschedulableQ.delete(sub)
+			}
+		}
+	}
+	p.moveRequestCycle = p.schedulingCycle
+	if moved {
+		p.cond.Broadcast()
+	}
+}
+
+// PendingSubscriptions returns all the pending subscriptions in the queue. This function is
+// used for debugging purposes in the scheduler cache dumper and comparer.
+func (p *PriorityQueue) PendingSubscriptions() []*appsapi.Subscription {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	var result []*appsapi.Subscription
+	for _, sInfo := range p.activeQ.List() {
+		result = append(result, sInfo.(*framework.QueuedSubscriptionInfo).Subscription)
+	}
+	for _, sInfo := range p.backoffQ.List() {
+		result = append(result, sInfo.(*framework.QueuedSubscriptionInfo).Subscription)
+	}
+	for _, sInfo := range p.unschedulableQ.subscriptionInfoMap {
+		result = append(result, sInfo.Subscription)
+	}
+	return result
+}
+
+// Close closes the priority queue.
+func (p *PriorityQueue) Close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	close(p.stop)
+	p.closed = true
+	p.cond.Broadcast()
+}
+
+func (p *PriorityQueue) subscriptionsCompareBackoffCompleted(sInfo1, sInfo2 interface{}) bool {
+	si1 := sInfo1.(*framework.QueuedSubscriptionInfo)
+	si2 := sInfo2.(*framework.QueuedSubscriptionInfo)
+	bo1 := p.getBackoffTime(si1)
+	bo2 := p.getBackoffTime(si2)
+	return bo1.Before(bo2)
+}
+
+// newQueuedSubscriptionInfo builds a QueuedSubscriptionInfo object.
+func (p *PriorityQueue) newQueuedSubscriptionInfo(sub *appsapi.Subscription) *framework.QueuedSubscriptionInfo {
+	now := p.clock.Now()
+	return &framework.QueuedSubscriptionInfo{
+		Subscription:            sub,
+		Timestamp:               now,
+		InitialAttemptTimestamp: now,
+		UnschedulablePlugins:    sets.NewString(),
+	}
+}
+
+// refreshSubscription updates the subscription of a QueuedSubscriptionInfo which is moved to the active queue,
+// while its attempts are kept for the metrics.
+func (p *PriorityQueue) refreshSubscription(oldSInfo *framework.QueuedSubscriptionInfo, newSub *appsapi.Subscription) *framework.QueuedSubscriptionInfo {
+	sInfo := updateSubscription(oldSInfo, newSub)
+	sInfo.Timestamp = p.clock.Now()
+	return sInfo
+}
+
+// getBackoffTime returns the time that sInfo completes backoff
+func (p *PriorityQueue) getBackoffTime(sInfo *framework.QueuedSubscriptionInfo) time.Time {
+	duration := p.calculateBackoffDuration(sInfo)
+	backoffTime := sInfo.Timestamp.Add(duration)
+	return backoffTime
+}
+
+// calculateBackoffDuration is a helper function for calculating the backoffDuration
+// based on the number of attempts the subscription has made.
+func (p *PriorityQueue) calculateBackoffDuration(sInfo *framework.QueuedSubscriptionInfo) time.Duration {
+	duration := p.subscriptionInitialBackoffDuration
+	for i := 1; i < sInfo.Attempts; i++ {
+		// Use subtraction instead of addition or multiplication to avoid overflow.
+		if duration > p.subscriptionMaxBackoffDuration-duration {
+			return p.subscriptionMaxBackoffDuration
+		}
+		duration += duration
+	}
+	return duration
+}
+
+func updateSubscription(oldSInfo interface{}, newSub *appsapi.Subscription) *framework.QueuedSubscriptionInfo {
+	sInfo := oldSInfo.(*framework.QueuedSubscriptionInfo)
+	sInfo.Subscription = newSub
+	return sInfo
+}
+
+// UnschedulableSubscriptions holds subscriptions that cannot be scheduled. This data structure
+// is used to implement unschedulableQ.
+type UnschedulableSubscriptions struct {
+	// subscriptionInfoMap is a map key by a subscription's full-name and the value is a pointer to the QueuedSubscriptionInfo.
+	subscriptionInfoMap map[string]*framework.QueuedSubscriptionInfo
+	keyFunc             func(*appsapi.Subscription) string
+	// metricRecorder updates the counter when elements of an unschedulableSubscriptionsMap
+	// get added or removed, and it does nothing if it's nil
+	metricRecorder metrics.MetricRecorder
+}
+
+// Add adds a subscription to the unschedulable subscriptionInfoMap.
+func (u *UnschedulableSubscriptions) addOrUpdate(sInfo *framework.QueuedSubscriptionInfo) {
+	key := u.keyFunc(sInfo.Subscription)
+	if _, exists := u.subscriptionInfoMap[key]; !exists && u.metricRecorder != nil {
+		u.metricRecorder.Inc()
+	}
+	u.subscriptionInfoMap[key] = sInfo
+}
+
+// Delete deletes a subscription from the unschedulable subscriptionInfoMap.
+func (u *UnschedulableSubscriptions) delete(sub *appsapi.Subscription) {
+	key := u.keyFunc(sub)
+	if _, exists := u.subscriptionInfoMap[key]; exists && u.metricRecorder != nil {
+		u.metricRecorder.Dec()
+	}
+	delete(u.subscriptionInfoMap, key)
+}
+
+// Get returns the QueuedSubscriptionInfo if a subscription with the same key as the key of the given "sub"
+// is found in the map. It returns nil otherwise.
+func (u *UnschedulableSubscriptions) get(sub *appsapi.Subscription) *framework.QueuedSubscriptionInfo {
+	key := u.keyFunc(sub)
+	if sInfo, exists := u.subscriptionInfoMap[key]; exists {
+		return sInfo
+	}
+	return nil
+}
+
+// newUnschedulableSubscriptions initializes a new object of UnschedulableSubscriptions.
+func newUnschedulableSubscriptions(metricRecorder metrics.MetricRecorder) *UnschedulableSubscriptions {
+	return &UnschedulableSubscriptions{
+		subscriptionInfoMap: make(map[string]*framework.QueuedSubscriptionInfo),
+		keyFunc:             subscriptionKey,
+		metricRecorder:      metricRecorder,
+	}
+}
+
+// Less orders subscriptions by their priority, and then by the timestamp when they are added to the queue.
+// Subscriptions with higher priority are popped first.
+func Less(sInfo1, sInfo2 interface{}) bool {
+	si1 := sInfo1.(*framework.QueuedSubscriptionInfo)
+	si2 := sInfo2.(*framework.QueuedSubscriptionInfo)
+	p1 := subscriptionPriority(si1.Subscription)
+	p2 := subscriptionPriority(si2.Subscription)
+	return (p1 > p2) || (p1 == p2 && si1.Timestamp.Before(si2.Timestamp))
+}
+
+// subscriptionPriority returns the priority of a subscription, which defaults to zero.
+func subscriptionPriority(sub *appsapi.Subscription) int32 {
+	if sub.Spec.Priority != nil {
+		return *sub.Spec.Priority
+	}
+	return 0
+}
+
+func subscriptionKey(sub *appsapi.Subscription) string {
+	return klog.KObj(sub).String()
+}
+
+func subscriptionInfoKeyFunc(obj interface{}) (string, error) {
+	return cache.MetaNamespaceKeyFunc(obj.(*framework.QueuedSubscriptionInfo).Subscription)
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/internal/queue/scheduling_queue_test.go and modified.
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	utilpointer "k8s.io/utils/pointer"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+func newSubscription(name string, priority *int32) *appsapi.Subscription {
+	return &appsapi.Subscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID(name),
+		},
+		Spec: appsapi.SubscriptionSpec{
+			Priority: priority,
+		},
+	}
+}
+
+func popName(t *testing.T, q *PriorityQueue) string {
+	sInfo, err := q.Pop()
+	if err != nil {
+		t.Fatalf("Pop() unexpected error: %v", err)
+	}
+	return sInfo.Subscription.Name
+}
+
+func TestPriorityQueue_AddWithPriority(t *testing.T) {
+	q := NewPriorityQueue()
+	for _, sub := range []*appsapi.Subscription{
+		newSubscription("low", utilpointer.Int32Ptr(-1)),
+		newSubscription("default", nil),
+		newSubscription("high", utilpointer.Int32Ptr(10)),
+	} {
+		if err := q.Add(sub); err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+	}
+
+	for _, want := range []string{"high", "default", "low"} {
+		if got := popName(t, q); got != want {
+			t.Errorf("Pop() = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestPriorityQueue_AddUnschedulableIfNotPresent(t *testing.T) {
+	q := NewPriorityQueue()
+	sub := newSubscription("sub", nil)
+	if err := q.Add(sub); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.AddUnschedulableIfNotPresent(q.newQueuedSubscriptionInfo(sub), q.SchedulingCycle()); err == nil {
+		t.Errorf("AddUnschedulableIfNotPresent() expected an error for a subscription in the active queue")
+	}
+
+	sInfo, err := q.Pop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.AddUnschedulableIfNotPresent(sInfo, q.SchedulingCycle()); err != nil {
+		t.Fatalf("AddUnschedulableIfNotPresent() unexpected error: %v", err)
+	}
+	if q.unschedulableQ.get(sub) == nil {
+		t.Errorf("expected %s to be in the unschedulable queue", sub.Name)
+	}
+	if err := q.AddUnschedulableIfNotPresent(sInfo, q.SchedulingCycle()); err == nil {
+		t.Errorf("AddUnschedulableIfNotPresent() expected an error for a subscription in the unschedulable queue")
+	}
+}
+
+// TestPriorityQueue_AddUnschedulableIfNotPresent_Backoff tests the scenarios when
+// AddUnschedulableIfNotPresent is called asynchronously.
+// Subscriptions in and before current scheduling cycle will be put back to the backoff queue
+// if we were trying to schedule them when we received a move request.
+func TestPriorityQueue_AddUnschedulableIfNotPresent_Backoff(t *testing.T) {
+	q := NewPriorityQueue(WithClock(testingclock.NewFakeClock(time.Now())))
+	sub1 := newSubscription("sub1", nil)
+	sub2 := newSubscription("sub2", nil)
+	for _, sub := range []*appsapi.Subscription{sub1, sub2} {
+		if err := q.Add(sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sInfo1, err := q.Pop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cycle := q.SchedulingCycle()
+	// a move request arrives while sub1 is being scheduled
+	q.MoveAllToActiveOrBackoffQueue(ClusterAdd, nil)
+	if err := q.AddUnschedulableIfNotPresent(sInfo1, cycle); err != nil {
+		t.Fatal(err)
+	}
+	if _, exists, _ := q.backoffQ.Get(sInfo1); !exists {
+		t.Errorf("expected %s to be in the backoff queue", sub1.Name)
+	}
+
+	sInfo2, err := q.Pop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.AddUnschedulableIfNotPresent(sInfo2, q.SchedulingCycle()); err != nil {
+		t.Fatal(err)
+	}
+	if q.unschedulableQ.get(sub2) == nil {
+		t.Errorf("expected %s to be in the unschedulable queue", sub2.Name)
+	}
+}
+
+func TestPriorityQueue_MoveAllToActiveOrBackoffQueue(t *testing.T) {
+	c := testingclock.NewFakeClock(time.Now())
+	q := NewPriorityQueue(WithClock(c))
+	matched := newSubscription("matched", nil)
+	unmatched := newSubscription("unmatched", nil)
+	for _, sub := range []*appsapi.Subscription{matched, unmatched} {
+		if err := q.Add(sub); err != nil {
+			t.Fatal(err)
+		}
+		sInfo, err := q.Pop()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := q.AddUnschedulableIfNotPresent(sInfo, q.SchedulingCycle()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	preCheck := func(sub *appsapi.Subscription) bool {
+		return sub.Name == matched.Name
+	}
+
+	// still backing off
+	q.MoveAllToActiveOrBackoffQueue(ClusterLabelChange, preCheck)
+	if _, exists, _ := q.backoffQ.Get(newQueuedSubscriptionInfoForLookup(matched)); !exists {
+		t.Errorf("expected %s to be in the backoff queue", matched.Name)
+	}
+	if q.unschedulableQ.get(unmatched) == nil {
+		t.Errorf("expected %s to stay in the unschedulable queue", unmatched.Name)
+	}
+
+	// complete backing off
+	c.Step(DefaultSubscriptionInitialBackoffDuration)
+	q.flushBackoffQCompleted()
+	if got := popName(t, q); got != matched.Name {
+		t.Errorf("Pop() = %s, want %s", got, matched.Name)
+	}
+	if q.activeQ.Len() != 0 {
+		t.Errorf("expected an empty active queue, got %d subscriptions", q.activeQ.Len())
+	}
+}
+
+func TestPriorityQueue_Update(t *testing.T) {
+	c := testingclock.NewFakeClock(time.Now())
+	q := NewPriorityQueue(WithClock(c))
+	sub := newSubscription("sub", nil)
+	if err := q.Add(sub); err != nil {
+		t.Fatal(err)
+	}
+	sInfo, err := q.Pop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.AddUnschedulableIfNotPresent(sInfo, q.SchedulingCycle()); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Step(DefaultSubscriptionInitialBackoffDuration)
+	newSub := sub.DeepCopy()
+	newSub.Spec.Priority = utilpointer.Int32Ptr(1)
+	if err := q.Update(sub, newSub); err != nil {
+		t.Fatalf("Update() unexpected error: %v", err)
+	}
+	if q.unschedulableQ.get(sub) != nil {
+		t.Errorf("expected %s to be moved out of the unschedulable queue", sub.Name)
+	}
+
+	sInfo, err = q.Pop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sInfo.Subscription != newSub {
+		t.Errorf("Pop() expected the updated subscription")
+	}
+	if sInfo.Attempts != 2 {
+		t.Errorf("Pop() attempts = %d, want 2", sInfo.Attempts)
+	}
+}
+
+func TestPriorityQueue_Delete(t *testing.T) {
+	q := NewPriorityQueue()
+	sub1 := newSubscription("sub1", nil)
+	sub2 := newSubscription("sub2", nil)
+	for _, sub := range []*appsapi.Subscription{sub1, sub2} {
+		if err := q.Add(sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := q.Delete(sub1); err != nil {
+		t.Fatalf("Delete() unexpected error: %v", err)
+	}
+	if got := q.PendingSubscriptions(); len(got) != 1 || got[0].Name != sub2.Name {
+		t.Errorf("PendingSubscriptions() = %v, want only %s", got, sub2.Name)
+	}
+}
+
+func TestPriorityQueue_FlushUnschedulableQLeftover(t *testing.T) {
+	c := testingclock.NewFakeClock(time.Now())
+	q := NewPriorityQueue(WithClock(c))
+	sub := newSubscription("sub", nil)
+	if err := q.Add(sub); err != nil {
+		t.Fatal(err)
+	}
+	sInfo, err := q.Pop()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.AddUnschedulableIfNotPresent(sInfo, q.SchedulingCycle()); err != nil {
+		t.Fatal(err)
+	}
+
+	q.flushUnschedulableQLeftover()
+	if q.unschedulableQ.get(sub) == nil {
+		t.Errorf("expected %s to stay in the unschedulable queue", sub.Name)
+	}
+
+	c.Step(unschedulableQTimeInterval + time.Second)
+	q.flushUnschedulableQLeftover()
+	if got := popName(t, q); got != sub.Name {
+		t.Errorf("Pop() = %s, want %s", got, sub.Name)
+	}
+}
+
+func TestPriorityQueue_CalculateBackoffDuration(t *testing.T) {
+	q := NewPriorityQueue()
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: DefaultSubscriptionMaxBackoffDuration},
+		{attempts: 100, want: DefaultSubscriptionMaxBackoffDuration},
+	}
+	for _, tt := range tests {
+		if got := q.calculateBackoffDuration(&framework.QueuedSubscriptionInfo{Attempts: tt.attempts}); got != tt.want {
+			t.Errorf("calculateBackoffDuration() with %d attempts = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestPriorityQueue_Close(t *testing.T) {
+	q := NewPriorityQueue()
+	done := make(chan error)
+	go func() {
+		_, err := q.Pop()
+		done <- err
+	}()
+	q.Close()
+	if err := <-done; err == nil || err.Error() != queueClosed {
+		t.Errorf("Pop() error = %v, want %s", err, queueClosed)
+	}
+}
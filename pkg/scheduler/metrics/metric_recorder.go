@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/metrics/metric_recorder.go and modified
+
+package metrics
+
+import (
+	"k8s.io/component-base/metrics"
+)
+
+// MetricRecorder represents a metric recorder which takes action when the
+// metric Inc(), Dec() and Clear()
+type MetricRecorder interface {
+	Inc()
+	Dec()
+	Clear()
+}
+
+var _ MetricRecorder = &PendingSubscriptionsRecorder{}
+
+// PendingSubscriptionsRecorder is an implementation of MetricRecorder
+type PendingSubscriptionsRecorder struct {
+	recorder metrics.GaugeMetric
+}
+
+// NewActiveSubscriptionsRecorder returns ActiveSubscriptions in a Prometheus metric fashion
+func NewActiveSubscriptionsRecorder() *PendingSubscriptionsRecorder {
+	return &PendingSubscriptionsRecorder{
+		recorder: ActiveSubscriptions(),
+	}
+}
+
+// NewUnschedulableSubscriptionsRecorder returns UnschedulableSubscriptions in a Prometheus metric fashion
+func NewUnschedulableSubscriptionsRecorder() *PendingSubscriptionsRecorder {
+	return &PendingSubscriptionsRecorder{
+		recorder: UnschedulableSubscriptions(),
+	}
+}
+
+// NewBackoffSubscriptionsRecorder returns BackoffSubscriptions in a Prometheus metric fashion
+func NewBackoffSubscriptionsRecorder() *PendingSubscriptionsRecorder {
+	return &PendingSubscriptionsRecorder{
+		recorder: BackoffSubscriptions(),
+	}
+}
+
+// Inc increases a metric counter by 1, in an atomic way
+func (r *PendingSubscriptionsRecorder) Inc() {
+	r.recorder.Inc()
+}
+
+// Dec decreases a metric counter by 1, in an atomic way
+func (r *PendingSubscriptionsRecorder) Dec() {
+	r.recorder.Dec()
+}
+
+// Clear set a metric counter to 0, in an atomic way
+func (r *PendingSubscriptionsRecorder) Clear() {
+	r.recorder.Set(float64(0))
+}
@@ -30,6 +30,8 @@ const (
 	// SchedulerSubsystem - subsystem name used by scheduler
 	SchedulerSubsystem = "clusternet_scheduler"
 
+	// PrioritizingExtender - prioritizing extender operation label value
+	PrioritizingExtender = "prioritizing_extender"
 	// Binding - binding operation label value
 	Binding = "binding"
 )
@@ -113,6 +115,52 @@ var (
 		},
 		[]string{"result"})
 
+	pendingSubscriptions = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "pending_subscriptions",
+			Help:           "Number of pending subscriptions, by the queue type. 'active' means number of subscriptions in activeQ; 'backoff' means number of subscriptions in backoffQ; 'unschedulable' means number of subscriptions in unschedulableQ.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"queue"})
+
+	SubscriptionSchedulingDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem: SchedulerSubsystem,
+			Name:      "subscription_scheduling_duration_seconds",
+			Help:      "E2e latency for a subscription being scheduled which may include multiple scheduling attempts.",
+			// Start with 10ms with the last bucket being [~88m, Inf).
+			Buckets:        metrics.ExponentialBuckets(0.01, 2, 20),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"attempts"})
+
+	SubscriptionSchedulingAttempts = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "subscription_scheduling_attempts",
+			Help:           "Number of attempts to successfully schedule a subscription.",
+			Buckets:        metrics.ExponentialBuckets(1, 2, 5),
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	SchedulingQueueWaitDuration = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem: SchedulerSubsystem,
+			Name:      "queue_wait_duration_seconds",
+			Help:      "Duration for a subscription waiting in the active queue before being popped for scheduling.",
+			// Start with 1ms with the last bucket being [~65s, Inf).
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 17),
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	SchedulerQueueIncomingSubscriptions = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "queue_incoming_subscriptions_total",
+			Help:           "Number of subscriptions added to scheduling queues by event and queue type.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"queue", "event"})
+
 	metricsList = []metrics.Registerable{
 		scheduleAttempts,
 		e2eSchedulingLatency,
@@ -121,6 +169,11 @@ var (
 		PluginExecutionDuration,
 		SchedulerGoroutines,
 		PermitWaitDuration,
+		pendingSubscriptions,
+		SubscriptionSchedulingDuration,
+		SubscriptionSchedulingAttempts,
+		SchedulingQueueWaitDuration,
+		SchedulerQueueIncomingSubscriptions,
 	}
 )
 
@@ -142,6 +195,21 @@ func RegisterMetrics(extraMetrics ...metrics.Registerable) {
 	}
 }
 
+// ActiveSubscriptions returns the pending subscriptions metrics with the label active
+func ActiveSubscriptions() metrics.GaugeMetric {
+	return pendingSubscriptions.With(metrics.Labels{"queue": "active"})
+}
+
+// BackoffSubscriptions returns the pending subscriptions metrics with the label backoff
+func BackoffSubscriptions() metrics.GaugeMetric {
+	return pendingSubscriptions.With(metrics.Labels{"queue": "backoff"})
+}
+
+// UnschedulableSubscriptions returns the pending subscriptions metrics with the label unschedulable
+func UnschedulableSubscriptions() metrics.GaugeMetric {
+	return pendingSubscriptions.With(metrics.Labels{"queue": "unschedulable"})
+}
+
 // SinceInSeconds gets the time since the specified start in seconds.
 func SinceInSeconds(start time.Time) float64 {
 	return time.Since(start).Seconds()
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"os"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	"github.com/clusternet/clusternet/pkg/scheduler/apis/scheme"
+	"github.com/clusternet/clusternet/pkg/scheduler/apis/v1alpha1"
+)
+
+// loadConfigFromFile loads the scheduler configuration from given file.
+func loadConfigFromFile(file string) (*schedulerapis.ClusternetSchedulerConfiguration, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadConfig(data)
+}
+
+func loadConfig(data []byte) (*schedulerapis.ClusternetSchedulerConfiguration, error) {
+	// The UniversalDecoder runs defaulting and returns the internal type by default.
+	obj, gvk, err := scheme.Codecs.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfgObj, ok := obj.(*schedulerapis.ClusternetSchedulerConfiguration); ok {
+		return cfgObj, nil
+	}
+	return nil, fmt.Errorf("couldn't decode as ClusternetSchedulerConfiguration, got %s", gvk)
+}
+
+// defaultConfig returns the default scheduler configuration, which is used when no config file is given.
+func defaultConfig() (*schedulerapis.ClusternetSchedulerConfiguration, error) {
+	versionedCfg := v1alpha1.ClusternetSchedulerConfiguration{}
+	scheme.Scheme.Default(&versionedCfg)
+
+	cfg := schedulerapis.ClusternetSchedulerConfiguration{}
+	if err := scheme.Scheme.Convert(&versionedCfg, &cfg, nil); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
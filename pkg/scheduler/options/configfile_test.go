@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+)
+
+func TestLoadConfigFromFile(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		expected  *schedulerapis.ClusternetSchedulerConfiguration
+		expectErr bool
+	}{
+		{
+			name: "multiple profiles with plugin args",
+			content: `apiVersion: scheduler.config.clusternet.io/v1alpha1
+kind: ClusternetSchedulerConfiguration
+profiles:
+- schedulerName: default
+- schedulerName: binpacking
+  percentageOfClustersToScore: 30
+  plugins:
+    score:
+      disabled:
+      - name: ClusterResourcesLeastAllocated
+      enabled:
+      - name: ClusterResourcesMostAllocated
+        weight: 2
+      - name: ClusterResourcesRequestedToCapacityRatio
+  pluginConfig:
+  - name: ClusterReady
+    args:
+      toleratedMissedHeartbeats: 5
+  - name: ClusterResourcesRequestedToCapacityRatio
+    args:
+      shape:
+      - utilization: 0
+        score: 0
+      - utilization: 100
+        score: 10
+extenders:
+- urlPrefix: http://127.0.0.1:8888
+  filterVerb: filter
+  prioritizeVerb: prioritize
+  weight: 2
+  ignorable: true
+`,
+			expected: &schedulerapis.ClusternetSchedulerConfiguration{
+				Profiles: []schedulerapis.ClusternetSchedulerProfile{
+					{
+						SchedulerName: "default",
+					},
+					{
+						SchedulerName:               "binpacking",
+						PercentageOfClustersToScore: 30,
+						Plugins: &schedulerapis.Plugins{
+							Score: schedulerapis.PluginSet{
+								Enabled: []schedulerapis.Plugin{
+									{Name: "ClusterResourcesMostAllocated", Weight: 2},
+									{Name: "ClusterResourcesRequestedToCapacityRatio", Weight: 1},
+								},
+								Disabled: []schedulerapis.Plugin{
+									{Name: "ClusterResourcesLeastAllocated"},
+								},
+							},
+						},
+						PluginConfig: []schedulerapis.PluginConfig{
+							{
+								Name: "ClusterReady",
+								Args: &schedulerapis.ClusterReadyArgs{ToleratedMissedHeartbeats: 5},
+							},
+							{
+								Name: "ClusterResourcesRequestedToCapacityRatio",
+								Args: &schedulerapis.ClusterResourcesRequestedToCapacityRatioArgs{
+									Shape: []schedulerapis.UtilizationShapePoint{
+										{Utilization: 0, Score: 0},
+										{Utilization: 100, Score: 10},
+									},
+									Resources: []schedulerapis.ResourceSpec{
+										{Name: "cpu", Weight: 1},
+										{Name: "memory", Weight: 1},
+									},
+								},
+							},
+						},
+					},
+				},
+				Extenders: []schedulerapis.Extender{
+					{
+						URLPrefix:      "http://127.0.0.1:8888",
+						FilterVerb:     "filter",
+						PrioritizeVerb: "prioritize",
+						Weight:         2,
+						HTTPTimeout:    metav1.Duration{Duration: 5 * time.Second},
+						Ignorable:      true,
+					},
+				},
+			},
+		},
+		{
+			name: "default profile",
+			content: `apiVersion: scheduler.config.clusternet.io/v1alpha1
+kind: ClusternetSchedulerConfiguration
+`,
+			expected: &schedulerapis.ClusternetSchedulerConfiguration{
+				Profiles: []schedulerapis.ClusternetSchedulerProfile{
+					{SchedulerName: "default"},
+				},
+			},
+		},
+		{
+			name: "unknown field",
+			content: `apiVersion: scheduler.config.clusternet.io/v1alpha1
+kind: ClusternetSchedulerConfiguration
+profiles:
+- schedulerName: default
+  unknownField: foo
+`,
+			expectErr: true,
+		},
+		{
+			name: "unknown kind",
+			content: `apiVersion: scheduler.config.clusternet.io/v1alpha1
+kind: UnknownConfiguration
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(file, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := loadConfigFromFile(file)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("loadConfigFromFile() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+
+			// ignore type meta
+			got.TypeMeta = tt.expected.TypeMeta
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("loadConfigFromFile() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDefaultConfig(t *testing.T) {
+	got, err := defaultConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &schedulerapis.ClusternetSchedulerConfiguration{
+		Profiles: []schedulerapis.ClusternetSchedulerProfile{
+			{SchedulerName: schedulerapis.DefaultSchedulerName},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("defaultConfig() = %+v, want %+v", got, expected)
+	}
+}
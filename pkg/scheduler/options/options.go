@@ -17,16 +17,40 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/pflag"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/clusternet/clusternet/pkg/known"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	"github.com/clusternet/clusternet/pkg/scheduler/apis/validation"
+	"github.com/clusternet/clusternet/pkg/scheduler/failover"
 	"github.com/clusternet/clusternet/pkg/utils"
 )
 
 // SchedulerOptions has all the params needed to run a Scheduler
 type SchedulerOptions struct {
 	*utils.ControllerOptions
+
+	// ConfigFile is the location of the scheduler's configuration file.
+	ConfigFile string
+
+	// Config is the scheduler configuration loaded from ConfigFile,
+	// or the default one if no ConfigFile is specified.
+	Config *schedulerapis.ClusternetSchedulerConfiguration
+
+	// FailoverGracePeriod is the period that a bound cluster may stay not ready,
+	// before the subscriptions bound to it are rescheduled.
+	FailoverGracePeriod time.Duration
+
+	// MigrateDividingReplicas indicates whether Dividing subscriptions are rescheduled on failover as well,
+	// which migrates their replicas from the lost clusters to the remaining ones.
+	MigrateDividingReplicas bool
+
+	// PreviewAddress is the address to serve scheduling previews on, which is disabled if empty.
+	PreviewAddress string
 }
 
 // NewSchedulerOptions returns a new SchedulerOptions
@@ -37,7 +61,8 @@ func NewSchedulerOptions() (*SchedulerOptions, error) {
 	}
 
 	return &SchedulerOptions{
-		ControllerOptions: controllerOptions,
+		ControllerOptions:   controllerOptions,
+		FailoverGracePeriod: failover.DefaultGracePeriod,
 	}, nil
 }
 
@@ -50,6 +75,16 @@ func (o *SchedulerOptions) Validate() error {
 		errors = append(errors, err)
 	}
 
+	if o.FailoverGracePeriod < 0 {
+		errors = append(errors, fmt.Errorf("--failover-grace-period must not be negative, got %v", o.FailoverGracePeriod))
+	}
+
+	if o.Config != nil {
+		if err := validation.ValidateClusternetSchedulerConfiguration(o.Config); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
 	return utilerrors.NewAggregate(errors)
 }
 
@@ -62,10 +97,25 @@ func (o *SchedulerOptions) Complete() error {
 		allErrs = append(allErrs, err)
 	}
 
+	// load scheduler configuration
+	var err error
+	if len(o.ConfigFile) > 0 {
+		o.Config, err = loadConfigFromFile(o.ConfigFile)
+	} else {
+		o.Config, err = defaultConfig()
+	}
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	return utilerrors.NewAggregate(allErrs)
 }
 
 // AddFlags adds flags for SchedulerOptions.
 func (o *SchedulerOptions) AddFlags(fs *pflag.FlagSet) {
 	o.ControllerOptions.AddFlags(fs)
+	fs.StringVar(&o.ConfigFile, "config", o.ConfigFile, "The path to the scheduler configuration file. If not specified, the default profile with the default plugins will be used.")
+	fs.DurationVar(&o.FailoverGracePeriod, "failover-grace-period", o.FailoverGracePeriod, "The period that a bound cluster may stay not ready, before the subscriptions bound to it are rescheduled to other clusters.")
+	fs.BoolVar(&o.MigrateDividingReplicas, "migrate-dividing-replicas", o.MigrateDividingReplicas, "Whether to migrate the replicas of Dividing subscriptions from a lost cluster to the remaining clusters, and move them back when the cluster recovers.")
+	fs.StringVar(&o.PreviewAddress, "preview-address", o.PreviewAddress, "The address to serve scheduling previews of Subscriptions on, such as \":8080\". Scheduling previews are disabled if empty.")
 }
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	"github.com/clusternet/clusternet/pkg/scheduler/algorithm"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+)
+
+// PreviewPath is the path of the HTTP endpoint serving scheduling previews.
+// A Subscription is posted to it, and a SchedulingPreview is returned.
+const PreviewPath = "/schedulingpreview"
+
+// SchedulingPreview describes where a Subscription would be scheduled to, and why.
+type SchedulingPreview struct {
+	// SelectedClusters are the namespaced names of clusters that the Subscription would be bound to.
+	SelectedClusters []string `json:"selectedClusters,omitempty"`
+
+	// Replicas are the divided replicas of each feed, which are corresponding with SelectedClusters by indices.
+	// Present only for Dividing scheduling.
+	Replicas map[string]appsapi.FeedReplicas `json:"replicas,omitempty"`
+
+	// Clusters holds the scheduling details of each evaluated cluster, which is keyed by the namespaced name.
+	Clusters map[string]*ClusterPreview `json:"clusters,omitempty"`
+
+	// Error describes why the Subscription is unschedulable.
+	Error string `json:"error,omitempty"`
+}
+
+// ClusterPreview holds the scheduling details of a cluster.
+type ClusterPreview struct {
+	// Feasible indicates whether the cluster passes all the filters.
+	Feasible bool `json:"feasible"`
+
+	// Code is the status code of the filters, when the cluster is filtered out.
+	Code string `json:"code,omitempty"`
+
+	// Reasons why the cluster is filtered out.
+	Reasons []string `json:"reasons,omitempty"`
+
+	// PluginScores are the weighted scores given by each score plugin.
+	PluginScores map[string]int64 `json:"pluginScores,omitempty"`
+
+	// Score is the final score of the cluster, including the scores given by extenders.
+	Score int64 `json:"score"`
+}
+
+// Preview runs the scheduling algorithm for the subscription against the current cache without binding it,
+// and returns where it would be scheduled to and why.
+func (sched *Scheduler) Preview(ctx context.Context, sub *appsapi.Subscription) (*SchedulingPreview, error) {
+	fwk, err := sched.frameworkForSubscription(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	// the preview algorithm is not shared with scheduleOne, while concurrent previews are serialized
+	sched.previewLock.Lock()
+	result, err := sched.previewAlgorithm.Schedule(ctx, fwk, framework.NewCycleState(), sub)
+	sched.previewLock.Unlock()
+
+	preview := &SchedulingPreview{
+		Clusters: make(map[string]*ClusterPreview),
+	}
+	diagnosis := result.Diagnosis
+	if err != nil {
+		var fitErr *framework.FitError
+		switch {
+		case errors.As(err, &fitErr):
+			diagnosis = fitErr.Diagnosis
+		case errors.Is(err, algorithm.ErrNoClustersAvailable):
+		default:
+			return nil, err
+		}
+		preview.Error = err.Error()
+	}
+
+	for namespacedName, status := range diagnosis.ClusterToStatusMap {
+		preview.Clusters[namespacedName] = &ClusterPreview{
+			Code:    status.Code().String(),
+			Reasons: status.Reasons(),
+		}
+	}
+	for _, clusterScore := range result.ClusterScores {
+		preview.Clusters[clusterScore.NamespacedName] = &ClusterPreview{
+			Feasible: true,
+			Score:    clusterScore.Score,
+		}
+	}
+	for plugin, clusterScoreList := range result.PluginScores {
+		for _, clusterScore := range clusterScoreList {
+			clusterPreview, ok := preview.Clusters[clusterScore.NamespacedName]
+			if !ok {
+				continue
+			}
+			if clusterPreview.PluginScores == nil {
+				clusterPreview.PluginScores = make(map[string]int64)
+			}
+			clusterPreview.PluginScores[plugin] = clusterScore.Score
+		}
+	}
+
+	preview.SelectedClusters = result.SuggestedClusters.BindingClusters
+	if len(result.SuggestedClusters.Replicas) > 0 {
+		preview.Replicas = make(map[string]appsapi.FeedReplicas, len(result.SuggestedClusters.Replicas))
+		for feedKey, replicas := range result.SuggestedClusters.Replicas {
+			preview.Replicas[feedKey] = replicas
+		}
+	}
+	return preview, nil
+}
+
+// ServeHTTP serves scheduling previews for the Subscriptions posted to PreviewPath.
+func (sched *Scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != PreviewPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	sub := &appsapi.Subscription{}
+	if err := json.NewDecoder(r.Body).Decode(sub); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode Subscription: %v", err), http.StatusBadRequest)
+		return
+	}
+	// the defaults are not applied yet for a Subscription that is not created
+	if len(sub.Spec.SchedulerName) == 0 {
+		sub.Spec.SchedulerName = schedulerapis.DefaultSchedulerName
+	}
+	if len(sub.Spec.SchedulingStrategy) == 0 {
+		sub.Spec.SchedulingStrategy = appsapi.ReplicaSchedulingStrategyType
+	}
+
+	preview, err := sched.Preview(r.Context(), sub)
+	if err != nil {
+		klog.ErrorS(err, "Failed to preview scheduling", "subscription", klog.KObj(sub))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(preview); err != nil {
+		klog.ErrorS(err, "Failed to write scheduling preview", "subscription", klog.KObj(sub))
+	}
+}
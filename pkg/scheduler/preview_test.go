@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilpointer "k8s.io/utils/pointer"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusterapi "github.com/clusternet/clusternet/pkg/apis/clusters/v1beta1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+	informers "github.com/clusternet/clusternet/pkg/generated/informers/externalversions"
+	"github.com/clusternet/clusternet/pkg/scheduler/algorithm"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	schedulercache "github.com/clusternet/clusternet/pkg/scheduler/cache"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins"
+	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins/names"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+	"github.com/clusternet/clusternet/pkg/scheduler/profile"
+)
+
+func TestSchedulingPreview(t *testing.T) {
+	informerFactory := informers.NewSharedInformerFactory(&fake.Clientset{}, 0)
+	clusters := []*clusterapi.ManagedCluster{
+		makeExtenderCluster("c1"),
+		makeExtenderCluster("c2"),
+		makeExtenderCluster("c3"),
+	}
+	clusters[0].Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "user1", Effect: corev1.TaintEffectNoSchedule}}
+	clusters[1].Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "user1", Effect: corev1.TaintEffectPreferNoSchedule}}
+	for _, cluster := range clusters {
+		if err := informerFactory.Clusters().V1beta1().ManagedClusters().Informer().GetStore().Add(cluster); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fwk, err := frameworkruntime.NewFramework(plugins.NewInTreeRegistry(), &schedulerapis.ClusternetSchedulerProfile{
+		SchedulerName: schedulerapis.DefaultSchedulerName,
+		Plugins: &schedulerapis.Plugins{
+			Filter: schedulerapis.PluginSet{Enabled: []schedulerapis.Plugin{{Name: names.TaintToleration}}},
+			Score:  schedulerapis.PluginSet{Enabled: []schedulerapis.Plugin{{Name: names.TaintToleration, Weight: 2}}},
+			Bind:   schedulerapis.PluginSet{Enabled: []schedulerapis.Plugin{{Name: names.DefaultBinder}}},
+		},
+	}, frameworkruntime.WithInformerFactory(informerFactory))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched := &Scheduler{
+		previewAlgorithm: algorithm.NewGenericScheduler(schedulercache.New(informerFactory.Clusters().V1beta1().ManagedClusters().Lister()), nil),
+		Profiles:         profile.Map{schedulerapis.DefaultSchedulerName: fwk},
+	}
+
+	filtered := &ClusterPreview{
+		Code:    "UnschedulableAndUnresolvable",
+		Reasons: []string{"clusters(s) had taint {dedicated: user1}, that the subscription didn't tolerate"},
+	}
+	tests := []struct {
+		name         string
+		method       string
+		minClusters  *int32
+		expectedCode int
+		expected     *SchedulingPreview
+	}{
+		{
+			name:         "schedulable subscription",
+			method:       http.MethodPost,
+			expectedCode: http.StatusOK,
+			expected: &SchedulingPreview{
+				SelectedClusters: []string{"ns-c2/c2", "ns-c3/c3"},
+				Clusters: map[string]*ClusterPreview{
+					"ns-c1/c1": filtered,
+					"ns-c2/c2": {Feasible: true, PluginScores: map[string]int64{names.TaintToleration: 0}, Score: 0},
+					"ns-c3/c3": {Feasible: true, PluginScores: map[string]int64{names.TaintToleration: 200}, Score: 200},
+				},
+			},
+		},
+		{
+			name:         "unschedulable subscription",
+			method:       http.MethodPost,
+			minClusters:  utilpointer.Int32(3),
+			expectedCode: http.StatusOK,
+			expected: &SchedulingPreview{
+				Clusters: map[string]*ClusterPreview{
+					"ns-c1/c1": filtered,
+				},
+				Error: "2/3 clusters are available, while at least 3 clusters are required: 1 clusters(s) had taint {dedicated: user1}, that the subscription didn't tolerate.",
+			},
+		},
+		{
+			name:         "method not allowed",
+			method:       http.MethodGet,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &appsapi.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+				Spec: appsapi.SubscriptionSpec{
+					Subscribers: []appsapi.Subscriber{{ClusterAffinity: &metav1.LabelSelector{}}},
+					MinClusters: tt.minClusters,
+				},
+			}
+			body, err := json.Marshal(sub)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recorder := httptest.NewRecorder()
+			sched.ServeHTTP(recorder, httptest.NewRequest(tt.method, PreviewPath, bytes.NewReader(body)))
+			if recorder.Code != tt.expectedCode {
+				t.Fatalf("expected status code %d, got %d: %s", tt.expectedCode, recorder.Code, recorder.Body.String())
+			}
+			if tt.expected == nil {
+				return
+			}
+
+			got := &SchedulingPreview{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), got); err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(got.SelectedClusters)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file was copied from k8s.io/kubernetes/pkg/scheduler/profile/profile.go and modified
+
+// Package profile holds the definition of a scheduling Profile.
+package profile
+
+import (
+	"fmt"
+
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+// Map holds frameworks indexed by scheduler name.
+type Map map[string]framework.Framework
+
+// NewMap builds the frameworks given by the configuration, indexed by name.
+func NewMap(cfgs []schedulerapis.ClusternetSchedulerProfile, r frameworkruntime.Registry, opts ...frameworkruntime.Option) (Map, error) {
+	m := make(Map)
+	for i := range cfgs {
+		cfg := &cfgs[i]
+		if _, ok := m[cfg.SchedulerName]; ok {
+			return nil, fmt.Errorf("duplicate profile with scheduler name %q", cfg.SchedulerName)
+		}
+
+		p, err := frameworkruntime.NewFramework(r, cfg, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating profile for scheduler name %s: %v", cfg.SchedulerName, err)
+		}
+		m[cfg.SchedulerName] = p
+	}
+	return m, nil
+}
+
+// HandlesSchedulerName returns whether a profile handles the given scheduler name.
+func (m Map) HandlesSchedulerName(name string) bool {
+	_, ok := m[name]
+	return ok
+}
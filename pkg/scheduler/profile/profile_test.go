@@ -0,0 +1,144 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
+	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
+	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+)
+
+var fakeRegistry = frameworkruntime.Registry{
+	"Bind1": newFakePlugin("Bind1"),
+	"Bind2": newFakePlugin("Bind2"),
+}
+
+func TestNewMap(t *testing.T) {
+	bindPlugins := func(names ...string) *schedulerapis.Plugins {
+		plugins := &schedulerapis.Plugins{}
+		for _, name := range names {
+			plugins.Bind.Enabled = append(plugins.Bind.Enabled, schedulerapis.Plugin{Name: name})
+		}
+		return plugins
+	}
+
+	tests := []struct {
+		name    string
+		cfgs    []schedulerapis.ClusternetSchedulerProfile
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfgs: []schedulerapis.ClusternetSchedulerProfile{
+				{SchedulerName: "profile-1", Plugins: bindPlugins("Bind1")},
+				{SchedulerName: "profile-2", Plugins: bindPlugins("Bind2"), PercentageOfClustersToScore: 20},
+			},
+		},
+		{
+			name: "duplicate scheduler name",
+			cfgs: []schedulerapis.ClusternetSchedulerProfile{
+				{SchedulerName: "profile-1", Plugins: bindPlugins("Bind1")},
+				{SchedulerName: "profile-1", Plugins: bindPlugins("Bind2")},
+			},
+			wantErr: "duplicate profile",
+		},
+		{
+			name: "config for a plugin that is not enabled",
+			cfgs: []schedulerapis.ClusternetSchedulerProfile{
+				{
+					SchedulerName: "profile-1",
+					Plugins:       bindPlugins("Bind1"),
+					PluginConfig:  []schedulerapis.PluginConfig{{Name: "Bind2"}},
+				},
+			},
+			wantErr: "plugin Bind2 is set, but the plugin is not enabled",
+		},
+		{
+			name: "invalid framework configuration",
+			cfgs: []schedulerapis.ClusternetSchedulerProfile{
+				{SchedulerName: "invalid-profile", Plugins: &schedulerapis.Plugins{}},
+			},
+			wantErr: "at least one bind plugin is needed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewMap(tc.cfgs, fakeRegistry)
+			if err := checkErr(err, tc.wantErr); err != nil {
+				t.Fatal(err)
+			}
+			if len(tc.wantErr) != 0 {
+				return
+			}
+			if len(m) != len(tc.cfgs) {
+				t.Errorf("got %d profiles, want %d", len(m), len(tc.cfgs))
+			}
+			for _, cfg := range tc.cfgs {
+				if !m.HandlesSchedulerName(cfg.SchedulerName) {
+					t.Errorf("profile %q is not handled", cfg.SchedulerName)
+				}
+				fwk := m[cfg.SchedulerName]
+				if fwk.ProfileName() != cfg.SchedulerName {
+					t.Errorf("got profile name %q, want %q", fwk.ProfileName(), cfg.SchedulerName)
+				}
+				if fwk.PercentageOfClustersToScore() != cfg.PercentageOfClustersToScore {
+					t.Errorf("got percentageOfClustersToScore %d, want %d", fwk.PercentageOfClustersToScore(), cfg.PercentageOfClustersToScore)
+				}
+			}
+			if m.HandlesSchedulerName("unknown-profile") {
+				t.Errorf("unknown profile should not be handled")
+			}
+		})
+	}
+}
+
+type fakePlugin struct {
+	name string
+}
+
+func (p *fakePlugin) Name() string {
+	return p.name
+}
+
+func (p *fakePlugin) Bind(context.Context, *framework.CycleState, *appsapi.Subscription, framework.TargetClusters) *framework.Status {
+	return nil
+}
+
+func newFakePlugin(name string) func(object runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	return func(_ runtime.Object, _ framework.Handle) (framework.Plugin, error) {
+		return &fakePlugin{name: name}, nil
+	}
+}
+
+func checkErr(err error, wantErr string) error {
+	if len(wantErr) == 0 {
+		return err
+	}
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		return fmt.Errorf("got error %v, want error containing %q", err, wantErr)
+	}
+	return nil
+}
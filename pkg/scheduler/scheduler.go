@@ -18,24 +18,31 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/workqueue"
 	"k8s.io/controller-manager/pkg/clientbuilder"
 	"k8s.io/klog/v2"
 
@@ -46,13 +53,18 @@ import (
 	applisters "github.com/clusternet/clusternet/pkg/generated/listers/apps/v1alpha1"
 	"github.com/clusternet/clusternet/pkg/known"
 	"github.com/clusternet/clusternet/pkg/scheduler/algorithm"
+	schedulerapis "github.com/clusternet/clusternet/pkg/scheduler/apis"
 	schedulercache "github.com/clusternet/clusternet/pkg/scheduler/cache"
+	extenderv1alpha1 "github.com/clusternet/clusternet/pkg/scheduler/extender/v1alpha1"
+	"github.com/clusternet/clusternet/pkg/scheduler/failover"
 	framework "github.com/clusternet/clusternet/pkg/scheduler/framework/interfaces"
 	"github.com/clusternet/clusternet/pkg/scheduler/framework/plugins"
 	frameworkruntime "github.com/clusternet/clusternet/pkg/scheduler/framework/runtime"
+	internalqueue "github.com/clusternet/clusternet/pkg/scheduler/internal/queue"
 	"github.com/clusternet/clusternet/pkg/scheduler/metrics"
 	"github.com/clusternet/clusternet/pkg/scheduler/options"
 	"github.com/clusternet/clusternet/pkg/scheduler/parallelize"
+	"github.com/clusternet/clusternet/pkg/scheduler/profile"
 	"github.com/clusternet/clusternet/pkg/utils"
 )
 
@@ -64,28 +76,46 @@ const (
 
 	// SchedulerError is the reason recorded for events when an error occurs during scheduling a subscription.
 	SchedulerError = "SchedulerError"
+
+	// ReasonScheduled reason in SubscriptionScheduled SubscriptionCondition means that the subscription
+	// has been bound to clusters.
+	ReasonScheduled = "Scheduled"
 )
 
 // Scheduler defines configuration for clusternet scheduler
 type Scheduler struct {
 	schedulerOptions *options.SchedulerOptions
 
-	kubeClient                *kubernetes.Clientset
-	clusternetClient          *clusternet.Clientset
+	kubeClient                kubernetes.Interface
+	clusternetClient          clusternet.Interface
 	ClusternetInformerFactory informers.SharedInformerFactory
+	recorder                  record.EventRecorder
 
-	subsLister applisters.SubscriptionLister
-	subsSynced cache.InformerSynced
+	clustersSynced  cache.InformerSynced
+	subsLister      applisters.SubscriptionLister
+	subsSynced      cache.InformerSynced
+	manifestsSynced cache.InformerSynced
 
 	// default in-tree registry
 	registry frameworkruntime.Registry
 
 	scheduleAlgorithm algorithm.ScheduleAlgorithm
 
+	// previewAlgorithm serves scheduling previews, which never bind subscriptions
+	previewAlgorithm algorithm.ScheduleAlgorithm
+	previewLock      sync.Mutex
+
+	// Extenders are the HTTP extenders shared by all the profiles
+	Extenders []framework.Extender
+
 	// SchedulingQueue holds subscriptions to be scheduled
-	SchedulingQueue workqueue.RateLimitingInterface
+	SchedulingQueue internalqueue.SchedulingQueue
 
-	framework framework.Framework
+	// Profiles are the scheduling profiles.
+	Profiles profile.Map
+
+	// failoverController re-queues the subscriptions bound to lost clusters
+	failoverController *failover.Controller
 
 	lock           sync.RWMutex
 	subscribersMap map[string][]appsapi.Subscriber
@@ -117,50 +147,92 @@ func NewScheduler(schedulerOptions *options.SchedulerOptions) (*Scheduler, error
 	utilruntime.Must(clusterapi.AddToScheme(scheme.Scheme))
 	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "clusternet-scheduler"})
 
+	return newScheduler(schedulerOptions, clientConfig, kubeClient, clusternetClient, clusternetInformerFactory, recorder)
+}
+
+// newScheduler returns a new Scheduler with the given clients, which could be fake ones in tests.
+func newScheduler(schedulerOptions *options.SchedulerOptions, clientConfig *restclient.Config,
+	kubeClient kubernetes.Interface, clusternetClient clusternet.Interface,
+	clusternetInformerFactory informers.SharedInformerFactory, recorder record.EventRecorder) (*Scheduler, error) {
 	schedulerCache := schedulercache.New(clusternetInformerFactory.Clusters().V1beta1().ManagedClusters().Lister())
 
+	extenders, err := buildExtenders(schedulerOptions.Config.Extenders)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build extenders: %w", err)
+	}
+
 	sched := &Scheduler{
 		schedulerOptions:          schedulerOptions,
 		kubeClient:                kubeClient,
 		clusternetClient:          clusternetClient,
 		ClusternetInformerFactory: clusternetInformerFactory,
+		recorder:                  recorder,
+		clustersSynced:            clusternetInformerFactory.Clusters().V1beta1().ManagedClusters().Informer().HasSynced,
 		subsLister:                clusternetInformerFactory.Apps().V1alpha1().Subscriptions().Lister(),
 		subsSynced:                clusternetInformerFactory.Apps().V1alpha1().Subscriptions().Informer().HasSynced,
+		manifestsSynced:           clusternetInformerFactory.Apps().V1alpha1().Manifests().Informer().HasSynced,
 		registry:                  plugins.NewInTreeRegistry(),
-		scheduleAlgorithm:         algorithm.NewGenericScheduler(schedulerCache),
-		SchedulingQueue:           workqueue.NewRateLimitingQueue(workqueue.DefaultItemBasedRateLimiter()),
+		scheduleAlgorithm:         algorithm.NewGenericScheduler(schedulerCache, extenders),
+		previewAlgorithm:          algorithm.NewGenericScheduler(schedulerCache, extenders),
+		Extenders:                 extenders,
+		SchedulingQueue:           internalqueue.NewSchedulingQueue(),
 		subscribersMap:            make(map[string][]appsapi.Subscriber),
 	}
 
-	framework, err := frameworkruntime.NewFramework(sched.registry, getDefaultPlugins(),
+	profiles := make([]schedulerapis.ClusternetSchedulerProfile, len(schedulerOptions.Config.Profiles))
+	for i := range schedulerOptions.Config.Profiles {
+		schedulerOptions.Config.Profiles[i].DeepCopyInto(&profiles[i])
+		profiles[i].Plugins = mergePlugins(getDefaultPlugins(), profiles[i].Plugins)
+	}
+	sched.Profiles, err = profile.NewMap(profiles, sched.registry,
 		frameworkruntime.WithEventRecorder(recorder),
 		frameworkruntime.WithInformerFactory(clusternetInformerFactory),
+		frameworkruntime.WithClusterCache(schedulerCache),
 		frameworkruntime.WithClientSet(clusternetClient),
 		frameworkruntime.WithKubeConfig(clientConfig),
 		frameworkruntime.WithParallelism(parallelize.DefaultParallelism),
 		frameworkruntime.WithRunAllFilters(false),
 	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("initializing profiles: %v", err)
 	}
-	sched.framework = framework
 
 	// register all metrics
 	metrics.Register()
 
+	sched.failoverController = failover.NewController(
+		schedulerOptions.FailoverGracePeriod,
+		schedulerOptions.MigrateDividingReplicas,
+		clusternetInformerFactory.Clusters().V1beta1().ManagedClusters(),
+		sched.subsLister,
+		func(sub *appsapi.Subscription) bool {
+			return sched.Profiles.HandlesSchedulerName(sub.Spec.SchedulerName)
+		},
+		sched.enqueueSubscription,
+	)
+
 	sched.addAllEventHandlers()
 	return sched, nil
 }
 
 // Run begins watching and scheduling. It starts scheduling and blocked until the context is done.
 func (sched *Scheduler) Run(ctx context.Context) error {
-	defer sched.SchedulingQueue.ShutDown()
+	defer sched.SchedulingQueue.Close()
 
 	// Start all informers.
 	sched.ClusternetInformerFactory.Start(ctx.Done())
 
 	// Wait for all caches to sync before scheduling.
-	sched.ClusternetInformerFactory.WaitForCacheSync(ctx.Done())
+	if !cache.WaitForNamedCacheSync("clusternet-scheduler", ctx.Done(), sched.clustersSynced, sched.subsSynced, sched.manifestsSynced) {
+		return fmt.Errorf("unable to sync caches for clusternet-scheduler")
+	}
+
+	sched.SchedulingQueue.Run()
+	go sched.failoverController.Run(ctx)
+
+	if len(sched.schedulerOptions.PreviewAddress) > 0 {
+		go sched.servePreview(ctx)
+	}
 
 	// if leader election is disabled, so runCommand inline until done.
 	if !sched.schedulerOptions.LeaderElection.LeaderElect {
@@ -206,71 +278,105 @@ func (sched *Scheduler) Run(ctx context.Context) error {
 	return nil
 }
 
+// servePreview serves scheduling previews on the configured address until the context is done.
+func (sched *Scheduler) servePreview(ctx context.Context) {
+	server := &http.Server{
+		Addr:    sched.schedulerOptions.PreviewAddress,
+		Handler: sched,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Close(); err != nil {
+			klog.ErrorS(err, "Failed to close scheduling preview server")
+		}
+	}()
+
+	klog.InfoS("Serving scheduling previews", "address", server.Addr, "path", PreviewPath)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.ErrorS(err, "Failed to serve scheduling previews")
+	}
+}
+
 // scheduleOne does the entire scheduling workflow for a single subscription.
 // It is serialized on the scheduling algorithm's cluster fitting.
 func (sched *Scheduler) scheduleOne(ctx context.Context) {
-	key, shutdown := sched.SchedulingQueue.Get()
-	if shutdown {
-		klog.Error("failed to get next unscheduled subscription from closed queue")
+	subInfo, err := sched.SchedulingQueue.Pop()
+	if err != nil {
+		klog.ErrorS(err, "Failed to get next unscheduled subscription from the scheduling queue")
 		return
 	}
-	defer sched.SchedulingQueue.Done(key)
 
-	// TODO: scheduling
-	// Convert the namespace/name string into a distinct namespace and name
-	ns, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	// The status of the subscription in the queue may be out of date, so the latest one is used.
+	sub, err := sched.subsLister.Subscriptions(subInfo.Subscription.Namespace).Get(subInfo.Subscription.Name)
 	if err != nil {
-		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		if !apierrors.IsNotFound(err) {
+			utilruntime.HandleError(err)
+		}
 		return
 	}
-
-	sub, err := sched.subsLister.Subscriptions(ns).Get(name)
+	if sub.DeletionTimestamp != nil {
+		return
+	}
+	subInfo.Subscription = sub
+	fwk, err := sched.frameworkForSubscription(sub)
 	if err != nil {
-		utilruntime.HandleError(err)
+		// This shouldn't happen, because we only accept for scheduling the subscriptions
+		// which specify a scheduler name that matches one of the profiles.
+		klog.ErrorS(err, "Error occurred")
+		sched.recorder.Event(sub, corev1.EventTypeWarning, "FailedScheduling", err.Error())
 		return
 	}
+
 	klog.V(3).InfoS("Attempting to schedule subscription", "subscription", klog.KObj(sub))
+	subSchedulingCycle := sched.SchedulingQueue.SchedulingCycle()
 
 	// Synchronously attempt to find a fit for the subscription.
 	start := time.Now()
+	state := framework.NewCycleState()
 
 	schedulingCycleCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	scheduleResult, err := sched.scheduleAlgorithm.Schedule(schedulingCycleCtx, sched.framework, sub)
+	scheduleResult, err := sched.scheduleAlgorithm.Schedule(schedulingCycleCtx, fwk, state, sub)
 	if err != nil {
-		sched.recordSchedulingFailure(sub, err, ReasonUnschedulable)
+		reason := ReasonUnschedulable
+		var fitErr *framework.FitError
+		if errors.As(err, &fitErr) || err == algorithm.ErrNoClustersAvailable {
+			metrics.SubscriptionUnschedulable(fwk.ProfileName(), metrics.SinceInSeconds(start))
+		} else {
+			klog.ErrorS(err, "Error selecting clusters for subscription", "subscription", klog.KObj(sub))
+			metrics.SubscriptionScheduleError(fwk.ProfileName(), metrics.SinceInSeconds(start))
+			reason = SchedulerError
+		}
+		sched.recordSchedulingFailure(fwk, subInfo, err, reason, subSchedulingCycle)
 		return
 	}
 	metrics.SchedulingAlgorithmLatency.Observe(metrics.SinceInSeconds(start))
 
 	// Run the Reserve method of reserve plugins.
-	var namespacedClusters []string
-	for _, cluster := range scheduleResult.SuggestedClusters {
-		namespacedClusters = append(namespacedClusters, cluster)
-	}
-	if sts := sched.framework.RunReservePluginsReserve(schedulingCycleCtx, sub, namespacedClusters); !sts.IsSuccess() {
-		metrics.SubscriptionScheduleError(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+	targetClusters := scheduleResult.SuggestedClusters
+	if sts := fwk.RunReservePluginsReserve(schedulingCycleCtx, state, sub, targetClusters); !sts.IsSuccess() {
+		metrics.SubscriptionScheduleError(fwk.ProfileName(), metrics.SinceInSeconds(start))
 		// trigger un-reserve to clean up state associated with the reserved subscription
-		sched.framework.RunReservePluginsUnreserve(schedulingCycleCtx, sub, namespacedClusters)
-		sched.recordSchedulingFailure(sub, sts.AsError(), SchedulerError)
+		fwk.RunReservePluginsUnreserve(schedulingCycleCtx, state, sub, targetClusters)
+		sched.recordSchedulingFailure(fwk, subInfo, sts.AsError(), SchedulerError, subSchedulingCycle)
 		return
 	}
 
 	// Run "permit" plugins.
-	runPermitStatus := sched.framework.RunPermitPlugins(schedulingCycleCtx, sub, namespacedClusters)
+	runPermitStatus := fwk.RunPermitPlugins(schedulingCycleCtx, state, sub, targetClusters)
 	if runPermitStatus.Code() != framework.Wait && !runPermitStatus.IsSuccess() {
 		var reason string
 		if runPermitStatus.IsUnschedulable() {
-			metrics.SubscriptionUnschedulable(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+			metrics.SubscriptionUnschedulable(fwk.ProfileName(), metrics.SinceInSeconds(start))
 			reason = ReasonUnschedulable
 		} else {
-			metrics.SubscriptionScheduleError(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+			metrics.SubscriptionScheduleError(fwk.ProfileName(), metrics.SinceInSeconds(start))
 			reason = SchedulerError
 		}
 		// One of the plugins returned status different from success or wait.
-		sched.framework.RunReservePluginsUnreserve(schedulingCycleCtx, sub, namespacedClusters)
-		sched.recordSchedulingFailure(sub, runPermitStatus.AsError(), reason)
+		fwk.RunReservePluginsUnreserve(schedulingCycleCtx, state, sub, targetClusters)
+		sched.recordSchedulingFailure(fwk, subInfo, runPermitStatus.AsError(), reason, subSchedulingCycle)
 		return
 	}
 
@@ -281,66 +387,80 @@ func (sched *Scheduler) scheduleOne(ctx context.Context) {
 		metrics.SchedulerGoroutines.WithLabelValues(metrics.Binding).Inc()
 		defer metrics.SchedulerGoroutines.WithLabelValues(metrics.Binding).Dec()
 
-		waitOnPermitStatus := sched.framework.WaitOnPermit(bindingCycleCtx, sub)
+		waitOnPermitStatus := fwk.WaitOnPermit(bindingCycleCtx, sub)
 		if !waitOnPermitStatus.IsSuccess() {
 			var reason string
 			if waitOnPermitStatus.IsUnschedulable() {
-				metrics.SubscriptionUnschedulable(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+				metrics.SubscriptionUnschedulable(fwk.ProfileName(), metrics.SinceInSeconds(start))
 				reason = ReasonUnschedulable
 			} else {
-				metrics.SubscriptionScheduleError(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+				metrics.SubscriptionScheduleError(fwk.ProfileName(), metrics.SinceInSeconds(start))
 				reason = SchedulerError
 			}
 			// trigger un-reserve plugins to clean up state associated with the reserved subscription
-			sched.framework.RunReservePluginsUnreserve(bindingCycleCtx, sub, namespacedClusters)
-			sched.recordSchedulingFailure(sub, waitOnPermitStatus.AsError(), reason)
+			fwk.RunReservePluginsUnreserve(bindingCycleCtx, state, sub, targetClusters)
+			sched.recordSchedulingFailure(fwk, subInfo, waitOnPermitStatus.AsError(), reason, subSchedulingCycle)
 			return
 		}
 
 		// Run "prebind" plugins.
-		preBindStatus := sched.framework.RunPreBindPlugins(bindingCycleCtx, sub, namespacedClusters)
+		preBindStatus := fwk.RunPreBindPlugins(bindingCycleCtx, state, sub, targetClusters)
 		if !preBindStatus.IsSuccess() {
-			metrics.SubscriptionScheduleError(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+			metrics.SubscriptionScheduleError(fwk.ProfileName(), metrics.SinceInSeconds(start))
 			// trigger un-reserve plugins to clean up state associated with the reserved subscription
-			sched.framework.RunReservePluginsUnreserve(bindingCycleCtx, sub, namespacedClusters)
-			sched.recordSchedulingFailure(sub, preBindStatus.AsError(), SchedulerError)
+			fwk.RunReservePluginsUnreserve(bindingCycleCtx, state, sub, targetClusters)
+			sched.recordSchedulingFailure(fwk, subInfo, preBindStatus.AsError(), SchedulerError, subSchedulingCycle)
 			return
 		}
 
-		err := sched.bind(bindingCycleCtx, sub, namespacedClusters)
+		err := sched.bind(bindingCycleCtx, fwk, state, sub, targetClusters)
 		if err != nil {
-			metrics.SubscriptionScheduleError(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+			metrics.SubscriptionScheduleError(fwk.ProfileName(), metrics.SinceInSeconds(start))
 			// trigger un-reserve plugins to clean up state associated with the reserved subscription
-			sched.framework.RunReservePluginsUnreserve(bindingCycleCtx, sub, namespacedClusters)
-			sched.recordSchedulingFailure(sub, fmt.Errorf("binding rejected: %w", err), SchedulerError)
+			fwk.RunReservePluginsUnreserve(bindingCycleCtx, state, sub, targetClusters)
+			sched.recordSchedulingFailure(fwk, subInfo, fmt.Errorf("binding rejected: %w", err), SchedulerError, subSchedulingCycle)
 		} else {
-			metrics.SubscriptionScheduled(sched.framework.ProfileName(), metrics.SinceInSeconds(start))
+			metrics.SubscriptionScheduled(fwk.ProfileName(), metrics.SinceInSeconds(start))
+			metrics.SubscriptionSchedulingAttempts.Observe(float64(subInfo.Attempts))
+			metrics.SubscriptionSchedulingDuration.WithLabelValues(getAttemptsLabel(subInfo)).Observe(metrics.SinceInSeconds(subInfo.InitialAttemptTimestamp))
 
 			// Run "postbind" plugins.
-			sched.framework.RunPostBindPlugins(bindingCycleCtx, sub, namespacedClusters)
+			fwk.RunPostBindPlugins(bindingCycleCtx, state, sub, targetClusters)
+
+			sched.updateScheduledCondition(bindingCycleCtx, sub, metav1.Condition{
+				Type:    appsapi.SubscriptionScheduled,
+				Status:  metav1.ConditionTrue,
+				Reason:  ReasonScheduled,
+				Message: fmt.Sprintf("Successfully bound to %d clusters", len(targetClusters.BindingClusters)),
+			}, nil)
 		}
 	}()
 }
 
 // bind a subscription to given clusters.
 // We expect this to run asynchronously, so we handle binding metrics internally.
-func (sched *Scheduler) bind(ctx context.Context, sub *appsapi.Subscription, namespacedClusters []string) (err error) {
+func (sched *Scheduler) bind(ctx context.Context, fwk framework.Framework, state *framework.CycleState, sub *appsapi.Subscription, targetClusters framework.TargetClusters) (err error) {
 	defer func() {
 		// finish binding
 		if err != nil {
 			klog.V(1).InfoS("Failed to bind sub", "sub", klog.KObj(sub))
 			return
 		}
-		sched.framework.EventRecorder().Eventf(
+		fwk.EventRecorder().Eventf(
 			sub,
 			corev1.EventTypeNormal,
 			"Scheduled",
 			"Successfully bound %s to %s",
-			klog.KObj(sub), strings.Join(namespacedClusters, ","),
+			klog.KObj(sub), strings.Join(targetClusters.BindingClusters, ","),
 		)
 	}()
 
-	bindStatus := sched.framework.RunBindPlugins(ctx, sub, namespacedClusters)
+	bound, err := sched.extendersBinding(sub, targetClusters)
+	if bound {
+		return err
+	}
+
+	bindStatus := fwk.RunBindPlugins(ctx, state, sub, targetClusters)
 	if bindStatus.IsSuccess() {
 		return nil
 	}
@@ -350,18 +470,99 @@ func (sched *Scheduler) bind(ctx context.Context, sub *appsapi.Subscription, nam
 	return fmt.Errorf("bind status: %s, %v", bindStatus.Code().String(), bindStatus.Message())
 }
 
+// extendersBinding delegates the binding to the first binder extender if there is any.
+// Extenders are shared by all the profiles, so a binder extender replaces the Bind plugins of every profile.
+// It is the binder extender's responsibility to write the binding clusters and the divided replicas
+// into the Subscription status, while PreBind and PostBind plugins still run around it in scheduleOne.
+func (sched *Scheduler) extendersBinding(sub *appsapi.Subscription, targetClusters framework.TargetClusters) (bool, error) {
+	for _, extender := range sched.Extenders {
+		if !extender.IsBinder() {
+			continue
+		}
+		return true, extender.Bind(&extenderv1alpha1.ExtenderBindingArgs{
+			SubscriptionName:      sub.Name,
+			SubscriptionNamespace: sub.Namespace,
+			SubscriptionUID:       sub.UID,
+			BindingClusters:       targetClusters.BindingClusters,
+			Replicas:              targetClusters.Replicas,
+		})
+	}
+	return false, nil
+}
+
 // recordSchedulingFailure records an event for the subscription that indicates the
-// subscription has failed to schedule. Also, update the subscription condition.
-func (sched *Scheduler) recordSchedulingFailure(sub *appsapi.Subscription, err error, _ string) {
+// subscription has failed to schedule, and puts it back to the scheduling queue.
+// Also, update the subscription condition.
+func (sched *Scheduler) recordSchedulingFailure(fwk framework.Framework, subInfo *framework.QueuedSubscriptionInfo,
+	err error, reason string, subSchedulingCycle int64) {
+	sub := subInfo.Subscription
 	klog.V(2).InfoS("Unable to schedule subscription; waiting", "subscription", klog.KObj(sub), "err", err)
 
 	msg := truncateMessage(err.Error())
-	sched.framework.EventRecorder().Event(sub, corev1.EventTypeWarning, "FailedScheduling", msg)
+	fwk.EventRecorder().Event(sub, corev1.EventTypeWarning, "FailedScheduling", msg)
 
-	// TODO: update subscription condition
+	var unschedulablePlugins []string
+	var fitErr *framework.FitError
+	if errors.As(err, &fitErr) {
+		unschedulablePlugins = fitErr.Diagnosis.UnschedulablePlugins.List()
+		subInfo.UnschedulablePlugins = fitErr.Diagnosis.UnschedulablePlugins
+	}
+	sched.updateScheduledCondition(context.TODO(), sub, metav1.Condition{
+		Type:    appsapi.SubscriptionScheduled,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: msg,
+	}, unschedulablePlugins)
+
+	// Check if the subscription still exists in the informer cache, and put it back to the queue.
+	cachedSub, err := sched.subsLister.Subscriptions(sub.Namespace).Get(sub.Name)
+	if err != nil {
+		klog.InfoS("Subscription doesn't exist in informer cache", "subscription", klog.KObj(sub), "err", err)
+		return
+	}
+	if cachedSub.DeletionTimestamp != nil {
+		return
+	}
+	// As <cachedSub> is from SharedInformer, we need to do a DeepCopy() here.
+	subInfo.Subscription = cachedSub.DeepCopy()
+	if err := sched.SchedulingQueue.AddUnschedulableIfNotPresent(subInfo, subSchedulingCycle); err != nil {
+		klog.V(4).InfoS("Skipped re-queuing the subscription", "subscription", klog.KObj(sub), "err", err)
+	}
+}
 
-	// re-added to the queue for re-processing
-	sched.SchedulingQueue.AddRateLimited(klog.KObj(sub).String())
+// enqueueSubscription adds the subscription with the given key to the scheduling queue.
+func (sched *Scheduler) enqueueSubscription(key string) {
+	ns, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return
+	}
+	sub, err := sched.subsLister.Subscriptions(ns).Get(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			utilruntime.HandleError(err)
+		}
+		return
+	}
+	if err := sched.SchedulingQueue.Add(sub); err != nil {
+		utilruntime.HandleError(fmt.Errorf("unable to queue subscription %s: %v", key, err))
+	}
+}
+
+// updateScheduledCondition sets the Scheduled condition of a subscription, together with the plugins
+// that rejected it and the generation that has been observed.
+func (sched *Scheduler) updateScheduledCondition(ctx context.Context, sub *appsapi.Subscription,
+	condition metav1.Condition, unschedulablePlugins []string) {
+	condition.ObservedGeneration = sub.Generation
+	err := utils.UpdateSubscriptionStatus(ctx, sched.clusternetClient, sub, func(status *appsapi.SubscriptionStatus) {
+		status.ObservedGeneration = sub.Generation
+		status.UnschedulablePlugins = unschedulablePlugins
+		meta.SetStatusCondition(&status.Conditions, condition)
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to update the condition of subscription",
+			"subscription", klog.KObj(sub), "condition", condition.Type)
+	}
 }
 
 // addAllEventHandlers is a helper function used in tests and in Scheduler
@@ -376,14 +577,16 @@ func (sched *Scheduler) addAllEventHandlers() {
 					sched.lock.Lock()
 					defer sched.lock.Unlock()
 					delete(sched.subscribersMap, klog.KObj(sub).String())
+					if err := sched.SchedulingQueue.Delete(sub); err != nil {
+						utilruntime.HandleError(fmt.Errorf("unable to dequeue subscription %s: %v", klog.KObj(sub), err))
+					}
 					return false
 				}
 
-				// TODO: filter scheduler name
-				return true
+				return sched.Profiles.HandlesSchedulerName(sub.Spec.SchedulerName)
 			case cache.DeletedFinalStateUnknown:
-				if _, ok := t.Obj.(*appsapi.Subscription); ok {
-					return true
+				if sub, ok := t.Obj.(*appsapi.Subscription); ok {
+					return sched.Profiles.HandlesSchedulerName(sub.Spec.SchedulerName)
 				}
 				utilruntime.HandleError(fmt.Errorf("unable to convert object %T to *Subscription in %T", obj, sched))
 				return false
@@ -398,7 +601,9 @@ func (sched *Scheduler) addAllEventHandlers() {
 				sched.lock.Lock()
 				defer sched.lock.Unlock()
 				sched.subscribersMap[klog.KObj(sub).String()] = sub.Spec.Subscribers
-				sched.SchedulingQueue.AddRateLimited(klog.KObj(sub).String())
+				if err := sched.SchedulingQueue.Add(sub); err != nil {
+					utilruntime.HandleError(fmt.Errorf("unable to queue subscription %s: %v", klog.KObj(sub), err))
+				}
 			},
 			UpdateFunc: func(oldObj, newObj interface{}) {
 				oldSub := oldObj.(*appsapi.Subscription)
@@ -413,27 +618,55 @@ func (sched *Scheduler) addAllEventHandlers() {
 				sched.lock.Lock()
 				defer sched.lock.Unlock()
 				sched.subscribersMap[klog.KObj(newSub).String()] = newSub.Spec.Subscribers
-				sched.SchedulingQueue.AddRateLimited(klog.KObj(newSub).String())
+				if err := sched.SchedulingQueue.Update(oldSub, newSub); err != nil {
+					utilruntime.HandleError(fmt.Errorf("unable to update subscription %s in the scheduling queue: %v", klog.KObj(newSub), err))
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				var sub *appsapi.Subscription
+				switch t := obj.(type) {
+				case *appsapi.Subscription:
+					sub = t
+				case cache.DeletedFinalStateUnknown:
+					var ok bool
+					sub, ok = t.Obj.(*appsapi.Subscription)
+					if !ok {
+						return
+					}
+				default:
+					return
+				}
+
+				sched.lock.Lock()
+				defer sched.lock.Unlock()
+				delete(sched.subscribersMap, klog.KObj(sub).String())
+				if err := sched.SchedulingQueue.Delete(sub); err != nil {
+					utilruntime.HandleError(fmt.Errorf("unable to dequeue subscription %s: %v", klog.KObj(sub), err))
+				}
 			},
 		},
 	})
 
-	enqueueSubscriptionForClusterFunc := func(mcls *clusterapi.ManagedCluster) {
-		sched.lock.RLock()
-		defer sched.lock.RUnlock()
+	enqueueSubscriptionForClusterFunc := func(mcls *clusterapi.ManagedCluster, event string) {
+		matchedKeys := sched.subscriptionsForCluster(mcls)
 
-		for key, subscribers := range sched.subscribersMap {
-			for _, subscriber := range subscribers {
-				selector, err := metav1.LabelSelectorAsSelector(subscriber.ClusterAffinity)
-				if err != nil {
-					klog.ErrorDepth(5, fmt.Sprintf("failed to parse labelSelector in Subscription %s: %v", key, err))
-					continue
-				}
-				if !selector.Matches(labels.Set(mcls.Labels)) {
-					continue
-				}
-				sched.SchedulingQueue.AddRateLimited(key)
-				break
+		// unschedulable subscriptions are retried once they complete backing off
+		sched.SchedulingQueue.MoveAllToActiveOrBackoffQueue(event, func(sub *appsapi.Subscription) bool {
+			return matchedKeys.Has(klog.KObj(sub).String())
+		})
+
+		// scheduled subscriptions are rescheduled, since the cluster may become a candidate or no longer be one
+		for key := range matchedKeys {
+			ns, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				continue
+			}
+			sub, err := sched.subsLister.Subscriptions(ns).Get(name)
+			if err != nil || len(sub.Status.BindingClusters) == 0 {
+				continue
+			}
+			if err := sched.SchedulingQueue.Add(sub); err != nil {
+				utilruntime.HandleError(fmt.Errorf("unable to queue subscription %s: %v", key, err))
 			}
 		}
 	}
@@ -444,7 +677,7 @@ func (sched *Scheduler) addAllEventHandlers() {
 			if mcls.DeletionTimestamp != nil {
 				return
 			}
-			enqueueSubscriptionForClusterFunc(mcls)
+			enqueueSubscriptionForClusterFunc(mcls, internalqueue.ClusterAdd)
 		},
 		UpdateFunc: func(oldObj, newObj interface{}) {
 			oldMcls := oldObj.(*clusterapi.ManagedCluster)
@@ -454,12 +687,42 @@ func (sched *Scheduler) addAllEventHandlers() {
 				return
 			}
 
-			// no updates on the labels/taints of ManagedCluster
-			if reflect.DeepEqual(oldMcls.Labels, newMcls.Labels) && reflect.DeepEqual(oldMcls.Spec.Taints, newMcls.Spec.Taints) {
-				klog.V(4).Infof("no updates on the labels/taints of ManagedCluster %s, skipping syncing", klog.KObj(oldMcls))
+			if !reflect.DeepEqual(oldMcls.Labels, newMcls.Labels) {
+				enqueueSubscriptionForClusterFunc(newMcls, internalqueue.ClusterLabelChange)
+				return
+			}
+			if !reflect.DeepEqual(oldMcls.Spec.Taints, newMcls.Spec.Taints) {
+				enqueueSubscriptionForClusterFunc(newMcls, internalqueue.ClusterTaintChange)
 				return
 			}
-			enqueueSubscriptionForClusterFunc(newMcls)
+
+			if oldMcls.Status.KubernetesVersion != newMcls.Status.KubernetesVersion ||
+				!reflect.DeepEqual(oldMcls.Status.ServedAPIs, newMcls.Status.ServedAPIs) {
+				enqueueSubscriptionForClusterFunc(newMcls, internalqueue.ClusterAPIChange)
+				return
+			}
+
+			// Subscriptions bound to a cluster that becomes not ready are re-queued by the failover controller
+			// after the grace period, so only the clusters getting ready are handled here.
+			if clusterReadinessChanged(oldMcls, newMcls) {
+				if _, notReady := failover.NotReadySince(newMcls); !notReady {
+					enqueueSubscriptionForClusterFunc(newMcls, internalqueue.ClusterReadinessChange)
+				}
+				return
+			}
+
+			// replicas of dynamic Dividing subscriptions need to be re-divided when the cluster grows or shrinks
+			if !apiequality.Semantic.DeepEqual(oldMcls.Status.Allocatable, newMcls.Status.Allocatable) ||
+				!apiequality.Semantic.DeepEqual(oldMcls.Status.Requested, newMcls.Status.Requested) {
+				sched.enqueueDynamicDividingSubscriptionsForCluster(newMcls)
+				// freed resources may make unschedulable subscriptions schedulable
+				matchedKeys := sched.subscriptionsForCluster(newMcls)
+				sched.SchedulingQueue.MoveAllToActiveOrBackoffQueue(internalqueue.ClusterAllocatableChange, func(sub *appsapi.Subscription) bool {
+					return matchedKeys.Has(klog.KObj(sub).String())
+				})
+				return
+			}
+			klog.V(4).Infof("no updates on the labels/taints/APIs/readiness/resources of ManagedCluster %s, skipping syncing", klog.KObj(oldMcls))
 		},
 		DeleteFunc: func(obj interface{}) {
 			// when a ManagedCluster is deleted,
@@ -468,6 +731,150 @@ func (sched *Scheduler) addAllEventHandlers() {
 		},
 	})
 
+	// replicas of Dividing subscriptions need to be re-divided when the referred workloads change
+	sched.ClusternetInformerFactory.Apps().V1alpha1().Manifests().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldManifest := oldObj.(*appsapi.Manifest)
+			newManifest := newObj.(*appsapi.Manifest)
+
+			if newManifest.DeletionTimestamp != nil {
+				return
+			}
+			if reflect.DeepEqual(oldManifest.Template, newManifest.Template) {
+				klog.V(4).Infof("no updates on the template of Manifest %s, skipping syncing", klog.KObj(oldManifest))
+				return
+			}
+			sched.enqueueDividingSubscriptionsForManifest(newManifest)
+		},
+	})
+}
+
+// subscriptionsForCluster returns the keys of the subscriptions whose subscribers match the given ManagedCluster.
+func (sched *Scheduler) subscriptionsForCluster(mcls *clusterapi.ManagedCluster) sets.String {
+	sched.lock.RLock()
+	defer sched.lock.RUnlock()
+
+	keys := sets.NewString()
+	for key, subscribers := range sched.subscribersMap {
+		for _, subscriber := range subscribers {
+			selector, err := metav1.LabelSelectorAsSelector(subscriber.ClusterAffinity)
+			if err != nil {
+				klog.ErrorDepth(5, fmt.Sprintf("failed to parse labelSelector in Subscription %s: %v", key, err))
+				continue
+			}
+			if !selector.Matches(labels.Set(mcls.Labels)) {
+				continue
+			}
+			keys.Insert(key)
+			break
+		}
+	}
+	return keys
+}
+
+// enqueueDividingSubscriptionsForManifest enqueues all the Dividing subscriptions that refer to the given Manifest.
+func (sched *Scheduler) enqueueDividingSubscriptionsForManifest(manifest *appsapi.Manifest) {
+	subs, err := sched.subsLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list subscriptions: %v", err))
+		return
+	}
+
+	for _, sub := range subs {
+		if sub.Spec.SchedulingStrategy != appsapi.DividingSchedulingStrategyType || sub.DeletionTimestamp != nil {
+			continue
+		}
+		if !sched.Profiles.HandlesSchedulerName(sub.Spec.SchedulerName) {
+			continue
+		}
+		for _, feed := range sub.Spec.Feeds {
+			if !utils.IsDividableFeed(feed) {
+				continue
+			}
+			selector, err := utils.GetLabelsSelectorFromFeed(feed)
+			if err != nil {
+				klog.ErrorDepth(5, fmt.Sprintf("failed to parse feed in Subscription %s: %v", klog.KObj(sub), err))
+				continue
+			}
+			if !selector.Matches(labels.Set(manifest.Labels)) {
+				continue
+			}
+			if err := sched.SchedulingQueue.Add(sub); err != nil {
+				utilruntime.HandleError(fmt.Errorf("unable to queue subscription %s: %v", klog.KObj(sub), err))
+			}
+			break
+		}
+	}
+}
+
+// enqueueDynamicDividingSubscriptionsForCluster enqueues all the Dividing subscriptions with Dynamic type,
+// which could be scheduled to the given ManagedCluster.
+func (sched *Scheduler) enqueueDynamicDividingSubscriptionsForCluster(mcls *clusterapi.ManagedCluster) {
+	subs, err := sched.subsLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list subscriptions: %v", err))
+		return
+	}
+
+	for _, sub := range subs {
+		if sub.Spec.SchedulingStrategy != appsapi.DividingSchedulingStrategyType || sub.DeletionTimestamp != nil {
+			continue
+		}
+		if sub.Spec.DividingScheduling == nil || sub.Spec.DividingScheduling.Type != appsapi.DynamicReplicaDividingType {
+			continue
+		}
+		if !sched.Profiles.HandlesSchedulerName(sub.Spec.SchedulerName) {
+			continue
+		}
+		for _, subscriber := range sub.Spec.Subscribers {
+			selector, err := metav1.LabelSelectorAsSelector(subscriber.ClusterAffinity)
+			if err != nil {
+				klog.ErrorDepth(5, fmt.Sprintf("failed to parse labelSelector in Subscription %s: %v", klog.KObj(sub), err))
+				continue
+			}
+			if !selector.Matches(labels.Set(mcls.Labels)) {
+				continue
+			}
+			if err := sched.SchedulingQueue.Add(sub); err != nil {
+				utilruntime.HandleError(fmt.Errorf("unable to queue subscription %s: %v", klog.KObj(sub), err))
+			}
+			break
+		}
+	}
+}
+
+// clusterReadinessChanged checks whether the readiness of a ManagedCluster changes,
+// which decides whether the cluster passes the ClusterReady filter.
+func clusterReadinessChanged(oldMcls, newMcls *clusterapi.ManagedCluster) bool {
+	if oldMcls.Status.Readyz != newMcls.Status.Readyz {
+		return true
+	}
+	oldReady := meta.FindStatusCondition(oldMcls.Status.Conditions, clusterapi.ClusterReady)
+	newReady := meta.FindStatusCondition(newMcls.Status.Conditions, clusterapi.ClusterReady)
+	if oldReady == nil || newReady == nil {
+		return oldReady != newReady
+	}
+	return oldReady.Status != newReady.Status || !oldReady.LastTransitionTime.Equal(&newReady.LastTransitionTime)
+}
+
+// frameworkForSubscription returns the framework of the profile that the subscription claims.
+func (sched *Scheduler) frameworkForSubscription(sub *appsapi.Subscription) (framework.Framework, error) {
+	fwk, ok := sched.Profiles[sub.Spec.SchedulerName]
+	if !ok {
+		return nil, fmt.Errorf("profile not found for scheduler name %q", sub.Spec.SchedulerName)
+	}
+	return fwk, nil
+}
+
+// getAttemptsLabel returns the label of the scheduling attempts of a subscription.
+// copied from k8s.io/kubernetes/pkg/scheduler/scheduler.go
+func getAttemptsLabel(subInfo *framework.QueuedSubscriptionInfo) string {
+	// We breakdown the subscription scheduling duration by attempts capped to a limit
+	// to avoid ending up with a high cardinality metric.
+	if subInfo.Attempts >= 15 {
+		return "15+"
+	}
+	return strconv.Itoa(subInfo.Attempts)
 }
 
 // truncateMessage truncates a message if it hits the NoteLengthLimit.
@@ -0,0 +1,66 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// BenchmarkScheduling measures the latency and the throughput of scheduling subscriptions
+// with the default plugins, against thousands of synthetic clusters.
+func BenchmarkScheduling(b *testing.B) {
+	tests := []struct {
+		numClusters      int
+		numSubscriptions int
+	}{
+		{numClusters: 1000, numSubscriptions: 100},
+		{numClusters: 5000, numSubscriptions: 100},
+	}
+
+	for _, test := range tests {
+		b.Run(fmt.Sprintf("%dClusters/%dSubscriptions", test.numClusters, test.numSubscriptions), func(b *testing.B) {
+			clusters := makeSyntheticClusters(test.numClusters)
+			subs, manifests := makeSyntheticSubscriptions(test.numSubscriptions)
+			objects := toObjects(clusters, subs, manifests)
+
+			var latencies []time.Duration
+			var elapsed time.Duration
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				h := newTestHarness(b, objects...)
+				ctx := h.start(b, test.numSubscriptions)
+				b.StartTimer()
+
+				start := time.Now()
+				latencies = append(latencies, h.scheduleAll(ctx, test.numSubscriptions)...)
+				h.waitForScheduled(b, test.numSubscriptions)
+				elapsed += time.Since(start)
+
+				b.StopTimer()
+				h.stop()
+				b.StartTimer()
+			}
+
+			b.ReportMetric(float64(test.numSubscriptions*b.N)/elapsed.Seconds(), "subscriptions/s")
+			b.ReportMetric(float64(percentile(latencies, 50).Microseconds())/1000, "p50-ms")
+			b.ReportMetric(float64(percentile(latencies, 99).Microseconds())/1000, "p99-ms")
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -38,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -303,6 +305,7 @@ func UpdateHelmReleaseStatus(ctx context.Context, clusternetClient *clusternetcl
 			desc.Status.Phase = appsapi.DescriptionPhaseFailure
 			desc.Status.Reason = status.Notes
 		}
+		desc.Status.ObservedGeneration = desc.Generation
 		_, err := clusternetClient.AppsV1alpha1().Descriptions(desc.Namespace).UpdateStatus(ctx, desc, metav1.UpdateOptions{})
 		if err == nil {
 			return nil
@@ -343,7 +346,10 @@ func ApplyDescription(ctx context.Context, clusternetClient *clusternetclientset
 	wg := sync.WaitGroup{}
 	objectsToBeDeployed := desc.Spec.Raw
 	errCh := make(chan error, len(objectsToBeDeployed))
-	for _, object := range objectsToBeDeployed {
+	// each resource writes its own index, so no lock is needed
+	manifestStatuses := make([]*appsapi.ManifestStatus, len(objectsToBeDeployed))
+	resources := make([]*unstructured.Unstructured, len(objectsToBeDeployed))
+	for idx, object := range objectsToBeDeployed {
 		resource := &unstructured.Unstructured{}
 		err := resource.UnmarshalJSON(object)
 		if err != nil {
@@ -354,36 +360,89 @@ func ApplyDescription(ctx context.Context, clusternetClient *clusternetclientset
 			continue
 		}
 
-		labels := resource.GetLabels()
-		if labels == nil {
-			labels = map[string]string{}
-		}
-		labels[known.ObjectOwnedByDescriptionLabel] = desc.Namespace + "." + desc.Name
-		resource.SetLabels(labels)
-		wg.Add(1)
-		go func(resource *unstructured.Unstructured) {
-			defer wg.Done()
+		setOwnedByDescriptionLabel(resource, desc)
+		resources[idx] = resource
+	}
 
-			// dryApply means do not apply resources, just add sub resource watcher.
+	// resources are applied in waves, where each wave waits for the ones it depends on
+	dependencies := getResourceDependencies(resources)
+	waves, sortErr := getResourceWaves(resources, dependencies)
+	if sortErr != nil {
+		allErrs = append(allErrs, sortErr)
+		msg := fmt.Sprintf("failed to sort resources of Description %s: %v", klog.KObj(desc), sortErr)
+		klog.ErrorDepth(5, msg)
+		recorder.Event(desc, corev1.EventTypeWarning, "FailedSortingResources", msg)
+	}
+	for _, wave := range waves {
+		for _, idx := range wave {
 			if !dryApply {
-				retryErr := ApplyResourceWithRetry(ctx, dynamicClient, discoveryRESTMapper, resource)
-				if retryErr != nil {
-					errCh <- retryErr
-					return
+				if dep := getUnappliedDependency(manifestStatuses, dependencies[idx]); dep != nil {
+					manifestStatuses[idx] = &appsapi.ManifestStatus{
+						Feed:    getResourceFeed(resources[idx]),
+						Phase:   appsapi.ManifestPhaseFailed,
+						Message: fmt.Sprintf("waiting for dependency %s to be applied", FormatFeed(dep.Feed)),
+					}
+					continue
 				}
 			}
 
-			if utilfeature.DefaultFeatureGate.Enabled(features.Recovery) && callbackHandler != nil {
-				callbackErr := callbackHandler(resource)
-				if callbackErr != nil {
-					errCh <- callbackErr
-					return
+			wg.Add(1)
+			go func(idx int, resource *unstructured.Unstructured) {
+				defer wg.Done()
+
+				// dryApply means do not apply resources, just add sub resource watcher.
+				if !dryApply {
+					retryErr := ApplyResourceWithRetry(ctx, dynamicClient, discoveryRESTMapper, resource,
+						desc.Spec.ConflictResolution)
+					switch {
+					case retryErr == nil:
+						manifestStatuses[idx] = getManifestStatus(ctx, dynamicClient, discoveryRESTMapper, resource, false)
+					case IsFieldManagerConflict(retryErr):
+						// conflicts are only reported in the status, since retrying won't resolve them.
+						// The resource is still watched, so it gets applied again once the conflicts are gone.
+						manifestStatuses[idx] = &appsapi.ManifestStatus{
+							Feed:    getResourceFeed(resource),
+							Phase:   appsapi.ManifestPhaseConflicted,
+							Message: retryErr.Error(),
+						}
+					default:
+						manifestStatuses[idx] = &appsapi.ManifestStatus{
+							Feed:    getResourceFeed(resource),
+							Phase:   appsapi.ManifestPhaseFailed,
+							Message: retryErr.Error(),
+						}
+						errCh <- retryErr
+						return
+					}
 				}
-			}
-		}(resource)
 
+				if utilfeature.DefaultFeatureGate.Enabled(features.Recovery) && callbackHandler != nil {
+					callbackErr := callbackHandler(resource)
+					if callbackErr != nil {
+						errCh <- callbackErr
+						return
+					}
+				}
+			}(idx, resources[idx])
+		}
+		wg.Wait()
+
+		if dryApply {
+			continue
+		}
+		// custom resources can only be applied after their CustomResourceDefinitions get established
+		for _, idx := range wave {
+			status := manifestStatuses[idx]
+			if resources[idx].GroupVersionKind().GroupKind() != crdGroupKind || status.Phase != appsapi.ManifestPhaseApplied {
+				continue
+			}
+			if err := waitForCRDEstablished(ctx, dynamicClient, discoveryRESTMapper, resources[idx]); err != nil {
+				status.Phase = appsapi.ManifestPhaseFailed
+				status.Message = err.Error()
+				allErrs = append(allErrs, err)
+			}
+		}
 	}
-	wg.Wait()
 
 	// collect errors
 	close(errCh)
@@ -399,6 +458,13 @@ func ApplyDescription(ctx context.Context, clusternetClient *clusternetclientset
 		return nil
 	}
 
+	var conflicts []string
+	for _, status := range manifestStatuses {
+		if status != nil && status.Phase == appsapi.ManifestPhaseConflicted {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s", FormatFeed(status.Feed), status.Message))
+		}
+	}
+
 	var statusPhase appsapi.DescriptionPhase
 	var reason string
 	if len(allErrs) > 0 {
@@ -408,18 +474,39 @@ func ApplyDescription(ctx context.Context, clusternetClient *clusternetclientset
 		msg := fmt.Sprintf("failed to deploying Description %s: %s", klog.KObj(desc), reason)
 		klog.ErrorDepth(5, msg)
 		recorder.Event(desc, corev1.EventTypeWarning, "UnSuccessfullyDeployed", msg)
-	} else {
-		statusPhase = appsapi.DescriptionPhaseSuccess
-		reason = ""
+	} else if len(conflicts) > 0 {
+		statusPhase = appsapi.DescriptionPhaseFailure
+		reason = strings.Join(conflicts, "; ")
 
-		msg := fmt.Sprintf("Description %s is deployed successfully", klog.KObj(desc))
-		klog.V(5).Info(msg)
-		recorder.Event(desc, corev1.EventTypeNormal, "SuccessfullyDeployed", msg)
+		msg := fmt.Sprintf("Description %s conflicts with other field managers: %s", klog.KObj(desc), reason)
+		klog.WarningDepth(5, msg)
+		recorder.Event(desc, corev1.EventTypeWarning, "ConflictedDeployment", msg)
+	} else {
+		statusPhase, reason = getDescriptionPhaseByHealth(manifestStatuses)
+		switch statusPhase {
+		case appsapi.DescriptionPhaseDegraded:
+			msg := fmt.Sprintf("Description %s is deployed but degraded: %s", klog.KObj(desc), reason)
+			klog.WarningDepth(5, msg)
+			recorder.Event(desc, corev1.EventTypeWarning, "DegradedDeployment", msg)
+		case appsapi.DescriptionPhaseProgressing:
+			klog.V(5).Infof("Description %s is deployed and progressing: %s", klog.KObj(desc), reason)
+		default:
+			msg := fmt.Sprintf("Description %s is deployed successfully", klog.KObj(desc))
+			klog.V(5).Info(msg)
+			recorder.Event(desc, corev1.EventTypeNormal, "SuccessfullyDeployed", msg)
+		}
 	}
 
 	// update status
 	desc.Status.Phase = statusPhase
 	desc.Status.Reason = reason
+	desc.Status.ObservedGeneration = desc.Generation
+	desc.Status.ManifestStatuses = nil
+	for _, status := range manifestStatuses {
+		if status != nil {
+			desc.Status.ManifestStatuses = append(desc.Status.ManifestStatuses, *status)
+		}
+	}
 	_, err := clusternetClient.AppsV1alpha1().Descriptions(desc.Namespace).UpdateStatus(context.TODO(), desc, metav1.UpdateOptions{})
 
 	if len(allErrs) > 0 {
@@ -428,6 +515,103 @@ func ApplyDescription(ctx context.Context, clusternetClient *clusternetclientset
 	return err
 }
 
+// DescriptionNeedsApplying tells whether the resources of a Description need to be applied to the child cluster,
+// other than only refreshing their statuses. Resources that have been applied are left untouched,
+// unless their drifts are to be corrected.
+func DescriptionNeedsApplying(desc *appsapi.Description) bool {
+	if len(desc.Spec.DriftPolicy) == 0 || desc.Spec.DriftPolicy == appsapi.DriftPolicyCorrect {
+		return true
+	}
+	if desc.Status.ObservedGeneration != desc.Generation {
+		return true
+	}
+	switch desc.Status.Phase {
+	case appsapi.DescriptionPhaseSuccess, appsapi.DescriptionPhaseProgressing, appsapi.DescriptionPhaseDegraded:
+		return false
+	default:
+		return true
+	}
+}
+
+// RefreshDescriptionStatus re-assesses the resources of a Description without applying them,
+// and records the drifted fields of each resource for DriftPolicyReport.
+func RefreshDescriptionStatus(ctx context.Context, clusternetClient *clusternetclientset.Clientset, dynamicClient dynamic.Interface,
+	discoveryRESTMapper meta.RESTMapper, desc *appsapi.Description, recorder record.EventRecorder,
+	callbackHandler ResourceCallbackHandler) error {
+	detectDrift := desc.Spec.DriftPolicy == appsapi.DriftPolicyReport
+
+	var allErrs []error
+	var manifestStatuses []*appsapi.ManifestStatus
+	for _, object := range desc.Spec.Raw {
+		resource := &unstructured.Unstructured{}
+		if err := resource.UnmarshalJSON(object); err != nil {
+			allErrs = append(allErrs, err)
+			continue
+		}
+		setOwnedByDescriptionLabel(resource, desc)
+		manifestStatuses = append(manifestStatuses,
+			getManifestStatus(ctx, dynamicClient, discoveryRESTMapper, resource, detectDrift))
+
+		if utilfeature.DefaultFeatureGate.Enabled(features.Recovery) && callbackHandler != nil {
+			if err := callbackHandler(resource); err != nil {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+	if len(allErrs) > 0 {
+		return utilerrors.NewAggregate(allErrs)
+	}
+
+	lastDrifts := map[string][]string{}
+	for _, status := range desc.Status.ManifestStatuses {
+		lastDrifts[FormatFeed(status.Feed)] = status.DriftedFields
+	}
+	var drifts []string
+	driftChanged := false
+	for _, status := range manifestStatuses {
+		if !reflect.DeepEqual(lastDrifts[FormatFeed(status.Feed)], status.DriftedFields) {
+			driftChanged = true
+		}
+		if len(status.DriftedFields) > 0 {
+			drifts = append(drifts, fmt.Sprintf("%s: %s", FormatFeed(status.Feed), strings.Join(status.DriftedFields, ", ")))
+		}
+	}
+	if driftChanged && len(drifts) > 0 {
+		msg := fmt.Sprintf("resources of Description %s drift from the desired state: %s", klog.KObj(desc),
+			strings.Join(drifts, "; "))
+		klog.WarningDepth(5, msg)
+		recorder.Event(desc, corev1.EventTypeWarning, "DriftDetected", msg)
+	}
+
+	desc.Status.Phase, desc.Status.Reason = getDescriptionPhaseByHealth(manifestStatuses)
+	desc.Status.ManifestStatuses = nil
+	for _, status := range manifestStatuses {
+		desc.Status.ManifestStatuses = append(desc.Status.ManifestStatuses, *status)
+	}
+	_, err := clusternetClient.AppsV1alpha1().Descriptions(desc.Namespace).UpdateStatus(context.TODO(), desc, metav1.UpdateOptions{})
+	return err
+}
+
+// getUnappliedDependency returns the status of the first dependency that is not applied, or nil if all are applied.
+func getUnappliedDependency(manifestStatuses []*appsapi.ManifestStatus, dependencies []int) *appsapi.ManifestStatus {
+	for _, depIdx := range dependencies {
+		if status := manifestStatuses[depIdx]; status != nil && status.Phase != appsapi.ManifestPhaseApplied {
+			return status
+		}
+	}
+	return nil
+}
+
+// setOwnedByDescriptionLabel labels a resource with the Description it belongs to.
+func setOwnedByDescriptionLabel(resource *unstructured.Unstructured, desc *appsapi.Description) {
+	labels := resource.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[known.ObjectOwnedByDescriptionLabel] = desc.Namespace + "." + desc.Name
+	resource.SetLabels(labels)
+}
+
 func OffloadDescription(ctx context.Context, clusternetClient *clusternetclientset.Clientset, dynamicClient dynamic.Interface,
 	discoveryRESTMapper meta.RESTMapper, desc *appsapi.Description, recorder record.EventRecorder) error {
 	var err error
@@ -435,7 +619,8 @@ func OffloadDescription(ctx context.Context, clusternetClient *clusternetclients
 	wg := sync.WaitGroup{}
 	objectsToBeDeleted := desc.Spec.Raw
 	errCh := make(chan error, len(objectsToBeDeleted))
-	for _, object := range objectsToBeDeleted {
+	resources := make([]*unstructured.Unstructured, len(objectsToBeDeleted))
+	for idx, object := range objectsToBeDeleted {
 		resource := &unstructured.Unstructured{}
 		err := resource.UnmarshalJSON(object)
 		if err != nil {
@@ -443,20 +628,28 @@ func OffloadDescription(ctx context.Context, clusternetClient *clusternetclients
 			msg := fmt.Sprintf("failed to unmarshal resource: %v", err)
 			klog.ErrorDepth(5, msg)
 			recorder.Event(desc, corev1.EventTypeWarning, "FailedMarshalingResource", msg)
-		} else {
+			continue
+		}
+		resources[idx] = resource
+	}
+
+	// resources are offloaded in the reverse order of applying
+	for _, wave := range getDeletionWaves(resources) {
+		for _, idx := range wave {
 			wg.Add(1)
 			go func(resource *unstructured.Unstructured) {
 				defer wg.Done()
-				klog.V(5).Infof("deleting %s %s defined in Description %s", resource.GetKind(),
-					klog.KObj(resource), klog.KObj(desc))
-				err := DeleteResourceWithRetry(ctx, dynamicClient, discoveryRESTMapper, resource)
+				deletionPolicy := GetDeletionPolicy(resource, desc.Spec.DeletionPolicy)
+				klog.V(5).Infof("offloading %s %s defined in Description %s with deletion policy %s", resource.GetKind(),
+					klog.KObj(resource), klog.KObj(desc), deletionPolicy)
+				err := OffloadResourceWithRetry(ctx, dynamicClient, discoveryRESTMapper, resource, deletionPolicy)
 				if err != nil {
 					errCh <- err
 				}
-			}(resource)
+			}(resources[idx])
 		}
+		wg.Wait()
 	}
-	wg.Wait()
 
 	// collect errors
 	close(errCh)
@@ -483,12 +676,26 @@ func OffloadDescription(ctx context.Context, clusternetClient *clusternetclients
 	return err
 }
 
-func ApplyResourceWithRetry(ctx context.Context, dynamicClient dynamic.Interface, restMapper meta.RESTMapper, resource *unstructured.Unstructured) error {
+// ApplyResourceWithRetry server-side applies a resource to the child cluster as the field manager of Clusternet,
+// so the fields owned by other field managers, like the replicas scaled by HorizontalPodAutoscalers, are kept.
+// Conflicting fields are taken over unless conflictResolution is ConflictResolutionReport, in which case the
+// conflict error is returned without retrying.
+func ApplyResourceWithRetry(ctx context.Context, dynamicClient dynamic.Interface, restMapper meta.RESTMapper,
+	resource *unstructured.Unstructured, conflictResolution appsapi.ConflictResolution) error {
 	// set UID as empty
 	resource.SetUID("")
+	// apply requests with managedFields or a stale resourceVersion will be rejected
+	resource.SetResourceVersion("")
+	resource.SetManagedFields(nil)
+
+	data, err := resource.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	force := conflictResolution != appsapi.ConflictResolutionReport
 
 	var lastError error
-	err := wait.ExponentialBackoffWithContext(ctx, retry.DefaultBackoff, func() (bool, error) {
+	err = wait.ExponentialBackoffWithContext(ctx, retry.DefaultBackoff, func() (bool, error) {
 		restMapping, err := restMapper.RESTMapping(resource.GroupVersionKind().GroupKind(), resource.GroupVersionKind().Version)
 		if err != nil {
 			lastError = fmt.Errorf("please check whether the advertised apiserver of current child cluster is accessible. %v", err)
@@ -496,54 +703,178 @@ func ApplyResourceWithRetry(ctx context.Context, dynamicClient dynamic.Interface
 		}
 
 		_, lastError = dynamicClient.Resource(restMapping.Resource).Namespace(resource.GetNamespace()).
-			Create(context.TODO(), resource, metav1.CreateOptions{})
+			Patch(context.TODO(), resource.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+				FieldManager: known.ClusternetFieldManager,
+				Force:        &force,
+			})
 		if lastError == nil {
 			return true, nil
 		}
-		if !apierrors.IsAlreadyExists(lastError) {
-			return false, nil
+		if IsFieldManagerConflict(lastError) {
+			// conflicts won't go away by retrying
+			return false, lastError
+		}
+		return false, nil
+	})
+
+	if err == nil {
+		return nil
+	}
+	return lastError
+}
+
+// IsFieldManagerConflict tells whether the error is caused by conflicting with other field managers
+// when server-side applying a resource.
+func IsFieldManagerConflict(err error) bool {
+	if !apierrors.IsConflict(err) {
+		return false
+	}
+	statusCauses, ok := getStatusCause(err)
+	if !ok {
+		return false
+	}
+	for _, cause := range statusCauses {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// getResourceFeed returns the feed that identifies a resource.
+func getResourceFeed(resource *unstructured.Unstructured) appsapi.Feed {
+	return appsapi.Feed{
+		Kind:       resource.GetKind(),
+		APIVersion: resource.GetAPIVersion(),
+		Namespace:  resource.GetNamespace(),
+		Name:       resource.GetName(),
+	}
+}
+
+// getManifestStatus returns the live status and the health of an applied resource in the child cluster,
+// along with the drifted fields if detectDrift is true. The health is unknown if the resource can not be retrieved.
+func getManifestStatus(ctx context.Context, dynamicClient dynamic.Interface, restMapper meta.RESTMapper,
+	resource *unstructured.Unstructured, detectDrift bool) *appsapi.ManifestStatus {
+	status := &appsapi.ManifestStatus{
+		Feed:   getResourceFeed(resource),
+		Phase:  appsapi.ManifestPhaseApplied,
+		Health: appsapi.HealthStateUnknown,
+	}
+
+	restMapping, err := restMapper.RESTMapping(resource.GroupVersionKind().GroupKind(), resource.GroupVersionKind().Version)
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	curObj, err := dynamicClient.Resource(restMapping.Resource).Namespace(resource.GetNamespace()).
+		Get(ctx, resource.GetName(), metav1.GetOptions{})
+	if err != nil {
+		status.Message = err.Error()
+		if detectDrift && apierrors.IsNotFound(err) {
+			status.DriftedFields = []string{"/"}
+		}
+		return status
+	}
+	if detectDrift {
+		status.DriftedFields = getDriftedFields(resource, curObj)
+	}
+
+	if observedStatus, ok := curObj.Object["status"]; ok {
+		status.ObservedStatus.Raw, err = json.Marshal(observedStatus)
+		if err != nil {
+			status.Message = err.Error()
+			return status
+		}
+	}
+	status.Health, status.Message = AssessHealth(curObj)
+	return status
+}
+
+// getDescriptionPhaseByHealth returns the phase of a Description whose resources are all applied,
+// along with the reason if any resources are not healthy. Resources of unknown health are not counted.
+func getDescriptionPhaseByHealth(manifestStatuses []*appsapi.ManifestStatus) (appsapi.DescriptionPhase, string) {
+	var degraded, progressing []string
+	for _, status := range manifestStatuses {
+		if status == nil {
+			continue
 		}
+		msg := fmt.Sprintf("%s: %s", FormatFeed(status.Feed), status.Message)
+		switch status.Health {
+		case appsapi.HealthStateDegraded:
+			degraded = append(degraded, msg)
+		case appsapi.HealthStateProgressing:
+			progressing = append(progressing, msg)
+		}
+	}
+
+	switch {
+	case len(degraded) > 0:
+		return appsapi.DescriptionPhaseDegraded, strings.Join(degraded, "; ")
+	case len(progressing) > 0:
+		return appsapi.DescriptionPhaseProgressing, strings.Join(progressing, "; ")
+	default:
+		return appsapi.DescriptionPhaseSuccess, ""
+	}
+}
+
+// GetDeletionPolicy returns the deletion policy of a resource, which is the one in the annotation
+// DeletionPolicyAnnotation if valid, otherwise the given default one.
+func GetDeletionPolicy(resource *unstructured.Unstructured, defaultPolicy appsapi.DeletionPolicy) appsapi.DeletionPolicy {
+	switch policy := appsapi.DeletionPolicy(resource.GetAnnotations()[known.DeletionPolicyAnnotation]); policy {
+	case appsapi.DeletionPolicyDelete, appsapi.DeletionPolicyOrphan:
+		return policy
+	case "":
+	default:
+		klog.Warningf("invalid value %q of annotation %s on %s %s, fall back to deletion policy %q", policy,
+			known.DeletionPolicyAnnotation, resource.GetKind(), klog.KObj(resource), defaultPolicy)
+	}
+	if len(defaultPolicy) == 0 {
+		return appsapi.DeletionPolicyDelete
+	}
+	return defaultPolicy
+}
+
+// OffloadResourceWithRetry deletes a resource from the child cluster, or orphans it with DeletionPolicyOrphan.
+func OffloadResourceWithRetry(ctx context.Context, dynamicClient dynamic.Interface, restMapper meta.RESTMapper,
+	resource *unstructured.Unstructured, deletionPolicy appsapi.DeletionPolicy) error {
+	if deletionPolicy == appsapi.DeletionPolicyOrphan {
+		return OrphanResourceWithRetry(ctx, dynamicClient, restMapper, resource)
+	}
+	return DeleteResourceWithRetry(ctx, dynamicClient, restMapper, resource)
+}
+
+// OrphanResourceWithRetry leaves a resource running in the child cluster, and strips the label
+// ObjectOwnedByDescriptionLabel from it, so that it is no longer managed by any Description.
+func OrphanResourceWithRetry(ctx context.Context, dynamicClient dynamic.Interface, restMapper meta.RESTMapper,
+	resource *unstructured.Unstructured) error {
+	patchData, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				known.ObjectOwnedByDescriptionLabel: nil,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
 
-		curObj, err := dynamicClient.Resource(restMapping.Resource).Namespace(resource.GetNamespace()).
-			Get(context.TODO(), resource.GetName(), metav1.GetOptions{})
+	var lastError error
+	err = wait.ExponentialBackoffWithContext(ctx, retry.DefaultBackoff, func() (bool, error) {
+		restMapping, err := restMapper.RESTMapping(resource.GroupVersionKind().GroupKind(), resource.GroupVersionKind().Version)
 		if err != nil {
-			lastError = err
+			lastError = fmt.Errorf("please check whether the advertised apiserver of current child cluster is accessible. %v", err)
 			return false, nil
-		} else {
-			lastError = nil
 		}
 
-		if ResourceNeedResync(curObj, resource) {
-			// try to update resource
-			_, lastError = dynamicClient.Resource(restMapping.Resource).Namespace(resource.GetNamespace()).
-				Update(context.TODO(), resource, metav1.UpdateOptions{})
-			if lastError == nil {
-				return true, nil
-			}
-			statusCauses, ok := getStatusCause(lastError)
-			if !ok {
-				lastError = fmt.Errorf("failed to get StatusCause for %s %s", resource.GetKind(), klog.KObj(resource))
-				return false, nil
-			}
-			resourceCopy := resource.DeepCopy()
-			for _, cause := range statusCauses {
-				if cause.Type != metav1.CauseTypeFieldValueInvalid {
-					continue
-				}
-				// apply immutable value
-				fields := strings.Split(cause.Field, ".")
-				setNestedField(resourceCopy, getNestedString(curObj.Object, fields...), fields...)
-			}
-			// update with immutable values applied
-			_, lastError = dynamicClient.Resource(restMapping.Resource).Namespace(resourceCopy.GetNamespace()).
-				Update(context.TODO(), resourceCopy, metav1.UpdateOptions{})
-			if lastError == nil {
-				return true, nil
-			}
+		_, lastError = dynamicClient.Resource(restMapping.Resource).Namespace(resource.GetNamespace()).
+			Patch(context.TODO(), resource.GetName(), types.MergePatchType, patchData, metav1.PatchOptions{
+				FieldManager: known.ClusternetFieldManager,
+			})
+		if lastError == nil || apierrors.IsNotFound(lastError) {
+			return true, nil
 		}
 		return false, nil
 	})
-
 	if err == nil {
 		return nil
 	}
@@ -574,27 +905,6 @@ func DeleteResourceWithRetry(ctx context.Context, dynamicClient dynamic.Interfac
 	return lastError
 }
 
-// copied from k8s.io/apimachinery/pkg/apis/meta/v1/unstructured
-func getNestedString(obj map[string]interface{}, fields ...string) string {
-	val, found, err := unstructured.NestedString(obj, fields...)
-	if !found || err != nil {
-		return ""
-	}
-	return val
-}
-
-// copied from k8s.io/apimachinery/pkg/apis/meta/v1/unstructured
-// and modified
-func setNestedField(u *unstructured.Unstructured, value interface{}, fields ...string) {
-	if u.Object == nil {
-		u.Object = make(map[string]interface{})
-	}
-	err := unstructured.SetNestedField(u.Object, value, fields...)
-	if err != nil {
-		klog.Warningf("failed to set nested field: %v", err)
-	}
-}
-
 // getStatusCause returns the named cause from the provided error if it exists and
 // the error is of the type APIStatus. Otherwise it returns false.
 func getStatusCause(err error) ([]metav1.StatusCause, bool) {
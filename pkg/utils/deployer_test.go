@@ -17,9 +17,20 @@ limitations under the License.
 package utils
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	"github.com/clusternet/clusternet/pkg/known"
 )
 
 func TestResourceNeedResync(t *testing.T) {
@@ -149,3 +160,177 @@ func TestResourceNeedResync(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFieldManagerConflict(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "apply conflict",
+			err: apierrors.NewApplyConflict([]metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kube-controller-manager" using apps/v1`,
+					Field:   ".spec.replicas",
+				},
+			}, `Apply failed with 1 conflict: conflict with "kube-controller-manager" using apps/v1: .spec.replicas`),
+			want: true,
+		},
+		{
+			name: "resource version conflict",
+			err: apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "foo",
+				errors.New("the object has been modified")),
+			want: false,
+		},
+		{
+			name: "invalid",
+			err:  apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "foo", nil),
+			want: false,
+		},
+		{
+			name: "non api error",
+			err:  errors.New("connection refused"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsFieldManagerConflict(tt.err); got != tt.want {
+				t.Errorf("IsFieldManagerConflict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescriptionNeedsApplying(t *testing.T) {
+	tests := []struct {
+		name        string
+		driftPolicy appsapi.DriftPolicy
+		generation  int64
+		status      appsapi.DescriptionStatus
+		want        bool
+	}{
+		{
+			name:       "drifts corrected by default",
+			generation: 1,
+			status:     appsapi.DescriptionStatus{Phase: appsapi.DescriptionPhaseSuccess, ObservedGeneration: 1},
+			want:       true,
+		},
+		{
+			name:        "drifts reported",
+			driftPolicy: appsapi.DriftPolicyReport,
+			generation:  1,
+			status:      appsapi.DescriptionStatus{Phase: appsapi.DescriptionPhaseProgressing, ObservedGeneration: 1},
+			want:        false,
+		},
+		{
+			name:        "spec updated",
+			driftPolicy: appsapi.DriftPolicyIgnore,
+			generation:  2,
+			status:      appsapi.DescriptionStatus{Phase: appsapi.DescriptionPhaseSuccess, ObservedGeneration: 1},
+			want:        true,
+		},
+		{
+			name:        "failed to apply",
+			driftPolicy: appsapi.DriftPolicyReport,
+			generation:  1,
+			status:      appsapi.DescriptionStatus{Phase: appsapi.DescriptionPhaseFailure, ObservedGeneration: 1},
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := &appsapi.Description{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar", Generation: tt.generation},
+				Spec:       appsapi.DescriptionSpec{DriftPolicy: tt.driftPolicy},
+				Status:     tt.status,
+			}
+			if got := DescriptionNeedsApplying(desc); got != tt.want {
+				t.Errorf("DescriptionNeedsApplying() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDeletionPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		annotation    string
+		defaultPolicy appsapi.DeletionPolicy
+		want          appsapi.DeletionPolicy
+	}{
+		{
+			name: "deleted by default",
+			want: appsapi.DeletionPolicyDelete,
+		},
+		{
+			name:          "policy of Description",
+			defaultPolicy: appsapi.DeletionPolicyOrphan,
+			want:          appsapi.DeletionPolicyOrphan,
+		},
+		{
+			name:          "overridden by annotation",
+			annotation:    "Delete",
+			defaultPolicy: appsapi.DeletionPolicyOrphan,
+			want:          appsapi.DeletionPolicyDelete,
+		},
+		{
+			name:          "invalid annotation",
+			annotation:    "Retain",
+			defaultPolicy: appsapi.DeletionPolicyOrphan,
+			want:          appsapi.DeletionPolicyOrphan,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := &unstructured.Unstructured{}
+			resource.SetKind("ConfigMap")
+			resource.SetName("foo")
+			if len(tt.annotation) > 0 {
+				resource.SetAnnotations(map[string]string{known.DeletionPolicyAnnotation: tt.annotation})
+			}
+			if got := GetDeletionPolicy(resource, tt.defaultPolicy); got != tt.want {
+				t.Errorf("GetDeletionPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrphanResourceWithRetry(t *testing.T) {
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+	restMapper.Add(gvk, meta.RESTScopeNamespace)
+
+	resource := &unstructured.Unstructured{}
+	resource.SetGroupVersionKind(gvk)
+	resource.SetNamespace("bar")
+	resource.SetName("foo")
+	resource.SetLabels(map[string]string{
+		known.ObjectOwnedByDescriptionLabel: "clusternet-abcde.foo-generic",
+		"app":                               "foo",
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{gvr: "ConfigMapList"}, resource.DeepCopy())
+
+	if err := OffloadResourceWithRetry(context.TODO(), dynamicClient, restMapper, resource,
+		appsapi.DeletionPolicyOrphan); err != nil {
+		t.Fatalf("failed to orphan resource: %v", err)
+	}
+
+	got, err := dynamicClient.Resource(gvr).Namespace("bar").Get(context.TODO(), "foo", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("orphaned resource should be kept: %v", err)
+	}
+	if _, ok := got.GetLabels()[known.ObjectOwnedByDescriptionLabel]; ok {
+		t.Errorf("label %s should be stripped from orphaned resource", known.ObjectOwnedByDescriptionLabel)
+	}
+	if got.GetLabels()["app"] != "foo" {
+		t.Errorf("other labels should be kept, got %v", got.GetLabels())
+	}
+}
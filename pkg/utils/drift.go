@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/clusternet/clusternet/pkg/known"
+)
+
+// driftFieldsToBeIgnored returns the fields that never drift, which are the ones ignored by ResourceNeedResync,
+// along with the ones maintained by the child cluster.
+func driftFieldsToBeIgnored() []string {
+	return append(fieldsToBeIgnored(), known.MetaResourceVersion, known.Status)
+}
+
+// getDriftedFields returns the sorted JSON pointers to the fields set in the desired object,
+// whose live values in the child cluster drift from the desired ones.
+// Fields only set in the live object, such as defaulted fields and fields owned by other field managers,
+// are not taken as drifts.
+func getDriftedFields(desired, live *unstructured.Unstructured) []string {
+	var driftedFields []string
+	compareFields(desired.Object, live.Object, "", driftFieldsToBeIgnored(), &driftedFields)
+	sort.Strings(driftedFields)
+	return driftedFields
+}
+
+func compareFields(desired, live interface{}, path string, ignoredFields []string, driftedFields *[]string) {
+	for _, field := range ignoredFields {
+		if path == field || strings.HasPrefix(path, field+"/") {
+			return
+		}
+	}
+
+	switch desiredValue := desired.(type) {
+	case nil:
+		// null sets nothing
+		return
+	case map[string]interface{}:
+		if len(desiredValue) == 0 {
+			return
+		}
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			*driftedFields = append(*driftedFields, path)
+			return
+		}
+		for key, value := range desiredValue {
+			compareFields(value, liveValue[key], path+"/"+escapeJSONPointer(key), ignoredFields, driftedFields)
+		}
+	case []interface{}:
+		if len(desiredValue) == 0 {
+			return
+		}
+		liveValue, ok := live.([]interface{})
+		if !ok {
+			*driftedFields = append(*driftedFields, path)
+			return
+		}
+		compareLists(desiredValue, liveValue, path, ignoredFields, driftedFields)
+	default:
+		if !scalarEqual(desiredValue, live) {
+			*driftedFields = append(*driftedFields, path)
+		}
+	}
+}
+
+// compareLists compares the items of lists by their names if all the desired items are named,
+// like containers and ports, so items injected into the live list are not taken as drifts.
+// Otherwise, items are compared by their indexes.
+func compareLists(desired, live []interface{}, path string, ignoredFields []string, driftedFields *[]string) {
+	if names := getItemNames(desired); names != nil {
+		liveItems := map[string]interface{}{}
+		for _, item := range live {
+			if name := getItemName(item); len(name) > 0 {
+				liveItems[name] = item
+			}
+		}
+		for idx, name := range names {
+			compareFields(desired[idx], liveItems[name], fmt.Sprintf("%s/%d", path, idx), ignoredFields, driftedFields)
+		}
+		return
+	}
+
+	if len(desired) != len(live) {
+		*driftedFields = append(*driftedFields, path)
+		return
+	}
+	for idx := range desired {
+		compareFields(desired[idx], live[idx], fmt.Sprintf("%s/%d", path, idx), ignoredFields, driftedFields)
+	}
+}
+
+// getItemNames returns the names of all the items, or nil if any item is not named.
+func getItemNames(items []interface{}) []string {
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		name := getItemName(item)
+		if len(name) == 0 {
+			return nil
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func getItemName(item interface{}) string {
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _, _ := unstructured.NestedString(obj, "name")
+	return name
+}
+
+// scalarEqual compares scalar values, where integers and floats of the same value are equal.
+func scalarEqual(x, y interface{}) bool {
+	if reflect.DeepEqual(x, y) {
+		return true
+	}
+	xf, ok := toFloat64(x)
+	if !ok {
+		return false
+	}
+	yf, ok := toFloat64(y)
+	return ok && xf == yf
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// escapeJSONPointer escapes a key as a reference token of JSON pointer, as defined in RFC 6901.
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
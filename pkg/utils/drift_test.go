@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetDriftedFields(t *testing.T) {
+	desired := `{"apiVersion":"apps/v1","kind":"Deployment",
+"metadata":{"name":"foo","namespace":"bar","creationTimestamp":null,"labels":{"apps.clusternet.io/owned-by-description":"bar.foo"}},
+"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"app","image":"nginx:1.21","resources":{}}]}}},
+"status":{}}`
+
+	tests := []struct {
+		name string
+		live string
+		want []string
+	}{
+		{
+			name: "defaulted and injected fields",
+			live: `{"apiVersion":"apps/v1","kind":"Deployment",
+"metadata":{"name":"foo","namespace":"bar","uid":"1234","resourceVersion":"42","generation":3,
+"creationTimestamp":"2021-12-01T00:00:00Z","labels":{"apps.clusternet.io/owned-by-description":"bar.foo"}},
+"spec":{"replicas":2,"revisionHistoryLimit":10,"template":{"spec":{"containers":[
+{"name":"istio-proxy","image":"istio/proxyv2"},
+{"name":"app","image":"nginx:1.21","imagePullPolicy":"IfNotPresent"}]}}},
+"status":{"replicas":2}}`,
+			want: nil,
+		},
+		{
+			name: "modified fields",
+			live: `{"apiVersion":"apps/v1","kind":"Deployment",
+"metadata":{"name":"foo","namespace":"bar"},
+"spec":{"replicas":5,"template":{"spec":{"containers":[{"name":"app","image":"nginx:latest"}]}}}}`,
+			want: []string{
+				"/metadata/labels",
+				"/spec/replicas",
+				"/spec/template/spec/containers/0/image",
+			},
+		},
+		{
+			name: "removed container",
+			live: `{"apiVersion":"apps/v1","kind":"Deployment",
+"metadata":{"name":"foo","namespace":"bar","labels":{"apps.clusternet.io/owned-by-description":"bar.foo"}},
+"spec":{"replicas":2.0,"template":{"spec":{"containers":[{"name":"sidecar","image":"busybox"}]}}}}`,
+			want: []string{
+				"/spec/template/spec/containers/0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desiredObj := &unstructured.Unstructured{}
+			if err := desiredObj.UnmarshalJSON([]byte(desired)); err != nil {
+				t.Fatalf("failed to unmarshal desired object: %v", err)
+			}
+			liveObj := &unstructured.Unstructured{}
+			if err := liveObj.UnmarshalJSON([]byte(tt.live)); err != nil {
+				t.Fatalf("failed to unmarshal live object: %v", err)
+			}
+			if got := getDriftedFields(desiredObj, liveObj); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getDriftedFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeJSONPointer(t *testing.T) {
+	if got := escapeJSONPointer("apps.clusternet.io/owned~by"); got != "apps.clusternet.io~1owned~0by" {
+		t.Errorf("escapeJSONPointer() = %v", got)
+	}
+}
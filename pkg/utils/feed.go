@@ -162,6 +162,11 @@ func HasFeed(feed appsapi.Feed, feeds []appsapi.Feed) bool {
 	return false
 }
 
+// GetFeedKey returns the key of a feed, which is used to index the divided replicas of a feed.
+func GetFeedKey(feed appsapi.Feed) string {
+	return fmt.Sprintf("%s/%s/%s/%s", feed.APIVersion, feed.Kind, feed.Namespace, feed.Name)
+}
+
 func HashSubscriptionSpec(subscriptionSpec *appsapi.SubscriptionSpec) uint64 {
 	specJSON, _ := json.Marshal(subscriptionSpec)
 	printer := spew.ConfigState{
@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+)
+
+// HealthCheckFunc assesses the health of a live resource in a child cluster,
+// and returns the health state along with a message.
+type HealthCheckFunc func(obj *unstructured.Unstructured) (appsapi.HealthState, string, error)
+
+var (
+	healthCheckersLock sync.RWMutex
+	healthCheckers     = map[schema.GroupKind]HealthCheckFunc{
+		{Group: "apps", Kind: "Deployment"}:        checkDeploymentHealth,
+		{Group: "apps", Kind: "StatefulSet"}:       checkStatefulSetHealth,
+		{Group: "apps", Kind: "DaemonSet"}:         checkDaemonSetHealth,
+		{Group: "batch", Kind: "Job"}:              checkJobHealth,
+		{Group: "", Kind: "Service"}:               checkServiceHealth,
+		{Group: "", Kind: "PersistentVolumeClaim"}: checkPersistentVolumeClaimHealth,
+	}
+)
+
+// RegisterHealthCheckFunc registers a HealthCheckFunc for the resources of a GroupKind,
+// which replaces the existing one if any.
+func RegisterHealthCheckFunc(gk schema.GroupKind, fn HealthCheckFunc) {
+	healthCheckersLock.Lock()
+	defer healthCheckersLock.Unlock()
+	healthCheckers[gk] = fn
+}
+
+// AssessHealth assesses the health of a live resource with the HealthCheckFunc registered for its GroupKind.
+// Resources without a registered HealthCheckFunc are assessed by their `.status.conditions`.
+func AssessHealth(obj *unstructured.Unstructured) (appsapi.HealthState, string) {
+	healthCheckersLock.RLock()
+	fn, ok := healthCheckers[obj.GroupVersionKind().GroupKind()]
+	healthCheckersLock.RUnlock()
+	if !ok {
+		fn = checkHealthByConditions
+	}
+
+	state, message, err := fn(obj)
+	if err != nil {
+		return appsapi.HealthStateUnknown, err.Error()
+	}
+	return state, message
+}
+
+func checkDeploymentHealth(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+	deploy := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, deploy); err != nil {
+		return "", "", err
+	}
+
+	if deploy.Generation > deploy.Status.ObservedGeneration {
+		return appsapi.HealthStateProgressing, "waiting for the spec update to be observed", nil
+	}
+	for _, condition := range deploy.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return appsapi.HealthStateDegraded, condition.Message, nil
+		}
+	}
+	replicas := int32(1)
+	if deploy.Spec.Replicas != nil {
+		replicas = *deploy.Spec.Replicas
+	}
+	switch {
+	case deploy.Status.UpdatedReplicas < replicas:
+		return appsapi.HealthStateProgressing, fmt.Sprintf("%d out of %d new replicas have been updated",
+			deploy.Status.UpdatedReplicas, replicas), nil
+	case deploy.Status.Replicas > deploy.Status.UpdatedReplicas:
+		return appsapi.HealthStateProgressing, fmt.Sprintf("%d old replicas are pending termination",
+			deploy.Status.Replicas-deploy.Status.UpdatedReplicas), nil
+	case deploy.Status.AvailableReplicas < deploy.Status.UpdatedReplicas:
+		return appsapi.HealthStateProgressing, fmt.Sprintf("%d of %d updated replicas are available",
+			deploy.Status.AvailableReplicas, deploy.Status.UpdatedReplicas), nil
+	}
+	return appsapi.HealthStateHealthy, "", nil
+}
+
+func checkStatefulSetHealth(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+	sts := &appsv1.StatefulSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, sts); err != nil {
+		return "", "", err
+	}
+
+	if sts.Generation > sts.Status.ObservedGeneration {
+		return appsapi.HealthStateProgressing, "waiting for the spec update to be observed", nil
+	}
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+	if sts.Status.ReadyReplicas < replicas {
+		return appsapi.HealthStateProgressing, fmt.Sprintf("%d of %d replicas are ready",
+			sts.Status.ReadyReplicas, replicas), nil
+	}
+	if sts.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType {
+		var partition int32
+		if sts.Spec.UpdateStrategy.RollingUpdate != nil && sts.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+			partition = *sts.Spec.UpdateStrategy.RollingUpdate.Partition
+		}
+		if sts.Status.UpdatedReplicas < replicas-partition {
+			return appsapi.HealthStateProgressing, fmt.Sprintf("%d of %d replicas have been updated",
+				sts.Status.UpdatedReplicas, replicas-partition), nil
+		}
+		if partition == 0 && sts.Status.UpdateRevision != sts.Status.CurrentRevision {
+			return appsapi.HealthStateProgressing, "waiting for the rolling update to complete", nil
+		}
+	}
+	return appsapi.HealthStateHealthy, "", nil
+}
+
+func checkDaemonSetHealth(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+	ds := &appsv1.DaemonSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, ds); err != nil {
+		return "", "", err
+	}
+
+	if ds.Generation > ds.Status.ObservedGeneration {
+		return appsapi.HealthStateProgressing, "waiting for the spec update to be observed", nil
+	}
+	if ds.Spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType &&
+		ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
+		return appsapi.HealthStateProgressing, fmt.Sprintf("%d of %d pods have been updated",
+			ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled), nil
+	}
+	if ds.Status.NumberAvailable < ds.Status.DesiredNumberScheduled {
+		return appsapi.HealthStateProgressing, fmt.Sprintf("%d of %d pods are available",
+			ds.Status.NumberAvailable, ds.Status.DesiredNumberScheduled), nil
+	}
+	return appsapi.HealthStateHealthy, "", nil
+}
+
+func checkJobHealth(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+	job := &batchv1.Job{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, job); err != nil {
+		return "", "", err
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			return appsapi.HealthStateDegraded, condition.Message, nil
+		case batchv1.JobComplete:
+			return appsapi.HealthStateHealthy, "", nil
+		}
+	}
+	return appsapi.HealthStateProgressing, fmt.Sprintf("%d pods are active", job.Status.Active), nil
+}
+
+func checkServiceHealth(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+	svc := &corev1.Service{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, svc); err != nil {
+		return "", "", err
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return appsapi.HealthStateProgressing, "waiting for the load balancer to be provisioned", nil
+	}
+	return appsapi.HealthStateHealthy, "", nil
+}
+
+func checkPersistentVolumeClaimHealth(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pvc); err != nil {
+		return "", "", err
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return appsapi.HealthStateHealthy, "", nil
+	case corev1.ClaimLost:
+		return appsapi.HealthStateDegraded, "the bound PersistentVolume is lost", nil
+	default:
+		return appsapi.HealthStateProgressing, "waiting for the claim to be bound", nil
+	}
+}
+
+// checkHealthByConditions assesses the health of a resource by the well-known types of its `.status.conditions`.
+// Resources without such conditions are healthy as long as they exist.
+func checkHealthByConditions(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return "", "", err
+	}
+
+	state, message := appsapi.HealthStateHealthy, ""
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(condition, "type")
+		conditionStatus, _, _ := unstructured.NestedString(condition, "status")
+		conditionMessage, _, _ := unstructured.NestedString(condition, "message")
+		switch conditionType {
+		case "Degraded", "Failed":
+			if conditionStatus == string(corev1.ConditionTrue) {
+				return appsapi.HealthStateDegraded, conditionMessage, nil
+			}
+		case "Ready", "Available":
+			if conditionStatus != string(corev1.ConditionTrue) {
+				state, message = appsapi.HealthStateProgressing, conditionMessage
+			}
+		}
+	}
+	return state, message, nil
+}
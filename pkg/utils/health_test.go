@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+)
+
+func TestAssessHealth(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want appsapi.HealthState
+	}{
+		{
+			name: "available deployment",
+			raw: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo","namespace":"bar","generation":2},
+"spec":{"replicas":2},"status":{"observedGeneration":2,"replicas":2,"updatedReplicas":2,"availableReplicas":2}}`,
+			want: appsapi.HealthStateHealthy,
+		},
+		{
+			name: "deployment rolling out",
+			raw: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo","namespace":"bar","generation":2},
+"spec":{"replicas":2},"status":{"observedGeneration":2,"replicas":3,"updatedReplicas":2,"availableReplicas":2}}`,
+			want: appsapi.HealthStateProgressing,
+		},
+		{
+			name: "deployment exceeding progress deadline",
+			raw: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo","namespace":"bar","generation":1},
+"spec":{"replicas":1},"status":{"observedGeneration":1,"conditions":[{"type":"Progressing","status":"False","reason":"ProgressDeadlineExceeded"}]}}`,
+			want: appsapi.HealthStateDegraded,
+		},
+		{
+			name: "statefulset not ready",
+			raw: `{"apiVersion":"apps/v1","kind":"StatefulSet","metadata":{"name":"foo","namespace":"bar","generation":1},
+"spec":{"replicas":3},"status":{"observedGeneration":1,"replicas":3,"readyReplicas":1}}`,
+			want: appsapi.HealthStateProgressing,
+		},
+		{
+			name: "daemonset available",
+			raw: `{"apiVersion":"apps/v1","kind":"DaemonSet","metadata":{"name":"foo","namespace":"bar","generation":1},
+"spec":{"updateStrategy":{"type":"RollingUpdate"}},"status":{"observedGeneration":1,"desiredNumberScheduled":2,"updatedNumberScheduled":2,"numberAvailable":2}}`,
+			want: appsapi.HealthStateHealthy,
+		},
+		{
+			name: "failed job",
+			raw: `{"apiVersion":"batch/v1","kind":"Job","metadata":{"name":"foo","namespace":"bar"},
+"status":{"failed":3,"conditions":[{"type":"Failed","status":"True","message":"BackoffLimitExceeded"}]}}`,
+			want: appsapi.HealthStateDegraded,
+		},
+		{
+			name: "running job",
+			raw:  `{"apiVersion":"batch/v1","kind":"Job","metadata":{"name":"foo","namespace":"bar"},"status":{"active":1}}`,
+			want: appsapi.HealthStateProgressing,
+		},
+		{
+			name: "load balancer pending",
+			raw:  `{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo","namespace":"bar"},"spec":{"type":"LoadBalancer"}}`,
+			want: appsapi.HealthStateProgressing,
+		},
+		{
+			name: "cluster ip service",
+			raw:  `{"apiVersion":"v1","kind":"Service","metadata":{"name":"foo","namespace":"bar"},"spec":{"type":"ClusterIP"}}`,
+			want: appsapi.HealthStateHealthy,
+		},
+		{
+			name: "bound pvc",
+			raw:  `{"apiVersion":"v1","kind":"PersistentVolumeClaim","metadata":{"name":"foo","namespace":"bar"},"status":{"phase":"Bound"}}`,
+			want: appsapi.HealthStateHealthy,
+		},
+		{
+			name: "configmap without status",
+			raw:  `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","namespace":"bar"}}`,
+			want: appsapi.HealthStateHealthy,
+		},
+		{
+			name: "custom resource not ready",
+			raw: `{"apiVersion":"example.com/v1","kind":"Database","metadata":{"name":"foo","namespace":"bar"},
+"status":{"conditions":[{"type":"Ready","status":"False","message":"provisioning"}]}}`,
+			want: appsapi.HealthStateProgressing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON([]byte(tt.raw)); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if got, msg := AssessHealth(obj); got != tt.want {
+				t.Errorf("AssessHealth() = %v (%s), want %v", got, msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterHealthCheckFunc(t *testing.T) {
+	gk := schema.GroupKind{Group: "example.com", Kind: "Widget"}
+	RegisterHealthCheckFunc(gk, func(obj *unstructured.Unstructured) (appsapi.HealthState, string, error) {
+		return appsapi.HealthStateDegraded, "always degraded", nil
+	})
+	defer func() {
+		healthCheckersLock.Lock()
+		delete(healthCheckers, gk)
+		healthCheckersLock.Unlock()
+	}()
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Widget")
+	if got, msg := AssessHealth(obj); got != appsapi.HealthStateDegraded || msg != "always degraded" {
+		t.Errorf("AssessHealth() = %v (%s), want %v", got, msg, appsapi.HealthStateDegraded)
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+
+	"github.com/clusternet/clusternet/pkg/known"
+)
+
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+// kindPriorities are the priorities of applying resources, where the ones with lower priorities are applied first.
+// Resources of other kinds, including custom resources, are applied last.
+var kindPriorities = map[schema.GroupKind]int{
+	{Group: "", Kind: "Namespace"}:                                   0,
+	crdGroupKind:                                                     1,
+	{Group: "", Kind: "ServiceAccount"}:                              2,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:        2,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}: 2,
+	{Group: "rbac.authorization.k8s.io", Kind: "Role"}:               2,
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"}:        2,
+	{Group: "", Kind: "ConfigMap"}:                                   3,
+	{Group: "", Kind: "Secret"}:                                      3,
+	{Group: "apps", Kind: "Deployment"}:                              4,
+	{Group: "apps", Kind: "StatefulSet"}:                             4,
+	{Group: "apps", Kind: "DaemonSet"}:                               4,
+	{Group: "apps", Kind: "ReplicaSet"}:                              4,
+	{Group: "batch", Kind: "Job"}:                                    4,
+	{Group: "batch", Kind: "CronJob"}:                                4,
+	{Group: "", Kind: "Pod"}:                                         4,
+}
+
+const otherKindPriority = 5
+
+const (
+	crdEstablishedInterval = time.Second
+	crdEstablishedTimeout  = 30 * time.Second
+)
+
+func getKindPriority(resource *unstructured.Unstructured) int {
+	if priority, ok := kindPriorities[resource.GroupVersionKind().GroupKind()]; ok {
+		return priority
+	}
+	return otherKindPriority
+}
+
+// getResourceDependencies returns the indexes of the resources that each resource depends on,
+// which are declared with the annotation DependsOnAnnotation. Nil resources are skipped, and so are
+// the dependencies not found in resources.
+func getResourceDependencies(resources []*unstructured.Unstructured) [][]int {
+	dependencies := make([][]int, len(resources))
+	for idx, resource := range resources {
+		if resource == nil {
+			continue
+		}
+		value := resource.GetAnnotations()[known.DependsOnAnnotation]
+		if len(value) == 0 {
+			continue
+		}
+		for _, ref := range strings.Split(value, ",") {
+			ref = strings.TrimSpace(ref)
+			if len(ref) == 0 {
+				continue
+			}
+			depIdx := findResource(resources, ref, resource.GetNamespace())
+			if depIdx < 0 {
+				klog.Warningf("dependency %q of %s %s is not found, ignoring it", ref, resource.GetKind(), klog.KObj(resource))
+				continue
+			}
+			dependencies[idx] = append(dependencies[idx], depIdx)
+		}
+	}
+	return dependencies
+}
+
+// findResource returns the index of the resource referred by ref in the form of "<Kind>/<namespace>/<name>"
+// or "<Kind>/<name>", or -1 if not found. The latter form refers to a resource in the given namespace
+// or a cluster-scoped one.
+func findResource(resources []*unstructured.Unstructured, ref, namespace string) int {
+	parts := strings.Split(ref, "/")
+	var kind, name string
+	namespaces := []string{namespace, ""}
+	switch len(parts) {
+	case 2:
+		kind, name = parts[0], parts[1]
+	case 3:
+		kind, name = parts[0], parts[2]
+		namespaces = []string{parts[1]}
+	default:
+		return -1
+	}
+
+	for idx, resource := range resources {
+		if resource == nil || resource.GetKind() != kind || resource.GetName() != name {
+			continue
+		}
+		if ContainsString(namespaces, resource.GetNamespace()) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// getResourceWaves sorts the indexes of resources into waves, which are applied one after another.
+// A resource comes after the ones of lower kind priorities and the ones it depends on.
+// Nil resources are skipped.
+func getResourceWaves(resources []*unstructured.Unstructured, dependencies [][]int) ([][]int, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := make([]int, len(resources))
+	levels := make([]int, len(resources))
+
+	var visit func(idx int) error
+	visit = func(idx int) error {
+		switch states[idx] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%s %s is in a dependency cycle", resources[idx].GetKind(), klog.KObj(resources[idx]))
+		}
+
+		states[idx] = visiting
+		levels[idx] = getKindPriority(resources[idx])
+		if idx < len(dependencies) {
+			for _, depIdx := range dependencies[idx] {
+				if err := visit(depIdx); err != nil {
+					return err
+				}
+				if levels[depIdx] >= levels[idx] {
+					levels[idx] = levels[depIdx] + 1
+				}
+			}
+		}
+		states[idx] = visited
+		return nil
+	}
+
+	wavesByLevel := map[int][]int{}
+	for idx, resource := range resources {
+		if resource == nil {
+			continue
+		}
+		if err := visit(idx); err != nil {
+			return nil, err
+		}
+		wavesByLevel[levels[idx]] = append(wavesByLevel[levels[idx]], idx)
+	}
+
+	var sortedLevels []int
+	for level := range wavesByLevel {
+		sortedLevels = append(sortedLevels, level)
+	}
+	sort.Ints(sortedLevels)
+
+	waves := make([][]int, 0, len(sortedLevels))
+	for _, level := range sortedLevels {
+		waves = append(waves, wavesByLevel[level])
+	}
+	return waves, nil
+}
+
+// getDeletionWaves sorts the indexes of resources into waves to be deleted one after another,
+// which is the reverse order of applying. Dependencies are ignored if they form a cycle.
+func getDeletionWaves(resources []*unstructured.Unstructured) [][]int {
+	waves, err := getResourceWaves(resources, getResourceDependencies(resources))
+	if err != nil {
+		klog.Warningf("failed to sort resources by dependencies: %v", err)
+		// kind priorities alone never form a cycle
+		waves, _ = getResourceWaves(resources, nil)
+	}
+	for i, j := 0, len(waves)-1; i < j; i, j = i+1, j-1 {
+		waves[i], waves[j] = waves[j], waves[i]
+	}
+	return waves
+}
+
+// SortResourcesForDeletion sorts resources in the reverse order of applying them.
+func SortResourcesForDeletion(resources []*unstructured.Unstructured) []*unstructured.Unstructured {
+	sorted := make([]*unstructured.Unstructured, 0, len(resources))
+	for _, wave := range getDeletionWaves(resources) {
+		for _, idx := range wave {
+			sorted = append(sorted, resources[idx])
+		}
+	}
+	return sorted
+}
+
+// waitForCRDEstablished waits until a CustomResourceDefinition is established, and then resets the RESTMapper
+// if possible, so that its custom resources could be mapped.
+func waitForCRDEstablished(ctx context.Context, dynamicClient dynamic.Interface, restMapper meta.RESTMapper,
+	crd *unstructured.Unstructured) error {
+	restMapping, err := restMapper.RESTMapping(crd.GroupVersionKind().GroupKind(), crd.GroupVersionKind().Version)
+	if err != nil {
+		return err
+	}
+
+	err = wait.PollImmediateWithContext(ctx, crdEstablishedInterval, crdEstablishedTimeout, func(ctx context.Context) (bool, error) {
+		curObj, err := dynamicClient.Resource(restMapping.Resource).Get(ctx, crd.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		conditions, _, _ := unstructured.NestedSlice(curObj.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if condition["type"] == "Established" && condition["status"] == string(metav1.ConditionTrue) {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("CustomResourceDefinition %s is not established: %v", crd.GetName(), err)
+	}
+
+	if resettable, ok := restMapper.(meta.ResettableRESTMapper); ok {
+		resettable.Reset()
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/clusternet/clusternet/pkg/known"
+)
+
+func newOrderingResource(apiVersion, kind, namespace, name, dependsOn string) *unstructured.Unstructured {
+	resource := &unstructured.Unstructured{}
+	resource.SetAPIVersion(apiVersion)
+	resource.SetKind(kind)
+	resource.SetNamespace(namespace)
+	resource.SetName(name)
+	if len(dependsOn) > 0 {
+		resource.SetAnnotations(map[string]string{known.DependsOnAnnotation: dependsOn})
+	}
+	return resource
+}
+
+func TestGetResourceWaves(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*unstructured.Unstructured
+		want      [][]int
+		wantErr   bool
+	}{
+		{
+			name: "kind priorities",
+			resources: []*unstructured.Unstructured{
+				newOrderingResource("example.com/v1", "Foo", "bar", "foo", ""),
+				newOrderingResource("apps/v1", "Deployment", "bar", "foo", ""),
+				newOrderingResource("v1", "Service", "bar", "foo", ""),
+				newOrderingResource("v1", "ConfigMap", "bar", "foo", ""),
+				newOrderingResource("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "foos.example.com", ""),
+				nil,
+				newOrderingResource("v1", "ServiceAccount", "bar", "foo", ""),
+				newOrderingResource("v1", "Namespace", "", "bar", ""),
+			},
+			want: [][]int{{7}, {4}, {6}, {3}, {1}, {0, 2}},
+		},
+		{
+			name: "explicit dependencies",
+			resources: []*unstructured.Unstructured{
+				newOrderingResource("apps/v1", "Deployment", "bar", "web", "Deployment/db"),
+				newOrderingResource("apps/v1", "Deployment", "bar", "db", "Secret/bar/db-password"),
+				newOrderingResource("v1", "Secret", "bar", "db-password", ""),
+				newOrderingResource("v1", "Service", "bar", "web", "Deployment/unknown"),
+			},
+			want: [][]int{{2}, {1}, {0, 3}},
+		},
+		{
+			name: "dependency in another namespace",
+			resources: []*unstructured.Unstructured{
+				newOrderingResource("v1", "ConfigMap", "foo", "app", "ConfigMap/bar/config"),
+				newOrderingResource("v1", "ConfigMap", "bar", "config", ""),
+				newOrderingResource("v1", "ConfigMap", "foo", "other", "ConfigMap/config"),
+			},
+			want: [][]int{{1, 2}, {0}},
+		},
+		{
+			name: "dependency cycle",
+			resources: []*unstructured.Unstructured{
+				newOrderingResource("v1", "ConfigMap", "bar", "a", "ConfigMap/b"),
+				newOrderingResource("v1", "ConfigMap", "bar", "b", "ConfigMap/a"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getResourceWaves(tt.resources, getResourceDependencies(tt.resources))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getResourceWaves() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getResourceWaves() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortResourcesForDeletion(t *testing.T) {
+	ns := newOrderingResource("v1", "Namespace", "", "bar", "")
+	cm := newOrderingResource("v1", "ConfigMap", "bar", "foo", "")
+	deploy := newOrderingResource("apps/v1", "Deployment", "bar", "foo", "")
+	a := newOrderingResource("v1", "Service", "bar", "a", "Service/b")
+	b := newOrderingResource("v1", "Service", "bar", "b", "Service/a")
+
+	got := SortResourcesForDeletion([]*unstructured.Unstructured{ns, deploy, cm})
+	if want := []*unstructured.Unstructured{deploy, cm, ns}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortResourcesForDeletion() = %v, want %v", got, want)
+	}
+
+	// dependency cycles fall back to kind priorities
+	got = SortResourcesForDeletion([]*unstructured.Unstructured{ns, a, b})
+	if want := []*unstructured.Unstructured{a, b, ns}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortResourcesForDeletion() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	clusternetclientset "github.com/clusternet/clusternet/pkg/generated/clientset/versioned"
+)
+
+// UpdateSubscriptionStatus applies mutateFunc on the status of a Subscription and updates it.
+// On conflicts, the latest Subscription is fetched and mutateFunc is applied again, so that
+// status fields maintained by other components are never overwritten with stale values.
+// Nothing is updated if mutateFunc makes no changes.
+func UpdateSubscriptionStatus(ctx context.Context, clusternetClient clusternetclientset.Interface,
+	sub *appsapi.Subscription, mutateFunc func(status *appsapi.SubscriptionStatus)) error {
+	klog.V(5).Infof("try to update Subscription %q status", klog.KObj(sub))
+
+	// make a copy so we don't mutate the shared cache
+	subCopy := sub.DeepCopy()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		oldStatus := subCopy.Status.DeepCopy()
+		mutateFunc(&subCopy.Status)
+		if reflect.DeepEqual(*oldStatus, subCopy.Status) {
+			return nil
+		}
+
+		_, err := clusternetClient.AppsV1alpha1().Subscriptions(subCopy.Namespace).UpdateStatus(ctx, subCopy, metav1.UpdateOptions{})
+		if err == nil || !apierrors.IsConflict(err) {
+			return err
+		}
+
+		updated, getErr := clusternetClient.AppsV1alpha1().Subscriptions(subCopy.Namespace).Get(ctx, subCopy.Name, metav1.GetOptions{})
+		if getErr != nil {
+			utilruntime.HandleError(fmt.Errorf("error getting updated Subscription %q: %v", klog.KObj(sub), getErr))
+			return err
+		}
+		subCopy = updated
+		return err
+	})
+}
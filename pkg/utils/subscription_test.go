@@ -0,0 +1,122 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+	"github.com/clusternet/clusternet/pkg/generated/clientset/versioned/fake"
+)
+
+func TestUpdateSubscriptionStatus(t *testing.T) {
+	readyCondition := metav1.Condition{
+		Type:    appsapi.SubscriptionReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "AllDescriptionsSucceeded",
+		Message: "all Descriptions have been deployed successfully",
+	}
+
+	tests := []struct {
+		name            string
+		conflicts       int
+		wantUpdates     int
+		wantBindings    []string
+		staleConditions []metav1.Condition
+	}{
+		{
+			name:         "update on the first attempt",
+			wantUpdates:  1,
+			wantBindings: []string{"ns1/c1"},
+		},
+		{
+			name:         "refetch the subscription on conflicts",
+			conflicts:    1,
+			wantUpdates:  2,
+			wantBindings: []string{"ns1/c1", "ns2/c2"},
+		},
+		{
+			name:            "no update without changes",
+			staleConditions: []metav1.Condition{readyCondition},
+			wantBindings:    []string{"ns1/c1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stale := &appsapi.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "default"},
+				Status: appsapi.SubscriptionStatus{
+					BindingClusters: []string{"ns1/c1"},
+					Conditions:      tt.staleConditions,
+				},
+			}
+			latest := stale.DeepCopy()
+			latest.Status.BindingClusters = []string{"ns1/c1", "ns2/c2"}
+
+			client := fake.NewSimpleClientset(latest)
+			conflicts := tt.conflicts
+			client.PrependReactor("update", "subscriptions", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "status" || conflicts == 0 {
+					return false, nil, nil
+				}
+				conflicts--
+				return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "subscriptions"}, "sub", nil)
+			})
+
+			err := UpdateSubscriptionStatus(context.TODO(), client, stale, func(status *appsapi.SubscriptionStatus) {
+				meta.SetStatusCondition(&status.Conditions, readyCondition)
+			})
+			if err != nil {
+				t.Fatalf("UpdateSubscriptionStatus() unexpected error: %v", err)
+			}
+
+			var updates int
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "update" && action.GetSubresource() == "status" {
+					updates++
+				}
+			}
+			if updates != tt.wantUpdates {
+				t.Errorf("UpdateSubscriptionStatus() sent %d updates, want %d", updates, tt.wantUpdates)
+			}
+			if updates == 0 {
+				return
+			}
+
+			got, err := client.AppsV1alpha1().Subscriptions("default").Get(context.TODO(), "sub", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Status.BindingClusters, tt.wantBindings) {
+				t.Errorf("UpdateSubscriptionStatus() binding clusters = %v, want %v", got.Status.BindingClusters, tt.wantBindings)
+			}
+			if !meta.IsStatusConditionTrue(got.Status.Conditions, appsapi.SubscriptionReady) {
+				t.Errorf("UpdateSubscriptionStatus() condition %s is not true", appsapi.SubscriptionReady)
+			}
+		})
+	}
+}
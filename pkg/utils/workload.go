@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	appsapi "github.com/clusternet/clusternet/pkg/apis/apps/v1alpha1"
+)
+
+// dividableWorkloadKinds are the kinds of workloads whose replicas could be divided into multiple clusters.
+var dividableWorkloadKinds = sets.NewString("Deployment", "StatefulSet", "ReplicaSet")
+
+// IsDividableFeed indicates whether the replicas of a feed could be divided.
+func IsDividableFeed(feed appsapi.Feed) bool {
+	return dividableWorkloadKinds.Has(feed.Kind)
+}
+
+// GetWorkloadReplicas returns the desired replicas declared in raw workload.
+// If spec.replicas is not set, the default value 1 will be returned.
+func GetWorkloadReplicas(raw []byte) (int32, error) {
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return 0, err
+	}
+
+	replicas, found, err := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get replicas of %s %s: %v", obj.GetKind(), klog.KObj(obj), err)
+	}
+	if !found {
+		return 1, nil
+	}
+	return int32(replicas), nil
+}
+
+// GetWorkloadPodTemplate returns the pod template declared in raw workload.
+func GetWorkloadPodTemplate(raw []byte) (*corev1.PodTemplateSpec, error) {
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(raw, obj); err != nil {
+		return nil, err
+	}
+
+	template, found, err := unstructured.NestedMap(obj.Object, "spec", "template")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod template of %s %s: %v", obj.GetKind(), klog.KObj(obj), err)
+	}
+	if !found {
+		return nil, fmt.Errorf("no pod template is found in %s %s", obj.GetKind(), klog.KObj(obj))
+	}
+
+	podTemplate := &corev1.PodTemplateSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(template, podTemplate); err != nil {
+		return nil, fmt.Errorf("failed to convert pod template of %s %s: %v", obj.GetKind(), klog.KObj(obj), err)
+	}
+	return podTemplate, nil
+}
+
+// GetPodResourceRequests returns the resource requests of a pod, which is
+// the larger one of the sum of all containers and the max of init containers, plus the pod overhead.
+func GetPodResourceRequests(podSpec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range podSpec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+	}
+	for _, container := range podSpec.InitContainers {
+		maxResourceList(requests, container.Resources.Requests)
+	}
+	addResourceList(requests, podSpec.Overhead)
+	return requests
+}
+
+// addResourceList adds the resources in newList to list.
+func addResourceList(list, newList corev1.ResourceList) {
+	for name, quantity := range newList {
+		if value, ok := list[name]; !ok {
+			list[name] = quantity.DeepCopy()
+		} else {
+			value.Add(quantity)
+			list[name] = value
+		}
+	}
+}
+
+// MultiplyResourceList returns the resources in list multiplied by times.
+// CPU is calculated in milli-units and others in units, so that large quantities like memory won't overflow.
+// Results exceeding the range of int64 are capped to math.MaxInt64.
+func MultiplyResourceList(list corev1.ResourceList, times int64) corev1.ResourceList {
+	result := make(corev1.ResourceList, len(list))
+	for name, quantity := range list {
+		if name == corev1.ResourceCPU {
+			result[name] = *resource.NewMilliQuantity(multiplyInt64(quantity.MilliValue(), times), quantity.Format)
+			continue
+		}
+		result[name] = *resource.NewQuantity(multiplyInt64(quantity.Value(), times), quantity.Format)
+	}
+	return result
+}
+
+// multiplyInt64 returns a*b for non-negative numbers, which is capped to math.MaxInt64.
+func multiplyInt64(a, b int64) int64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+	if a > math.MaxInt64/b {
+		return math.MaxInt64
+	}
+	return a * b
+}
+
+// maxResourceList sets list to the greater of list/newList for every resource in newList.
+func maxResourceList(list, newList corev1.ResourceList) {
+	for name, quantity := range newList {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Clusternet Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"math"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGetWorkloadReplicas(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    int32
+		wantErr bool
+	}{
+		{
+			name: "replicas set",
+			raw:  `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo","namespace":"bar"},"spec":{"replicas":5}}`,
+			want: 5,
+		},
+		{
+			name: "replicas unset",
+			raw:  `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo","namespace":"bar"},"spec":{}}`,
+			want: 1,
+		},
+		{
+			name: "zero replicas",
+			raw:  `{"apiVersion":"apps/v1","kind":"StatefulSet","metadata":{"name":"foo","namespace":"bar"},"spec":{"replicas":0}}`,
+			want: 0,
+		},
+		{
+			name:    "invalid replicas",
+			raw:     `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"foo","namespace":"bar"},"spec":{"replicas":"3"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetWorkloadReplicas([]byte(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetWorkloadReplicas() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetWorkloadReplicas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPodResourceRequests(t *testing.T) {
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("100Mi"),
+					},
+				},
+			},
+			{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("200m"),
+					},
+				},
+			},
+		},
+		InitContainers: []corev1.Container{
+			{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("500m"),
+						corev1.ResourceMemory: resource.MustParse("50Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	got := GetPodResourceRequests(podSpec)
+	if got.Cpu().MilliValue() != 500 {
+		t.Errorf("expected 500m cpu, got %s", got.Cpu())
+	}
+	if got.Memory().Value() != 100*1024*1024 {
+		t.Errorf("expected 100Mi memory, got %s", got.Memory())
+	}
+}
+
+func TestMultiplyResourceList(t *testing.T) {
+	list := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("500m"),
+		corev1.ResourceMemory: resource.MustParse("64Gi"),
+	}
+
+	got := MultiplyResourceList(list, 3)
+	if got.Cpu().MilliValue() != 1500 {
+		t.Errorf("expected 1500m cpu, got %s", got.Cpu())
+	}
+	if got.Memory().Value() != 3*64*1024*1024*1024 {
+		t.Errorf("expected 192Gi memory, got %s", got.Memory())
+	}
+
+	// 64Gi in milli-units times 1e6 replicas would overflow int64
+	got = MultiplyResourceList(list, 1000000)
+	if got.Memory().Value() != 1000000*64*1024*1024*1024 {
+		t.Errorf("expected 64000000Gi memory, got %s", got.Memory())
+	}
+
+	got = MultiplyResourceList(list, math.MaxInt64)
+	if got.Memory().Value() != math.MaxInt64 {
+		t.Errorf("expected memory to be capped, got %s", got.Memory())
+	}
+}